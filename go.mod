@@ -5,5 +5,9 @@ go 1.17
 require (
 	github.com/gogo/protobuf v1.3.2
 	github.com/google/go-cmp v0.5.8
+	github.com/google/uuid v1.3.0
 	gopkg.in/inf.v0 v0.9.1
+	sigs.k8s.io/yaml v1.2.0
 )
+
+require gopkg.in/yaml.v2 v2.4.0 // indirect
@@ -0,0 +1,101 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+// runAsUser forces the user the containers of the pods of a namespace run as.
+type runAsUser struct {
+	*Handler
+	calls *[]string
+}
+
+func (r runAsUser) Admit(ctx context.Context, a Attributes) error {
+	*r.calls = append(*r.calls, "runAsUser.Admit")
+	if pod, ok := a.GetObject().(*v1.Pod); ok && a.GetNamespace() == "payments" {
+		pod.Spec.SecurityContext = &v1.PodSecurityContext{RunAsUser: "payments"}
+	}
+	return nil
+}
+
+// deploymentDir rejects pods deployed outside of /opt/apps.
+type deploymentDir struct {
+	*Handler
+	calls *[]string
+}
+
+func (d deploymentDir) Validate(ctx context.Context, a Attributes) error {
+	*d.calls = append(*d.calls, "deploymentDir.Validate")
+	if pod, ok := a.GetObject().(*v1.Pod); ok {
+		for _, container := range pod.Spec.Containers {
+			if container.ImageDeploymentDir != "/opt/apps" {
+				return NewForbidden(a, fmt.Errorf("container %s must be deployed under /opt/apps", container.Name))
+			}
+		}
+	}
+	return nil
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	chain := NewChainHandler(
+		deploymentDir{NewHandler(Create, Update), &calls},
+		runAsUser{NewHandler(Create), &calls},
+	)
+	pod := &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "payments"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web", ImageDeploymentDir: "/opt/apps"}}},
+	}
+	attributes := func(op Operation) Attributes {
+		return NewAttributesRecord(pod, nil, v1.SchemeGroupVersion.WithKind("pod"), "payments", "web",
+			v1.SchemeGroupVersion.WithResource("pods"), "", op, nil)
+	}
+
+	if err := chain.Admit(context.Background(), attributes(Create)); err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.Validate(context.Background(), attributes(Create)); err != nil {
+		t.Fatal(err)
+	}
+	if pod.Spec.SecurityContext == nil || pod.Spec.SecurityContext.RunAsUser != "payments" {
+		t.Errorf("expected the mutating plugin to set the user, got %#v", pod.Spec.SecurityContext)
+	}
+	if expected := []string{"runAsUser.Admit", "deploymentDir.Validate"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+
+	// plugins only see the operations they handle
+	calls = nil
+	if err := chain.Admit(context.Background(), attributes(Update)); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no mutating plugin to run on update, got %v", calls)
+	}
+	if chain.Handles(Delete) {
+		t.Errorf("expected the chain not to handle deletes")
+	}
+
+	pod.Spec.Containers[0].ImageDeploymentDir = "/tmp"
+	err := chain.Validate(context.Background(), attributes(Update))
+	if err == nil {
+		t.Fatal("expected the validating plugin to reject the pod")
+	}
+	if expected := `pods.carry.i "web" is forbidden: container web must be deployed under /opt/apps`; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(Create, Delete)
+	for op, expected := range map[Operation]bool{Create: true, Update: false, Delete: true} {
+		if h.Handles(op) != expected {
+			t.Errorf("expected Handles(%s) to be %v", op, expected)
+		}
+	}
+}
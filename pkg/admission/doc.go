@@ -0,0 +1,26 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission contains the admission control framework of the api
+// server.
+//
+// Admission plugins see every create, update and delete request after it has
+// been decoded and before the object is stored. Mutating plugins
+// (MutationInterface) run first, in order, and may change the object; the
+// object is then validated, and the validating plugins (ValidationInterface)
+// run last, in order, and may only reject the request. Any plugin rejecting a
+// request stops it.
+package admission
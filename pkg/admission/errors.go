@@ -0,0 +1,51 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func extractResourceName(a Attributes) (name string, err error) {
+	name = a.GetName()
+	if len(name) == 0 && a.GetObject() != nil {
+		accessor, err := v1.Accessor(a.GetObject())
+		if err != nil {
+			return "", err
+		}
+		// a create with generate_name has no name yet
+		name = accessor.GetName()
+		if len(name) == 0 {
+			name = accessor.GetGenerateName()
+		}
+	}
+	return name, nil
+}
+
+// NewForbidden is a utility function to return a well-formatted admission control error response
+func NewForbidden(a Attributes, internalError error) error {
+	// do not double wrap an error of same type
+	if apierrors.IsForbidden(internalError) {
+		return internalError
+	}
+	name, err := extractResourceName(a)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	return apierrors.NewForbidden(a.GetResource().GroupResource(), name, internalError)
+}
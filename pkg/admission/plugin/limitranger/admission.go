@@ -0,0 +1,185 @@
+// Package limitranger contains the LimitRanger admission plugin, which
+// defaults the resources of the containers of new pods and enforces the
+// limits of the LimitRanges of their namespace.
+package limitranger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// PluginName indicates the name of admission plug-in
+const PluginName = "LimitRanger"
+
+// LimitRanger is an implementation of admission.Interface. On the creation of
+// a pod it sets the limits and requests its containers leave out to the
+// defaults of the LimitRanges of the namespace, then checks them against
+// their min and max.
+type LimitRanger struct {
+	*admission.Handler
+	storage storage.Interface
+}
+
+var _ admission.MutationInterface = &LimitRanger{}
+var _ admission.ValidationInterface = &LimitRanger{}
+
+// NewLimitRanger returns a LimitRanger plugin that reads the LimitRanges from
+// s.
+func NewLimitRanger(s storage.Interface) *LimitRanger {
+	return &LimitRanger{
+		Handler: admission.NewHandler(admission.Create),
+		storage: s,
+	}
+}
+
+// Admit sets the default resources of the containers of a new pod.
+func (l *LimitRanger) Admit(ctx context.Context, a admission.Attributes) error {
+	pod, ok := l.pod(a)
+	if !ok {
+		return nil
+	}
+	items, err := l.containerLimits(ctx, a.GetNamespace())
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		forEachContainer(pod, func(container *v1.Container) {
+			defaultResources(&container.Resources, item)
+		})
+	}
+	return nil
+}
+
+// Validate checks the resources of the containers of a new pod against the
+// min and max of the LimitRanges.
+func (l *LimitRanger) Validate(ctx context.Context, a admission.Attributes) error {
+	pod, ok := l.pod(a)
+	if !ok {
+		return nil
+	}
+	items, err := l.containerLimits(ctx, a.GetNamespace())
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, item := range items {
+		forEachContainer(pod, func(container *v1.Container) {
+			errs = append(errs, checkResources(container, item)...)
+		})
+	}
+	if len(errs) != 0 {
+		return admission.NewForbidden(a, fmt.Errorf("%s", strings.Join(errs, "; ")))
+	}
+	return nil
+}
+
+// pod returns the pod of the request, if it is one LimitRanger looks at.
+func (l *LimitRanger) pod(a admission.Attributes) (*v1.Pod, bool) {
+	if len(a.GetSubresource()) != 0 {
+		return nil, false
+	}
+	pod, ok := a.GetObject().(*v1.Pod)
+	return pod, ok
+}
+
+// containerLimits returns the container limits of the LimitRanges of
+// namespace.
+func (l *LimitRanger) containerLimits(ctx context.Context, namespace string) ([]v1.LimitRangeItem, error) {
+	list := &v1.LimitRangeList{}
+	opts := storage.ListOptions{Predicate: storage.Everything}
+	if err := l.storage.List(ctx, storage.KeyPrefix("limitrange", namespace), opts, list); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	var items []v1.LimitRangeItem
+	for _, limitRange := range list.Items {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type == v1.LimitTypeContainer {
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+func forEachContainer(pod *v1.Pod, f func(container *v1.Container)) {
+	for _, containers := range [][]v1.Container{
+		pod.Spec.InstallationContainers,
+		pod.Spec.InitContainers,
+		pod.Spec.Containers,
+		pod.Spec.UninstallationContainers,
+	} {
+		for i := range containers {
+			f(&containers[i])
+		}
+	}
+}
+
+// defaultResources sets the limits and requests missing from resources to
+// the defaults of item. A request without a default request defaults to the
+// limit.
+func defaultResources(resources *v1.ResourceRequirements, item v1.LimitRangeItem) {
+	for name, value := range item.Default {
+		if _, ok := resources.Limits[name]; !ok {
+			if resources.Limits == nil {
+				resources.Limits = v1.ResourceList{}
+			}
+			resources.Limits[name] = value.DeepCopy()
+		}
+	}
+	for name, value := range item.DefaultRequest {
+		if _, ok := resources.Requests[name]; !ok {
+			if resources.Requests == nil {
+				resources.Requests = v1.ResourceList{}
+			}
+			resources.Requests[name] = value.DeepCopy()
+		}
+	}
+	for name := range item.Default {
+		limit, ok := resources.Limits[name]
+		if _, hasRequest := resources.Requests[name]; ok && !hasRequest {
+			if resources.Requests == nil {
+				resources.Requests = v1.ResourceList{}
+			}
+			resources.Requests[name] = limit.DeepCopy()
+		}
+	}
+}
+
+// checkResources returns why the resources of container break the min and
+// max of item.
+func checkResources(container *v1.Container, item v1.LimitRangeItem) []string {
+	var errs []string
+	for _, name := range sortedNames(item.Min) {
+		min := item.Min[name]
+		if request, ok := container.Resources.Requests[name]; !ok {
+			errs = append(errs, fmt.Sprintf("minimum %s usage per container is %s, but no request is specified for container %s", name, min.String(), container.Name))
+		} else if request.Cmp(min) < 0 {
+			errs = append(errs, fmt.Sprintf("minimum %s usage per container is %s, but request of container %s is %s", name, min.String(), container.Name, request.String()))
+		}
+	}
+	for _, name := range sortedNames(item.Max) {
+		max := item.Max[name]
+		if limit, ok := container.Resources.Limits[name]; !ok {
+			errs = append(errs, fmt.Sprintf("maximum %s usage per container is %s, but no limit is specified for container %s", name, max.String(), container.Name))
+		} else if limit.Cmp(max) > 0 {
+			errs = append(errs, fmt.Sprintf("maximum %s usage per container is %s, but limit of container %s is %s", name, max.String(), container.Name, limit.String()))
+		}
+	}
+	return errs
+}
+
+func sortedNames(resources v1.ResourceList) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,134 @@
+package limitranger
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/resource"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/memory"
+)
+
+// newLimitRanger returns a LimitRanger reading a LimitRange of the payments
+// namespace limiting its containers to item.
+func newLimitRanger(t *testing.T, item v1.LimitRangeItem) *LimitRanger {
+	s := memory.New()
+	item.Type = v1.LimitTypeContainer
+	limitRange := &v1.LimitRange{
+		ObjectMeta: v1.ObjectMeta{Name: "limits", Namespace: "payments"},
+		Spec:       v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{item}},
+	}
+	if err := s.Create(context.Background(), storage.Key("limitrange", "payments", "limits"), limitRange, nil); err != nil {
+		t.Fatal(err)
+	}
+	return NewLimitRanger(s)
+}
+
+func podAttributes(pod *v1.Pod) admission.Attributes {
+	return admission.NewAttributesRecord(pod, nil, v1.SchemeGroupVersion.WithKind("pod"), pod.Namespace, pod.Name,
+		v1.SchemeGroupVersion.WithResource("pods"), "", admission.Create, &user.DefaultInfo{Name: "alice"})
+}
+
+func testPod(resources ...v1.ResourceRequirements) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "payments"}}
+	for _, r := range resources {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: "web", Resources: r})
+	}
+	return pod
+}
+
+func cpu(value string) v1.ResourceList {
+	return v1.ResourceList{v1.ResourceCPU: resource.MustParse(value)}
+}
+
+func TestDefaultResources(t *testing.T) {
+	plugin := newLimitRanger(t, v1.LimitRangeItem{
+		Default:        v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("1Gi")},
+		DefaultRequest: cpu("500m"),
+	})
+	pod := testPod(
+		v1.ResourceRequirements{},
+		v1.ResourceRequirements{Limits: cpu("2"), Requests: cpu("100m")},
+	)
+	pod.Spec.InitContainers = []v1.Container{{Name: "init"}}
+	if err := plugin.Admit(context.Background(), podAttributes(pod)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, container := range []v1.Container{pod.Spec.InitContainers[0], pod.Spec.Containers[0]} {
+		resources := container.Resources
+		if limit := resources.Limits[v1.ResourceCPU]; limit.Cmp(resource.MustParse("1")) != 0 {
+			t.Errorf("%s: expected the default cpu limit, got %s", container.Name, limit.String())
+		}
+		if request := resources.Requests[v1.ResourceCPU]; request.Cmp(resource.MustParse("500m")) != 0 {
+			t.Errorf("%s: expected the default cpu request, got %s", container.Name, request.String())
+		}
+		// without a default request the request defaults to the limit
+		if request := resources.Requests[v1.ResourceMemory]; request.Cmp(resource.MustParse("1Gi")) != 0 {
+			t.Errorf("%s: expected the memory request to default to the limit, got %s", container.Name, request.String())
+		}
+	}
+
+	// what the container sets is kept
+	resources := pod.Spec.Containers[1].Resources
+	if limit := resources.Limits[v1.ResourceCPU]; limit.Cmp(resource.MustParse("2")) != 0 {
+		t.Errorf("expected the cpu limit to be kept, got %s", limit.String())
+	}
+	if request := resources.Requests[v1.ResourceCPU]; request.Cmp(resource.MustParse("100m")) != 0 {
+		t.Errorf("expected the cpu request to be kept, got %s", request.String())
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	plugin := newLimitRanger(t, v1.LimitRangeItem{Min: cpu("100m"), Max: cpu("2")})
+	tests := []struct {
+		name      string
+		resources v1.ResourceRequirements
+		expected  string
+	}{
+		{
+			name:      "within limits",
+			resources: v1.ResourceRequirements{Limits: cpu("2"), Requests: cpu("100m")},
+		},
+		{
+			name:      "below min",
+			resources: v1.ResourceRequirements{Limits: cpu("1"), Requests: cpu("50m")},
+			expected:  "minimum cpu usage per container is 100m, but request of container web is 50m",
+		},
+		{
+			name:      "above max",
+			resources: v1.ResourceRequirements{Limits: cpu("3"), Requests: cpu("1")},
+			expected:  "maximum cpu usage per container is 2, but limit of container web is 3",
+		},
+		{
+			name:     "not specified",
+			expected: "minimum cpu usage per container is 100m, but no request is specified for container web; maximum cpu usage per container is 2, but no limit is specified for container web",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := plugin.Validate(context.Background(), podAttributes(testPod(test.resources)))
+			if len(test.expected) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected a forbidden error with %q, got %v", test.expected, err)
+			}
+		})
+	}
+
+	// other namespaces are not limited
+	pod := testPod(v1.ResourceRequirements{Limits: cpu("3")})
+	pod.Namespace = "default"
+	if err := plugin.Validate(context.Background(), podAttributes(pod)); err != nil {
+		t.Errorf("expected a pod of another namespace to be admitted, got %v", err)
+	}
+}
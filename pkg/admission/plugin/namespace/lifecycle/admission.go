@@ -0,0 +1,57 @@
+// Package lifecycle contains the NamespaceLifecycle admission plugin, which
+// stops new objects from being created in namespaces that are being deleted.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// PluginName indicates the name of admission plug-in
+const PluginName = "NamespaceLifecycle"
+
+// Lifecycle is an implementation of admission.Interface. It rejects the
+// creation of objects in a namespace that is terminating, as they would not
+// be cleaned up with the namespace.
+type Lifecycle struct {
+	*admission.Handler
+	storage storage.Interface
+}
+
+var _ admission.ValidationInterface = &Lifecycle{}
+
+// NewLifecycle returns a NamespaceLifecycle plugin that looks the namespaces
+// up in s.
+func NewLifecycle(s storage.Interface) *Lifecycle {
+	return &Lifecycle{
+		Handler: admission.NewHandler(admission.Create),
+		storage: s,
+	}
+}
+
+// Validate rejects the creation of an object in a terminating namespace.
+// Namespaces that do not exist are left alone.
+func (l *Lifecycle) Validate(ctx context.Context, a admission.Attributes) error {
+	// cluster scoped kinds, including namespaces themselves
+	if len(a.GetNamespace()) == 0 {
+		return nil
+	}
+
+	namespace := &v1.Namespace{}
+	err := l.storage.Get(ctx, storage.Key("namespace", "", a.GetNamespace()), storage.GetOptions{}, namespace)
+	if storage.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if namespace.Status.Phase == v1.NamespaceTerminating || !namespace.DeletionTime.IsZero() {
+		return admission.NewForbidden(a, fmt.Errorf("unable to create new content in namespace %s because it is being terminated", a.GetNamespace()))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/memory"
+)
+
+func podAttributes(namespace string) admission.Attributes {
+	pod := &v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: namespace}}
+	return admission.NewAttributesRecord(pod, nil, v1.SchemeGroupVersion.WithKind("pod"), namespace, pod.Name,
+		v1.SchemeGroupVersion.WithResource("pods"), "", admission.Create, &user.DefaultInfo{Name: "alice"})
+}
+
+func TestLifecycle(t *testing.T) {
+	s := memory.New()
+	ctx := context.Background()
+	for _, namespace := range []*v1.Namespace{
+		{ObjectMeta: v1.ObjectMeta{Name: "active"}, Status: v1.NamespaceStatus{Phase: v1.NamespaceActive}},
+		{ObjectMeta: v1.ObjectMeta{Name: "terminating"}, Status: v1.NamespaceStatus{Phase: v1.NamespaceTerminating}},
+	} {
+		if err := s.Create(ctx, storage.Key("namespace", "", namespace.Name), namespace, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plugin := NewLifecycle(s)
+
+	if err := plugin.Validate(ctx, podAttributes("active")); err != nil {
+		t.Errorf("expected a pod of an active namespace to be admitted, got %v", err)
+	}
+	if err := plugin.Validate(ctx, podAttributes("missing")); err != nil {
+		t.Errorf("expected a pod of a namespace that does not exist to be admitted, got %v", err)
+	}
+	err := plugin.Validate(ctx, podAttributes("terminating"))
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("expected a pod of a terminating namespace to be forbidden, got %v", err)
+	}
+	if expected := `pods.carry.i "web" is forbidden: unable to create new content in namespace terminating because it is being terminated`; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	namespace := &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "team-a"}}
+	attributes := admission.NewAttributesRecord(namespace, nil, v1.SchemeGroupVersion.WithKind("namespace"), "", namespace.Name,
+		v1.SchemeGroupVersion.WithResource("namespaces"), "", admission.Create, &user.DefaultInfo{Name: "alice"})
+	if err := plugin.Validate(ctx, attributes); err != nil {
+		t.Errorf("expected a namespace to be admitted, got %v", err)
+	}
+}
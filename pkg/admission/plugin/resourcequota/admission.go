@@ -0,0 +1,231 @@
+// Package resourcequota contains the ResourceQuota admission plugin, which
+// rejects the creation of objects that would take a namespace over one of
+// its ResourceQuotas.
+package resourcequota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/resource"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// PluginName indicates the name of admission plug-in
+const PluginName = "ResourceQuota"
+
+// QuotaAdmission is an implementation of admission.Interface. The usage of a
+// namespace is computed from the objects stored at the time of the request;
+// concurrent creates may together go over a quota.
+type QuotaAdmission struct {
+	*admission.Handler
+	storage storage.Interface
+}
+
+var _ admission.ValidationInterface = &QuotaAdmission{}
+
+// NewResourceQuota returns a ResourceQuota plugin that reads the quotas and
+// the objects counted against them from s.
+func NewResourceQuota(s storage.Interface) *QuotaAdmission {
+	return &QuotaAdmission{
+		Handler: admission.NewHandler(admission.Create),
+		storage: s,
+	}
+}
+
+// Validate rejects the object of the request if it takes the usage of its
+// namespace over the hard limit of a quota.
+func (q *QuotaAdmission) Validate(ctx context.Context, a admission.Attributes) error {
+	if len(a.GetSubresource()) != 0 || len(a.GetNamespace()) == 0 {
+		return nil
+	}
+	kind := a.GetKind().Kind
+	requested := usage(a.GetObject())
+	if len(requested) == 0 {
+		return nil
+	}
+
+	quotas := &v1.ResourceQuotaList{}
+	opts := storage.ListOptions{Predicate: storage.Everything}
+	if err := q.storage.List(ctx, storage.KeyPrefix("resourcequota", a.GetNamespace()), opts, quotas); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+
+	var used v1.ResourceList
+	for _, quota := range quotas.Items {
+		var failures []string
+		for _, name := range sortedNames(quota.Spec.Hard) {
+			request, ok := requested[name]
+			if !ok {
+				if kind == "pod" && isComputeResource(name) {
+					failures = append(failures, fmt.Sprintf("must specify %s", name))
+				}
+				continue
+			}
+			if used == nil {
+				var err error
+				if used, err = q.namespaceUsage(ctx, kind, a.GetNamespace()); err != nil {
+					return err
+				}
+			}
+			hard := quota.Spec.Hard[name]
+			current := used[name]
+			total := current.DeepCopy()
+			total.Add(request)
+			if total.Cmp(hard) > 0 {
+				failures = append(failures, fmt.Sprintf("requested: %s=%s, used: %s=%s, limited: %s=%s",
+					name, request.String(), name, current.String(), name, hard.String()))
+			}
+		}
+		if len(failures) != 0 {
+			return admission.NewForbidden(a, fmt.Errorf("exceeded quota: %s, %s", quota.Name, strings.Join(failures, ", ")))
+		}
+	}
+	return nil
+}
+
+// namespaceUsage returns the resources used by the objects of kind in
+// namespace.
+func (q *QuotaAdmission) namespaceUsage(ctx context.Context, kind, namespace string) (v1.ResourceList, error) {
+	var list runtime.Object
+	switch kind {
+	case "pod":
+		list = &v1.PodList{}
+	case "service":
+		list = &v1.ServiceList{}
+	case "configmap":
+		list = &v1.ConfigMapList{}
+	default:
+		return v1.ResourceList{}, nil
+	}
+	opts := storage.ListOptions{Predicate: storage.Everything}
+	if err := q.storage.List(ctx, storage.KeyPrefix(kind, namespace), opts, list); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	used := v1.ResourceList{}
+	add := func(obj runtime.Object) {
+		for name, value := range usage(obj) {
+			total := used[name]
+			total.Add(value)
+			used[name] = total
+		}
+	}
+	switch l := list.(type) {
+	case *v1.PodList:
+		for i := range l.Items {
+			add(&l.Items[i])
+		}
+	case *v1.ServiceList:
+		for i := range l.Items {
+			add(&l.Items[i])
+		}
+	case *v1.ConfigMapList:
+		for i := range l.Items {
+			add(&l.Items[i])
+		}
+	}
+	return used, nil
+}
+
+// usage returns the resources obj counts against a quota.
+func usage(obj runtime.Object) v1.ResourceList {
+	one := *resource.NewQuantity(1, resource.DecimalSI)
+	switch o := obj.(type) {
+	case *v1.Pod:
+		// pods that finished do not use resources any more
+		if o.Status.Phase == v1.PodSucceeded || o.Status.Phase == v1.PodFailed {
+			return v1.ResourceList{}
+		}
+		result := podComputeUsage(&o.Spec)
+		result[v1.ResourcePods] = one
+		return result
+	case *v1.Service:
+		return v1.ResourceList{v1.ResourceServices: one}
+	case *v1.ConfigMap:
+		return v1.ResourceList{v1.ResourceConfigMaps: one}
+	}
+	return nil
+}
+
+// podComputeUsage returns the requests and limits of a pod. The main
+// containers run together, the installation, init and uninstallation
+// containers run one by one, so a pod uses the larger of the sum of its main
+// containers and the most any other container uses.
+func podComputeUsage(spec *v1.PodSpec) v1.ResourceList {
+	requests := v1.ResourceList{}
+	limits := v1.ResourceList{}
+	for _, container := range spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+	for _, containers := range [][]v1.Container{spec.InstallationContainers, spec.InitContainers, spec.UninstallationContainers} {
+		for _, container := range containers {
+			maxResourceList(requests, container.Resources.Requests)
+			maxResourceList(limits, container.Resources.Limits)
+		}
+	}
+
+	result := v1.ResourceList{}
+	if value, ok := requests[v1.ResourceCPU]; ok {
+		result[v1.ResourceCPU] = value.DeepCopy()
+		result[v1.ResourceRequestsCPU] = value.DeepCopy()
+	}
+	if value, ok := requests[v1.ResourceMemory]; ok {
+		result[v1.ResourceMemory] = value.DeepCopy()
+		result[v1.ResourceRequestsMemory] = value.DeepCopy()
+	}
+	if value, ok := limits[v1.ResourceCPU]; ok {
+		result[v1.ResourceLimitsCPU] = value.DeepCopy()
+	}
+	if value, ok := limits[v1.ResourceMemory]; ok {
+		result[v1.ResourceLimitsMemory] = value.DeepCopy()
+	}
+	return result
+}
+
+func addResourceList(list, add v1.ResourceList) {
+	for name, value := range add {
+		total := list[name]
+		total.Add(value)
+		list[name] = total
+	}
+}
+
+func maxResourceList(list, other v1.ResourceList) {
+	for name, value := range other {
+		if current, ok := list[name]; !ok || value.Cmp(current) > 0 {
+			list[name] = value.DeepCopy()
+		}
+	}
+}
+
+// isComputeResource reports whether name is a compute resource every
+// container must declare for a quota on it to be enforced.
+func isComputeResource(name v1.ResourceName) bool {
+	switch name {
+	case v1.ResourceCPU, v1.ResourceMemory,
+		v1.ResourceRequestsCPU, v1.ResourceRequestsMemory,
+		v1.ResourceLimitsCPU, v1.ResourceLimitsMemory:
+		return true
+	}
+	return false
+}
+
+func sortedNames(resources v1.ResourceList) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
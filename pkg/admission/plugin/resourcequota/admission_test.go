@@ -0,0 +1,162 @@
+package resourcequota
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/resource"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/memory"
+)
+
+const namespace = "payments"
+
+// newQuotaStorage returns a storage holding a quota of namespace limited to
+// hard.
+func newQuotaStorage(t *testing.T, hard v1.ResourceList) storage.Interface {
+	s := memory.New()
+	quota := &v1.ResourceQuota{
+		ObjectMeta: v1.ObjectMeta{Name: "quota", Namespace: namespace},
+		Spec:       v1.ResourceQuotaSpec{Hard: hard},
+	}
+	create(t, s, "resourcequota", quota)
+	return s
+}
+
+func create(t *testing.T, s storage.Interface, kind string, obj runtime.Object) {
+	t.Helper()
+	meta := obj.(v1.Object)
+	if err := s.Create(context.Background(), storage.Key(kind, meta.GetNamespace(), meta.GetName()), obj, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func attributes(obj runtime.Object, kind, resource string) admission.Attributes {
+	meta := obj.(v1.Object)
+	return admission.NewAttributesRecord(obj, nil, v1.SchemeGroupVersion.WithKind(kind), meta.GetNamespace(), meta.GetName(),
+		v1.SchemeGroupVersion.WithResource(resource), "", admission.Create, &user.DefaultInfo{Name: "alice"})
+}
+
+func testPod(name string, cpu ...string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace}}
+	for _, value := range cpu {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+			Name: "web",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(value)},
+			},
+		})
+	}
+	return pod
+}
+
+func TestMustSpecifyComputeResources(t *testing.T) {
+	s := newQuotaStorage(t, v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2")})
+	plugin := NewResourceQuota(s)
+
+	err := plugin.Validate(context.Background(), attributes(testPod("web"), "pod", "pods"))
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "must specify requests.cpu") {
+		t.Errorf("expected a forbidden error asking for requests.cpu, got %v", err)
+	}
+	if err := plugin.Validate(context.Background(), attributes(testPod("web", "1"), "pod", "pods")); err != nil {
+		t.Errorf("expected a pod requesting cpu to be admitted, got %v", err)
+	}
+}
+
+func TestPodComputeUsage(t *testing.T) {
+	pod := testPod("web", "500m", "500m")
+	for _, cpu := range []string{"1500m", "1200m"} {
+		container := v1.Container{Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+		}}
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
+		pod.Spec.InstallationContainers = append(pod.Spec.InstallationContainers, container)
+	}
+
+	used := usage(pod)
+	// init and installation containers run one by one: the most any of them
+	// requests, not their sum
+	if cpu := used[v1.ResourceRequestsCPU]; cpu.Cmp(resource.MustParse("1500m")) != 0 {
+		t.Errorf("expected requests.cpu 1500m, got %s", cpu.String())
+	}
+	if pods := used[v1.ResourcePods]; pods.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("expected 1 pod, got %s", pods.String())
+	}
+
+	// the main containers run together
+	pod.Spec.Containers = append(pod.Spec.Containers, testPod("", "1").Spec.Containers...)
+	used = usage(pod)
+	if cpu := used[v1.ResourceRequestsCPU]; cpu.Cmp(resource.MustParse("2")) != 0 {
+		t.Errorf("expected requests.cpu 2, got %s", cpu.String())
+	}
+}
+
+func TestTerminatedPodsAreNotCounted(t *testing.T) {
+	s := newQuotaStorage(t, v1.ResourceList{
+		v1.ResourcePods:        resource.MustParse("2"),
+		v1.ResourceRequestsCPU: resource.MustParse("2"),
+	})
+	plugin := NewResourceQuota(s)
+
+	for _, phase := range []v1.PodPhase{v1.PodSucceeded, v1.PodFailed} {
+		pod := testPod(string(phase), "2")
+		pod.Status.Phase = phase
+		create(t, s, "pod", pod)
+	}
+	running := testPod("running", "1")
+	running.Status.Phase = v1.PodRunning
+	create(t, s, "pod", running)
+
+	if err := plugin.Validate(context.Background(), attributes(testPod("web", "1"), "pod", "pods")); err != nil {
+		t.Errorf("expected finished pods not to use the quota, got %v", err)
+	}
+	create(t, s, "pod", testPod("web", "1"))
+	err := plugin.Validate(context.Background(), attributes(testPod("db", "1"), "pod", "pods"))
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "requested: pods=1, used: pods=2, limited: pods=2") {
+		t.Errorf("expected the pod quota to be exceeded, got %v", err)
+	}
+}
+
+func TestObjectCounts(t *testing.T) {
+	s := newQuotaStorage(t, v1.ResourceList{
+		v1.ResourceServices:   resource.MustParse("1"),
+		v1.ResourceConfigMaps: resource.MustParse("2"),
+	})
+	plugin := NewResourceQuota(s)
+	ctx := context.Background()
+
+	service := &v1.Service{ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: namespace}}
+	if err := plugin.Validate(ctx, attributes(service, "service", "services")); err != nil {
+		t.Errorf("expected the first service to be admitted, got %v", err)
+	}
+	create(t, s, "service", service)
+	service = &v1.Service{ObjectMeta: v1.ObjectMeta{Name: "db", Namespace: namespace}}
+	if err := plugin.Validate(ctx, attributes(service, "service", "services")); !apierrors.IsForbidden(err) {
+		t.Errorf("expected the second service to be forbidden, got %v", err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		configMap := &v1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace}}
+		if err := plugin.Validate(ctx, attributes(configMap, "configmap", "configmaps")); err != nil {
+			t.Errorf("expected config map %s to be admitted, got %v", name, err)
+		}
+		create(t, s, "configmap", configMap)
+	}
+	configMap := &v1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "c", Namespace: namespace}}
+	err := plugin.Validate(ctx, attributes(configMap, "configmap", "configmaps"))
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "exceeded quota: quota, requested: configmaps=1, used: configmaps=2, limited: configmaps=2") {
+		t.Errorf("expected the config map quota to be exceeded, got %v", err)
+	}
+
+	// other namespaces have their own quotas
+	configMap.Namespace = "default"
+	if err := plugin.Validate(ctx, attributes(configMap, "configmap", "configmaps")); err != nil {
+		t.Errorf("expected a config map of another namespace to be admitted, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/opencarry/carry/pkg/admission"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/labels"
+)
+
+// FailurePolicyType specifies what happens when a webhook cannot be called or
+// does not answer properly.
+type FailurePolicyType string
+
+const (
+	// Ignore means that an error calling the webhook is ignored and the
+	// request goes on, the webhook fails open.
+	Ignore FailurePolicyType = "ignore"
+	// Fail means that an error calling the webhook causes the admission to
+	// fail, the webhook fails closed.
+	Fail FailurePolicyType = "fail"
+)
+
+const (
+	// DefaultTimeoutSeconds 默认的webhook调用超时时间
+	DefaultTimeoutSeconds = 10
+	// MaxTimeoutSeconds webhook调用超时时间的上限
+	MaxTimeoutSeconds = 30
+)
+
+// Webhook describes an admission webhook and the requests it is sent.
+type Webhook struct {
+	// Name identifies the webhook in errors, like "policy.security.example.com".
+	Name string `json:"name"`
+	// URL is where the AdmissionReview is POSTed to.
+	URL string `json:"url"`
+	// Rules describes what operations on what resources the webhook cares
+	// about. The webhook is sent a request if any rule matches; no rules
+	// match every request.
+	Rules []Rule `json:"rules,omitempty"`
+	// FailurePolicy defines how errors calling the webhook are handled,
+	// fail by default.
+	FailurePolicy FailurePolicyType `json:"failure_policy,omitempty"`
+	// NamespaceSelector decides whether to send the request for an object
+	// based on the labels of its namespace. Namespaces match their own
+	// labels, other cluster scoped objects always match. Nil matches
+	// everything.
+	NamespaceSelector *v1.LabelSelector `json:"namespace_selector,omitempty"`
+	// ObjectSelector decides whether to send the request based on the labels
+	// of the object; it matches if either the new or the old object match.
+	// Nil matches everything.
+	ObjectSelector *v1.LabelSelector `json:"object_selector,omitempty"`
+	// TimeoutSeconds is how long to wait for the webhook before the call
+	// fails, between 1 and 30. Defaults to 10 seconds.
+	TimeoutSeconds *int32 `json:"timeout_seconds,omitempty"`
+}
+
+// Rule matches requests by operation and resource.
+type Rule struct {
+	// Operations the rule matches, "*" matches all of them.
+	Operations []admission.Operation `json:"operations"`
+	// Resources the rule matches, like "pods" or "pods/status". "*" matches
+	// every resource and "*/status" the status of every resource.
+	Resources []string `json:"resources"`
+}
+
+// hook is a Webhook ready to be called.
+type hook struct {
+	Webhook
+	url               *url.URL
+	timeout           time.Duration
+	namespaceSelector labels.Selector
+	objectSelector    labels.Selector
+}
+
+// compile checks the configuration of webhook and fills in its defaults.
+func compile(webhook Webhook) (*hook, error) {
+	h := &hook{Webhook: webhook}
+	if len(webhook.Name) == 0 {
+		return nil, fmt.Errorf("webhook name must be set")
+	}
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return nil, fmt.Errorf("webhook %s: invalid url: %v", webhook.Name, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("webhook %s: url must use https or http, got %q", webhook.Name, webhook.URL)
+	}
+	h.url = u
+
+	switch webhook.FailurePolicy {
+	case "":
+		h.FailurePolicy = Fail
+	case Fail, Ignore:
+	default:
+		return nil, fmt.Errorf("webhook %s: unsupported failure_policy %q", webhook.Name, webhook.FailurePolicy)
+	}
+
+	h.timeout = DefaultTimeoutSeconds * time.Second
+	if webhook.TimeoutSeconds != nil {
+		seconds := *webhook.TimeoutSeconds
+		if seconds < 1 || seconds > MaxTimeoutSeconds {
+			return nil, fmt.Errorf("webhook %s: timeout_seconds must be between 1 and %d", webhook.Name, MaxTimeoutSeconds)
+		}
+		h.timeout = time.Duration(seconds) * time.Second
+	}
+
+	for _, rule := range webhook.Rules {
+		if len(rule.Operations) == 0 || len(rule.Resources) == 0 {
+			return nil, fmt.Errorf("webhook %s: rules must list operations and resources", webhook.Name)
+		}
+	}
+
+	// a nil selector selects everything here, unlike in LabelSelectorAsSelector
+	h.namespaceSelector, h.objectSelector = labels.Everything(), labels.Everything()
+	if webhook.NamespaceSelector != nil {
+		if h.namespaceSelector, err = v1.LabelSelectorAsSelector(webhook.NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("webhook %s: invalid namespace_selector: %v", webhook.Name, err)
+		}
+	}
+	if webhook.ObjectSelector != nil {
+		if h.objectSelector, err = v1.LabelSelectorAsSelector(webhook.ObjectSelector); err != nil {
+			return nil, fmt.Errorf("webhook %s: invalid object_selector: %v", webhook.Name, err)
+		}
+	}
+	return h, nil
+}
+
+// matchesRules reports whether the webhook is interested in the operation
+// and resource of a.
+func (h *hook) matchesRules(a admission.Attributes) bool {
+	if len(h.Rules) == 0 {
+		return true
+	}
+	resource := a.GetResource().Resource
+	if len(a.GetSubresource()) != 0 {
+		resource += "/" + a.GetSubresource()
+	}
+	for _, rule := range h.Rules {
+		if matchesOperation(rule.Operations, a.GetOperation()) && matchesResource(rule.Resources, resource, a.GetSubresource()) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesOperation(operations []admission.Operation, operation admission.Operation) bool {
+	for _, op := range operations {
+		if op == "*" || op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesResource(resources []string, resource, subresource string) bool {
+	for _, r := range resources {
+		switch {
+		case r == resource:
+			return true
+		case r == "*" && len(subresource) == 0:
+			return true
+		case r == "*/*":
+			return true
+		case len(subresource) != 0 && r == "*/"+subresource:
+			return true
+		}
+	}
+	return false
+}
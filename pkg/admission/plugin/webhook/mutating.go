@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/opencarry/carry/pkg/admission"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/util/jsonpatch"
+)
+
+// MutatingPluginName indicates the name of admission plug-in
+const MutatingPluginName = "MutatingAdmissionWebhook"
+
+// MutatingWebhook is an admission plugin that calls the mutating webhooks in
+// order, each one seeing the object as patched by the ones before.
+type MutatingWebhook struct {
+	*dispatcher
+}
+
+var _ admission.MutationInterface = &MutatingWebhook{}
+
+// NewMutatingWebhook returns a plugin calling webhooks with client, the
+// default client if nil. The labels of namespaces are read from s.
+func NewMutatingWebhook(webhooks []Webhook, s storage.Interface, client *http.Client) (*MutatingWebhook, error) {
+	d, err := newDispatcher(webhooks, s, client)
+	if err != nil {
+		return nil, err
+	}
+	return &MutatingWebhook{d}, nil
+}
+
+// Admit calls the matching webhooks and applies the patches they return to
+// the object of the request.
+func (m *MutatingWebhook) Admit(ctx context.Context, a admission.Attributes) error {
+	for _, h := range m.hooks {
+		call, err := m.shouldCall(ctx, h, a)
+		if err != nil {
+			return err
+		}
+		if !call {
+			continue
+		}
+		response, err := m.call(ctx, h, a)
+		if err == nil && response.Allowed && len(response.Patch) != 0 {
+			err = applyPatch(a, response)
+		}
+		if err != nil {
+			if err := callError(h, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if !response.Allowed {
+			return deniedError(h, a, response)
+		}
+	}
+	return nil
+}
+
+// applyPatch applies the patch of response to the object of a in place.
+func applyPatch(a admission.Attributes, response *v1.AdmissionResponse) error {
+	obj := a.GetObject()
+	if obj == nil {
+		return fmt.Errorf("there is no object to patch")
+	}
+	if response.PatchType == nil || *response.PatchType != v1.PatchTypeJSONPatch {
+		return fmt.Errorf("unsupported patch_type %v", response.PatchType)
+	}
+	patch, err := jsonpatch.DecodePatch(response.Patch)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %v", err)
+	}
+	codec := scheme.Codecs.LegacyCodec(a.GetKind().GroupVersion())
+	original, err := runtime.Encode(codec, obj)
+	if err != nil {
+		return err
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return fmt.Errorf("failed to apply the patch: %v", err)
+	}
+	out := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	decoded, _, err := codec.Decode(patched, nil, out)
+	if err != nil {
+		return fmt.Errorf("failed to decode the patched object: %v", err)
+	}
+	if reflect.TypeOf(decoded) != reflect.TypeOf(obj) {
+		return fmt.Errorf("the patch changed the kind of the object")
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(decoded).Elem())
+	return nil
+}
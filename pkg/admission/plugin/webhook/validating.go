@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/opencarry/carry/pkg/admission"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// ValidatingPluginName indicates the name of admission plug-in
+const ValidatingPluginName = "ValidatingAdmissionWebhook"
+
+// ValidatingWebhook is an admission plugin that calls the validating webhooks
+// in order. The request is denied as soon as one of them denies it.
+type ValidatingWebhook struct {
+	*dispatcher
+}
+
+var _ admission.ValidationInterface = &ValidatingWebhook{}
+
+// NewValidatingWebhook returns a plugin calling webhooks with client, the
+// default client if nil. The labels of namespaces are read from s.
+func NewValidatingWebhook(webhooks []Webhook, s storage.Interface, client *http.Client) (*ValidatingWebhook, error) {
+	d, err := newDispatcher(webhooks, s, client)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatingWebhook{d}, nil
+}
+
+// Validate calls the matching webhooks.
+func (v *ValidatingWebhook) Validate(ctx context.Context, a admission.Attributes) error {
+	for _, h := range v.hooks {
+		call, err := v.shouldCall(ctx, h, a)
+		if err != nil {
+			return err
+		}
+		if !call {
+			continue
+		}
+		response, err := v.call(ctx, h, a)
+		if err == nil && len(response.Patch) != 0 {
+			err = fmt.Errorf("validating webhooks may not return a patch")
+		}
+		if err != nil {
+			if err := callError(h, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if !response.Allowed {
+			return deniedError(h, a, response)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Package webhook contains the admission plugins that send the requests to
+// the api server to external HTTP services.
+//
+// For every matching webhook the plugins POST an AdmissionReview carrying the
+// request, with the object, the old object and the operation, to the url of
+// the webhook, which answers with an AdmissionReview carrying the response.
+// Mutating webhooks may change the object with a JSON patch in their
+// response; validating webhooks can only allow or deny the request.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// maxResponseBytes is the limit on the size of the response of a webhook.
+const maxResponseBytes = 3 * 1024 * 1024
+
+// dispatcher sends the admission requests to webhooks.
+type dispatcher struct {
+	*admission.Handler
+	hooks   []*hook
+	storage storage.Interface
+	client  *http.Client
+}
+
+func newDispatcher(webhooks []Webhook, s storage.Interface, client *http.Client) (*dispatcher, error) {
+	d := &dispatcher{
+		Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+		storage: s,
+		client:  client,
+	}
+	if d.client == nil {
+		d.client = http.DefaultClient
+	}
+	names := map[string]bool{}
+	for _, webhook := range webhooks {
+		h, err := compile(webhook)
+		if err != nil {
+			return nil, err
+		}
+		if names[h.Name] {
+			return nil, fmt.Errorf("duplicate webhook name %q", h.Name)
+		}
+		names[h.Name] = true
+		d.hooks = append(d.hooks, h)
+	}
+	return d, nil
+}
+
+// shouldCall reports whether h is to be sent the request of a.
+func (d *dispatcher) shouldCall(ctx context.Context, h *hook, a admission.Attributes) (bool, error) {
+	if !h.matchesRules(a) {
+		return false, nil
+	}
+	if !h.objectSelector.Empty() && !matchesLabels(h.objectSelector, a.GetObject()) && !matchesLabels(h.objectSelector, a.GetOldObject()) {
+		return false, nil
+	}
+	if h.namespaceSelector.Empty() {
+		return true, nil
+	}
+	if len(a.GetNamespace()) == 0 {
+		if a.GetKind().Kind != "namespace" {
+			// other cluster scoped objects are not selected by namespace
+			return true, nil
+		}
+		return matchesLabels(h.namespaceSelector, a.GetObject()) || matchesLabels(h.namespaceSelector, a.GetOldObject()), nil
+	}
+	namespace := &v1.Namespace{}
+	err := d.storage.Get(ctx, storage.Key("namespace", "", a.GetNamespace()), storage.GetOptions{IgnoreNotFound: true}, namespace)
+	if err != nil {
+		return false, apierrors.NewInternalError(err)
+	}
+	return h.namespaceSelector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+func matchesLabels(selector labels.Selector, obj runtime.Object) bool {
+	if obj == nil {
+		return false
+	}
+	metadata, err := v1.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(metadata.GetLabels()))
+}
+
+// call sends the request of a to h and returns its response. It returns an
+// error if the webhook could not be called or did not answer properly.
+func (d *dispatcher) call(ctx context.Context, h *hook, a admission.Attributes) (*v1.AdmissionResponse, error) {
+	request, err := newAdmissionRequest(a)
+	if err != nil {
+		return nil, err
+	}
+	review := &v1.AdmissionReview{Request: request}
+	body, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), review)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", runtime.ContentTypeJSON)
+	req.Header.Set("Accept", runtime.ContentTypeJSON)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResponseBytes {
+		return nil, fmt.Errorf("response larger than %d bytes", maxResponseBytes)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+
+	answer := &v1.AdmissionReview{}
+	if err := json.Unmarshal(data, answer); err != nil {
+		return nil, fmt.Errorf("failed to decode the response: %v", err)
+	}
+	if answer.Response == nil {
+		return nil, fmt.Errorf("the response does not contain a response")
+	}
+	if answer.Response.UID != request.UID {
+		return nil, fmt.Errorf("expected the response for request %s, got %s", request.UID, answer.Response.UID)
+	}
+	return answer.Response, nil
+}
+
+func newAdmissionRequest(a admission.Attributes) (*v1.AdmissionRequest, error) {
+	request := &v1.AdmissionRequest{
+		UID:         v1.UID(uuid.New().String()),
+		Kind:        a.GetKind().Kind,
+		Resource:    a.GetResource().Resource,
+		SubResource: a.GetSubresource(),
+		Name:        a.GetName(),
+		Namespace:   a.GetNamespace(),
+		Operation:   string(a.GetOperation()),
+	}
+	if userInfo := a.GetUserInfo(); userInfo != nil {
+		request.UserInfo = v1.UserInfo{
+			Username: userInfo.GetName(),
+			UID:      userInfo.GetUID(),
+			Groups:   userInfo.GetGroups(),
+			Extra:    userInfo.GetExtra(),
+		}
+	}
+	var err error
+	if request.Object, err = encodeObject(a.GetObject(), a); err != nil {
+		return nil, err
+	}
+	if request.OldObject, err = encodeObject(a.GetOldObject(), a); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// encodeObject encodes obj, an object of the request of a, in the group
+// version of the request.
+func encodeObject(obj runtime.Object, a admission.Attributes) (json.RawMessage, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	return runtime.Encode(scheme.Codecs.LegacyCodec(a.GetKind().GroupVersion()), obj)
+}
+
+// callError returns the error of a request for which h could not be called,
+// nil if h fails open.
+func callError(h *hook, err error) error {
+	if h.FailurePolicy == Ignore {
+		return nil
+	}
+	return apierrors.NewInternalError(fmt.Errorf("failed calling webhook %q: %v", h.Name, err))
+}
+
+// deniedError returns the error of a request h denied.
+func deniedError(h *hook, a admission.Attributes, response *v1.AdmissionResponse) error {
+	message := "without explanation"
+	code := int32(http.StatusForbidden)
+	reason := v1.StatusReasonForbidden
+	if response.Result != nil {
+		if len(response.Result.Message) != 0 {
+			message = response.Result.Message
+		}
+		if response.Result.Code != 0 {
+			code = response.Result.Code
+		}
+		if len(response.Result.Reason) != 0 {
+			reason = response.Result.Reason
+		}
+	}
+	return &apierrors.StatusError{ErrStatus: v1.Status{
+		Status:  v1.StatusFailure,
+		Code:    code,
+		Reason:  reason,
+		Message: fmt.Sprintf("admission webhook %q denied the request: %s", h.Name, message),
+		Details: &v1.StatusDetails{
+			Name:  a.GetName(),
+			Group: a.GetResource().Group,
+			Kind:  a.GetResource().Resource,
+		},
+	}}
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/memory"
+)
+
+// newWebhookServer serves a webhook answering the reviews it receives with
+// respond.
+func newWebhookServer(t *testing.T, respond func(request *v1.AdmissionRequest) *v1.AdmissionResponse) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		review := &v1.AdmissionReview{}
+		if err := json.NewDecoder(req.Body).Decode(review); err != nil || review.Request == nil {
+			http.Error(w, "bad review", http.StatusBadRequest)
+			return
+		}
+		response := respond(review.Request)
+		if response == nil {
+			// hang until the client gives up
+			<-req.Context().Done()
+			return
+		}
+		response.UID = review.Request.UID
+		json.NewEncoder(w).Encode(&v1.AdmissionReview{Response: response})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func podAttributes(pod *v1.Pod, operation admission.Operation) admission.Attributes {
+	return admission.NewAttributesRecord(pod, nil, v1.SchemeGroupVersion.WithKind("pod"), pod.Namespace, pod.Name,
+		v1.SchemeGroupVersion.WithResource("pods"), "", operation, &user.DefaultInfo{Name: "alice", Groups: []string{"dev"}})
+}
+
+func testPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "payments", Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name: "web", Image: "web:1", ImageDeploymentDir: "/opt/apps/web",
+		}}},
+	}
+}
+
+func TestMutatingWebhook(t *testing.T) {
+	var received *v1.AdmissionRequest
+	server := newWebhookServer(t, func(request *v1.AdmissionRequest) *v1.AdmissionResponse {
+		received = request
+		patchType := v1.PatchTypeJSONPatch
+		return &v1.AdmissionResponse{
+			Allowed:   true,
+			PatchType: &patchType,
+			Patch:     json.RawMessage(`[{"op": "add", "path": "/spec/security_context", "value": {"run_as_user": "payments"}}]`),
+		}
+	})
+	plugin, err := NewMutatingWebhook([]Webhook{{
+		Name:  "run-as-user",
+		URL:   server.URL,
+		Rules: []Rule{{Operations: []admission.Operation{admission.Create}, Resources: []string{"pods"}}},
+	}}, memory.New(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := testPod()
+	if err := plugin.Admit(context.Background(), podAttributes(pod, admission.Create)); err != nil {
+		t.Fatal(err)
+	}
+	if pod.Spec.SecurityContext == nil || pod.Spec.SecurityContext.RunAsUser != "payments" {
+		t.Errorf("expected the patch to set the user, got %#v", pod.Spec.SecurityContext)
+	}
+	if pod.Name != "web" || pod.Spec.Containers[0].Image != "web:1" {
+		t.Errorf("expected the rest of the pod to be kept, got %#v", pod)
+	}
+	if received == nil || received.Operation != "create" || received.Kind != "pod" || received.UserInfo.Username != "alice" {
+		t.Fatalf("unexpected request %#v", received)
+	}
+	if !strings.Contains(string(received.Object), `"image_deployment_dir":"/opt/apps/web"`) || len(received.OldObject) != 0 {
+		t.Errorf("unexpected objects in the request: %s %s", received.Object, received.OldObject)
+	}
+
+	// updates do not match the rules
+	received = nil
+	if err := plugin.Admit(context.Background(), podAttributes(testPod(), admission.Update)); err != nil || received != nil {
+		t.Errorf("expected the webhook not to be called on update, got %v %#v", err, received)
+	}
+}
+
+func TestValidatingWebhook(t *testing.T) {
+	server := newWebhookServer(t, func(request *v1.AdmissionRequest) *v1.AdmissionResponse {
+		pod := &v1.Pod{}
+		json.Unmarshal(request.Object, pod)
+		for _, container := range pod.Spec.Containers {
+			if !strings.HasPrefix(container.ImageDeploymentDir, "/opt/apps/") {
+				return &v1.AdmissionResponse{Result: &v1.Status{Message: "containers must be deployed under /opt/apps"}}
+			}
+		}
+		return &v1.AdmissionResponse{Allowed: true}
+	})
+	plugin, err := NewValidatingWebhook([]Webhook{{Name: "deployment-dir", URL: server.URL}}, memory.New(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := plugin.Validate(context.Background(), podAttributes(testPod(), admission.Create)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	pod := testPod()
+	pod.Spec.Containers[0].ImageDeploymentDir = "/tmp/web"
+	err = plugin.Validate(context.Background(), podAttributes(pod, admission.Create))
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("expected the pod to be forbidden, got %v", err)
+	}
+	if expected := `admission webhook "deployment-dir" denied the request: containers must be deployed under /opt/apps`; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestFailurePolicy(t *testing.T) {
+	server := newWebhookServer(t, func(request *v1.AdmissionRequest) *v1.AdmissionResponse { return nil })
+	timeout := int32(1)
+	for _, policy := range []FailurePolicyType{Ignore, Fail} {
+		plugin, err := NewValidatingWebhook([]Webhook{{
+			Name: "slow", URL: server.URL, FailurePolicy: policy, TimeoutSeconds: &timeout,
+		}}, memory.New(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = plugin.Validate(context.Background(), podAttributes(testPod(), admission.Create))
+		switch policy {
+		case Ignore:
+			if err != nil {
+				t.Errorf("expected the webhook to fail open, got %v", err)
+			}
+		case Fail:
+			if !apierrors.IsInternalError(err) || !strings.Contains(err.Error(), `failed calling webhook "slow"`) {
+				t.Errorf("expected the webhook to fail closed, got %v", err)
+			}
+		}
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	store := memory.New()
+	for name, labels := range map[string]map[string]string{
+		"payments": {"team": "payments"},
+		"sandbox":  {"team": "sandbox"},
+	} {
+		namespace := &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels}}
+		if err := store.Create(context.Background(), storage.Key("namespace", "", name), namespace, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	calls := 0
+	server := newWebhookServer(t, func(request *v1.AdmissionRequest) *v1.AdmissionResponse {
+		calls++
+		return &v1.AdmissionResponse{Allowed: true}
+	})
+	plugin, err := NewValidatingWebhook([]Webhook{{
+		Name:              "payments-only",
+		URL:               server.URL,
+		NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		ObjectSelector: &v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{{
+			Key: "skip-webhook", Operator: v1.LabelSelectorOpDoesNotExist,
+		}}},
+	}}, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+		called    bool
+	}{
+		{"selected namespace", "payments", nil, true},
+		{"other namespace", "sandbox", nil, false},
+		{"namespace that does not exist", "missing", nil, false},
+		{"object opting out", "payments", map[string]string{"skip-webhook": "true"}, false},
+	}
+	for _, tc := range testCases {
+		calls = 0
+		pod := testPod()
+		pod.Namespace, pod.Labels = tc.namespace, tc.labels
+		if err := plugin.Validate(context.Background(), podAttributes(pod, admission.Create)); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if (calls != 0) != tc.called {
+			t.Errorf("%s: expected the webhook to be called: %v, got %d calls", tc.name, tc.called, calls)
+		}
+	}
+}
+
+func TestInvalidConfiguration(t *testing.T) {
+	timeout := int32(60)
+	for name, webhooks := range map[string][]Webhook{
+		"no name":         {{URL: "http://localhost"}},
+		"invalid url":     {{Name: "a", URL: "localhost:8080"}},
+		"unknown policy":  {{Name: "a", URL: "http://localhost", FailurePolicy: "maybe"}},
+		"timeout too big": {{Name: "a", URL: "http://localhost", TimeoutSeconds: &timeout}},
+		"empty rule":      {{Name: "a", URL: "http://localhost", Rules: []Rule{{}}}},
+		"duplicate names": {{Name: "a", URL: "http://localhost"}, {Name: "a", URL: "http://localhost"}},
+	} {
+		if _, err := NewMutatingWebhook(webhooks, memory.New(), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package defaulting
+
+import (
+	"strings"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+const (
+	// DefaultReplicas 副本数默认值
+	DefaultReplicas int64 = 1
+	// DefaultRevisionHistoryLimit 保留的历史版本数默认值
+	DefaultRevisionHistoryLimit int64 = 10
+	// DefaultProgressDeadlineSeconds Deployment进度超时时间默认值
+	DefaultProgressDeadlineSeconds int64 = 600
+	// DefaultBackoffLimit Job失败重试次数默认值
+	DefaultBackoffLimit int64 = 6
+)
+
+func SetDefaults_Deployment(obj *v1.Deployment) {
+	if obj.Spec.Replicas == nil {
+		obj.Spec.Replicas = int64Ptr(DefaultReplicas)
+	}
+	if len(obj.Spec.Strategy.Type) == 0 {
+		obj.Spec.Strategy.Type = v1.InplaceUpdateDeploymentStrategyType
+	}
+	if obj.Spec.RevisionHistoryLimit == nil {
+		obj.Spec.RevisionHistoryLimit = int64Ptr(DefaultRevisionHistoryLimit)
+	}
+	if obj.Spec.ProgressDeadlineSeconds == nil {
+		obj.Spec.ProgressDeadlineSeconds = int64Ptr(DefaultProgressDeadlineSeconds)
+	}
+}
+
+func SetDefaults_ReplicaSet(obj *v1.ReplicaSet) {
+	if obj.Spec.Replicas == nil {
+		obj.Spec.Replicas = int64Ptr(DefaultReplicas)
+	}
+	if len(obj.Spec.Strategy.Type) == 0 {
+		obj.Spec.Strategy.Type = v1.InplaceUpdateReplicaSetStrategyType
+	}
+}
+
+func SetDefaults_StatefulSet(obj *v1.StatefulSet) {
+	if obj.Spec.Replicas == nil {
+		obj.Spec.Replicas = int64Ptr(DefaultReplicas)
+	}
+	if len(obj.Spec.Strategy.Type) == 0 {
+		obj.Spec.Strategy.Type = v1.InplaceUpdateStatefulSetStrategyType
+	}
+	if obj.Spec.RevisionHistoryLimit == nil {
+		obj.Spec.RevisionHistoryLimit = int64Ptr(DefaultRevisionHistoryLimit)
+	}
+}
+
+func SetDefaults_DaemonSet(obj *v1.DaemonSet) {
+	if len(obj.Spec.Strategy.Type) == 0 {
+		obj.Spec.Strategy.Type = v1.InplaceUpdateDaemonSetStrategyType
+	}
+	if obj.Spec.RevisionHistoryLimit == nil {
+		obj.Spec.RevisionHistoryLimit = int64Ptr(DefaultRevisionHistoryLimit)
+	}
+}
+
+func SetDefaults_Job(obj *v1.Job) {
+	if obj.Spec.BackoffLimit == nil {
+		obj.Spec.BackoffLimit = int64Ptr(DefaultBackoffLimit)
+	}
+}
+
+func SetDefaults_PodSpec(obj *v1.PodSpec) {
+	if len(obj.RestartPolicy) == 0 {
+		obj.RestartPolicy = v1.RestartPolicyAlways
+	}
+	if obj.TerminationGracePeriodSeconds == nil {
+		obj.TerminationGracePeriodSeconds = int64Ptr(v1.DefaultTerminationGracePeriodSeconds)
+	}
+	// 只有会运行结束的Pod（如Job的Pod）才设置存活时间，常驻的Pod不能被主动终止
+	if obj.ActiveDeadlineSeconds == nil && obj.RestartPolicy != v1.RestartPolicyAlways {
+		obj.ActiveDeadlineSeconds = int64Ptr(v1.DefaultActiveDeadlineSeconds)
+	}
+	if obj.Suspended == nil {
+		suspended := v1.DefaultSuspended
+		obj.Suspended = &suspended
+	}
+	if len(obj.SchedulerName) == 0 {
+		obj.SchedulerName = v1.DefaultSchedulerName
+	}
+}
+
+func SetDefaults_Container(obj *v1.Container) {
+	if len(obj.ImagePullPolicy) == 0 {
+		obj.ImagePullPolicy = defaultPullPolicy(obj.Image)
+	}
+}
+
+// defaultPullPolicy 镜像tag为latest时总是拉取，其它情况本地不存在才拉取。
+// 没有指定tag等同于latest，按digest引用的镜像内容不会变化。
+func defaultPullPolicy(image string) v1.PullPolicy {
+	if strings.Contains(image, "@") {
+		return v1.PullIfNotPresent
+	}
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	if len(tag) == 0 || tag == "latest" {
+		return v1.PullAlways
+	}
+	return v1.PullIfNotPresent
+}
+
+func SetDefaults_ContainerPort(obj *v1.ContainerPort) {
+	if len(obj.Protocol) == 0 {
+		obj.Protocol = v1.ProtocolTCP
+	}
+}
+
+func SetDefaults_ConfigMapVolumeSource(obj *v1.ConfigMapVolumeSource) {
+	if obj.DefaultMode == nil {
+		obj.DefaultMode = int64Ptr(v1.ConfigMapVolumeSourceDefaultMode)
+	}
+}
+
+func SetDefaults_ServicePort(obj *v1.ServicePort) {
+	if len(obj.Protocol) == 0 {
+		obj.Protocol = v1.ProtocolTCP
+	}
+}
+
+func SetDefaults_NamespaceStatus(obj *v1.NamespaceStatus) {
+	if len(obj.Phase) == 0 {
+		obj.Phase = v1.NamespaceActive
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -0,0 +1,135 @@
+package defaulting_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opencarry/carry/pkg/api/defaulting"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestDefaultDeploymentFromWire(t *testing.T) {
+	data := []byte(`{
+		"kind": "deployment",
+		"api_version": "carry.i/v1",
+		"metadata": {"name": "foo", "namespace": "bar"},
+		"spec": {
+			"template": {
+				"spec": {
+					"containers": [{"name": "main", "image": "mountain/foo", "ports": [{"container_port": 80}]}],
+					"volumes": [{"name": "config", "configMap": {"name": "foo"}}]
+				}
+			}
+		}
+	}`)
+	obj, err := runtime.Decode(scheme.Codecs.UniversalDecoder(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment := obj.(*v1.Deployment)
+
+	if *deployment.Spec.Replicas != 1 || *deployment.Spec.RevisionHistoryLimit != 10 || *deployment.Spec.ProgressDeadlineSeconds != 600 {
+		t.Errorf("unexpected deployment spec defaults: %#v", deployment.Spec)
+	}
+	if deployment.Spec.Strategy.Type != v1.InplaceUpdateDeploymentStrategyType {
+		t.Errorf("unexpected strategy %q", deployment.Spec.Strategy.Type)
+	}
+	podSpec := deployment.Spec.Template.Spec
+	if podSpec.RestartPolicy != v1.RestartPolicyAlways || *podSpec.TerminationGracePeriodSeconds != 30 ||
+		podSpec.SchedulerName != v1.DefaultSchedulerName || podSpec.Suspended == nil || *podSpec.Suspended {
+		t.Errorf("unexpected pod spec defaults: %#v", podSpec)
+	}
+	if podSpec.ActiveDeadlineSeconds != nil {
+		t.Errorf("long running pods must not get an active deadline")
+	}
+	if podSpec.Containers[0].ImagePullPolicy != v1.PullAlways || podSpec.Containers[0].Ports[0].Protocol != v1.ProtocolTCP {
+		t.Errorf("unexpected container defaults: %#v", podSpec.Containers[0])
+	}
+	if *podSpec.Volumes[0].ConfigMap.DefaultMode != v1.ConfigMapVolumeSourceDefaultMode {
+		t.Errorf("unexpected config map default mode %o", *podSpec.Volumes[0].ConfigMap.DefaultMode)
+	}
+}
+
+func TestDefaultJob(t *testing.T) {
+	job := &v1.Job{Spec: v1.JobSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+		RestartPolicy: v1.RestartPolicyNever,
+		Containers:    []v1.Container{{Name: "main", Image: "mountain/foo:1.0"}},
+	}}}}
+	scheme.Scheme.Default(job)
+	if *job.Spec.BackoffLimit != defaulting.DefaultBackoffLimit {
+		t.Errorf("unexpected backoff limit %d", *job.Spec.BackoffLimit)
+	}
+	if job.Spec.Template.Spec.ActiveDeadlineSeconds == nil || *job.Spec.Template.Spec.ActiveDeadlineSeconds != v1.DefaultActiveDeadlineSeconds {
+		t.Errorf("expected run-to-completion pods to get the default active deadline")
+	}
+	if job.Spec.Template.Spec.RestartPolicy != v1.RestartPolicyNever {
+		t.Errorf("restart policy must not be overwritten")
+	}
+	if job.Spec.Template.Spec.Containers[0].ImagePullPolicy != v1.PullIfNotPresent {
+		t.Errorf("unexpected pull policy %q", job.Spec.Template.Spec.Containers[0].ImagePullPolicy)
+	}
+}
+
+func TestDefaultKeepsExplicitValues(t *testing.T) {
+	rs := &v1.ReplicaSet{Spec: v1.ReplicaSetSpec{
+		Replicas: int64Ptr(0),
+		Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+			RestartPolicy:                 v1.RestartPolicyOnFailure,
+			TerminationGracePeriodSeconds: int64Ptr(0),
+			ActiveDeadlineSeconds:         int64Ptr(5),
+			Suspended:                     boolPtr(true),
+			SchedulerName:                 "other",
+			InitContainers:                []v1.Container{{Name: "init", Image: "foo:latest", ImagePullPolicy: v1.PullNever}},
+		}},
+	}}
+	expected := rs.DeepCopy()
+	expected.Spec.Strategy.Type = v1.InplaceUpdateReplicaSetStrategyType
+
+	scheme.Scheme.Default(rs)
+	if diff := cmp.Diff(expected, rs); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestDefaultLists(t *testing.T) {
+	list := &v1.ServiceList{Items: []v1.Service{{Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}}}}}
+	scheme.Scheme.Default(list)
+	if list.Items[0].Spec.Ports[0].Protocol != v1.ProtocolTCP {
+		t.Errorf("expected list items to be defaulted")
+	}
+	namespaces := &v1.NamespaceList{Items: []v1.Namespace{{}}}
+	scheme.Scheme.Default(namespaces)
+	if namespaces.Items[0].Status.Phase != v1.NamespaceActive {
+		t.Errorf("unexpected namespace phase %q", namespaces.Items[0].Status.Phase)
+	}
+}
+
+func TestDefaultPullPolicy(t *testing.T) {
+	testCases := map[string]v1.PullPolicy{
+		"foo":                         v1.PullAlways,
+		"foo:latest":                  v1.PullAlways,
+		"registry:5000/foo":           v1.PullAlways,
+		"registry:5000/foo:latest":    v1.PullAlways,
+		"foo:1.0":                     v1.PullIfNotPresent,
+		"registry:5000/foo:1.0":       v1.PullIfNotPresent,
+		"foo@sha256:0123456789abcdef": v1.PullIfNotPresent,
+	}
+	for image, expected := range testCases {
+		pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: image}}}}
+		defaulting.SetObjectDefaults_Pod(pod)
+		if actual := pod.Spec.Containers[0].ImagePullPolicy; actual != expected {
+			t.Errorf("%s: expected %q, got %q", image, expected, actual)
+		}
+	}
+}
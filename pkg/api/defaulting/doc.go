@@ -0,0 +1,7 @@
+// +k8s:defaulter-gen=TypeMeta
+// +k8s:defaulter-gen-input=github.com/opencarry/carry/pkg/apis/carry.i/v1
+
+// Package defaulting fills the documented default values of the carry.i/v1 types.
+// The defaulters are registered per kind in a runtime.Scheme, so decoding with a
+// defaulting codec or calling Scheme.Default always yields complete objects.
+package defaulting
@@ -0,0 +1,10 @@
+package defaulting
+
+import "github.com/opencarry/carry/pkg/runtime"
+
+var (
+	// SchemeBuilder registers the defaulters of every kind.
+	SchemeBuilder = runtime.NewSchemeBuilder(RegisterDefaults)
+	// AddToScheme adds the defaulters to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
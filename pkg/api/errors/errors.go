@@ -0,0 +1,678 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// StatusError is an error intended for consumption by a REST API server; it can also be
+// reconstructed by clients from a REST response. Public to allow easy type switches.
+type StatusError struct {
+	ErrStatus v1.Status
+}
+
+// APIStatus is exposed by errors that can be converted to an api.Status object
+// for finer grained details.
+type APIStatus interface {
+	Status() v1.Status
+}
+
+var _ error = &StatusError{}
+
+// Error implements the Error interface.
+func (e *StatusError) Error() string {
+	return e.ErrStatus.Message
+}
+
+// Status allows access to e's status without having to know the detailed workings
+// of StatusError.
+func (e *StatusError) Status() v1.Status {
+	return e.ErrStatus
+}
+
+// DebugError reports extended info about the error to debug output.
+func (e *StatusError) DebugError() (string, []interface{}) {
+	return "server response object: %#v", []interface{}{e.ErrStatus}
+}
+
+// HasStatusCause returns true if the provided error has a details cause
+// with the provided type name.
+func HasStatusCause(err error, name v1.CauseType) bool {
+	_, ok := StatusCause(err, name)
+	return ok
+}
+
+// StatusCause returns the named cause from the provided error if it exists and
+// the error is of the type APIStatus. Otherwise it returns false.
+func StatusCause(err error, name v1.CauseType) (v1.StatusCause, bool) {
+	apierr, ok := err.(APIStatus)
+	if !ok || apierr == nil || apierr.Status().Details == nil {
+		return v1.StatusCause{}, false
+	}
+	for _, cause := range apierr.Status().Details.Causes {
+		if cause.Type == name {
+			return cause, true
+		}
+	}
+	return v1.StatusCause{}, false
+}
+
+// UnexpectedObjectError can be returned by FromObject if it's passed a non-status object.
+type UnexpectedObjectError struct {
+	Object runtime.Object
+}
+
+// Error returns an error message describing 'u'.
+func (u *UnexpectedObjectError) Error() string {
+	return fmt.Sprintf("unexpected object: %v", u.Object)
+}
+
+// FromObject generates an StatusError from an v1.Status, if that is the type of obj; otherwise,
+// returns an UnexpecteObjectError.
+func FromObject(obj runtime.Object) error {
+	if status, ok := obj.(*v1.Status); ok {
+		return &StatusError{ErrStatus: *status}
+	}
+	return &UnexpectedObjectError{obj}
+}
+
+func newStatus(code int32, reason v1.StatusReason, message string, details *v1.StatusDetails) *StatusError {
+	return &StatusError{v1.Status{
+		Status:  v1.StatusFailure,
+		Code:    code,
+		Reason:  reason,
+		Details: details,
+		Message: message,
+	}}
+}
+
+// NewNotFound returns a new error which indicates that the resource of the kind and the name was not found.
+func NewNotFound(qualifiedResource schema.GroupResource, name string) *StatusError {
+	return newStatus(http.StatusNotFound, v1.StatusReasonNotFound,
+		fmt.Sprintf("%s %q not found", qualifiedResource.String(), name),
+		&v1.StatusDetails{Group: qualifiedResource.Group, Kind: qualifiedResource.Resource, Name: name})
+}
+
+// NewAlreadyExists returns an error indicating the item requested exists by that identifier.
+func NewAlreadyExists(qualifiedResource schema.GroupResource, name string) *StatusError {
+	return newStatus(http.StatusConflict, v1.StatusReasonAlreadyExists,
+		fmt.Sprintf("%s %q already exists", qualifiedResource.String(), name),
+		&v1.StatusDetails{Group: qualifiedResource.Group, Kind: qualifiedResource.Resource, Name: name})
+}
+
+// NewApplyConflict returns an error including details on the requests apply conflicts
+func NewApplyConflict(causes []v1.StatusCause, message string) *StatusError {
+	return newStatus(http.StatusConflict, v1.StatusReasonConflict, message,
+		&v1.StatusDetails{
+			Causes: causes,
+		})
+}
+
+// NewGenerateNameConflict returns an error indicating the server
+// was not able to generate a valid name for a resource.
+func NewGenerateNameConflict(qualifiedResource schema.GroupResource, name string, retryAfterSeconds int) *StatusError {
+	return newStatus(http.StatusConflict, v1.StatusReasonAlreadyExists,
+		fmt.Sprintf(
+			"%s %q already exists, the server was not able to generate a unique name for the object",
+			qualifiedResource.String(), name),
+		&v1.StatusDetails{
+			Group:             qualifiedResource.Group,
+			Kind:              qualifiedResource.Resource,
+			Name:              name,
+			RetryAfterSeconds: int32(retryAfterSeconds),
+		})
+}
+
+// NewUnauthorized returns an error indicating the client is not authorized to perform the requested
+// action.
+func NewUnauthorized(reason string) *StatusError {
+	message := reason
+	if len(message) == 0 {
+		message = "not authorized"
+	}
+	return newStatus(http.StatusUnauthorized, v1.StatusReasonUnauthorized, message, nil)
+}
+
+// NewForbidden returns an error indicating the requested action was forbidden
+func NewForbidden(qualifiedResource schema.GroupResource, name string, err error) *StatusError {
+	var message string
+	if qualifiedResource.Empty() {
+		message = fmt.Sprintf("forbidden: %v", err)
+	} else if name == "" {
+		message = fmt.Sprintf("%s is forbidden: %v", qualifiedResource.String(), err)
+	} else {
+		message = fmt.Sprintf("%s %q is forbidden: %v", qualifiedResource.String(), name, err)
+	}
+	return newStatus(http.StatusForbidden, v1.StatusReasonForbidden, message,
+		&v1.StatusDetails{Group: qualifiedResource.Group, Kind: qualifiedResource.Resource, Name: name})
+}
+
+// NewConflict returns an error indicating the item can't be updated as provided.
+func NewConflict(qualifiedResource schema.GroupResource, name string, err error) *StatusError {
+	return newStatus(http.StatusConflict, v1.StatusReasonConflict,
+		fmt.Sprintf("operation cannot be fulfilled on %s %q: %v", qualifiedResource.String(), name, err),
+		&v1.StatusDetails{Group: qualifiedResource.Group, Kind: qualifiedResource.Resource, Name: name})
+}
+
+// NewGone returns an error indicating the item no longer available at the server and no forwarding address is known.
+func NewGone(message string) *StatusError {
+	return newStatus(http.StatusGone, v1.StatusReasonGone, message, nil)
+}
+
+// NewResourceExpired creates an error that indicates that the requested resource content has expired from
+// the server (usually due to a resourceVersion that is too old).
+func NewResourceExpired(message string) *StatusError {
+	return newStatus(http.StatusGone, v1.StatusReasonExpired, message, nil)
+}
+
+// NewInvalid returns an error indicating the item is invalid and cannot be processed.
+// Every field error becomes a cause carrying the path of the offending field.
+func NewInvalid(qualifiedKind schema.GroupKind, name string, errs field.ErrorList) *StatusError {
+	causes := make([]v1.StatusCause, 0, len(errs))
+	for i := range errs {
+		err := errs[i]
+		causes = append(causes, v1.StatusCause{
+			Type:    v1.CauseType(err.Type),
+			Message: err.ErrorBody(),
+			Field:   err.Field,
+		})
+	}
+	var message string
+	if len(errs) == 0 {
+		message = fmt.Sprintf("%s %q is invalid", qualifiedKind.String(), name)
+	} else {
+		message = fmt.Sprintf("%s %q is invalid: %v", qualifiedKind.String(), name, errs.ToAggregate())
+	}
+	return newStatus(http.StatusUnprocessableEntity, v1.StatusReasonInvalid, message,
+		&v1.StatusDetails{Group: qualifiedKind.Group, Kind: qualifiedKind.Kind, Name: name, Causes: causes})
+}
+
+// NewBadRequest creates an error that indicates that the request is invalid and can not be processed.
+func NewBadRequest(reason string) *StatusError {
+	return newStatus(http.StatusBadRequest, v1.StatusReasonBadRequest, reason, nil)
+}
+
+// NewTooManyRequests creates an error that indicates that the client must try again later because
+// the specified endpoint is not accepting requests. More specific details should be provided
+// if client should know why the failure was limited.
+func NewTooManyRequests(message string, retryAfterSeconds int) *StatusError {
+	return newStatus(http.StatusTooManyRequests, v1.StatusReasonTooManyRequests, message,
+		&v1.StatusDetails{RetryAfterSeconds: int32(retryAfterSeconds)})
+}
+
+// NewServiceUnavailable creates an error that indicates that the requested service is unavailable.
+func NewServiceUnavailable(reason string) *StatusError {
+	return newStatus(http.StatusServiceUnavailable, v1.StatusReasonServiceUnavailable, reason, nil)
+}
+
+// NewMethodNotSupported returns an error indicating the requested action is not supported on this kind.
+func NewMethodNotSupported(qualifiedResource schema.GroupResource, action string) *StatusError {
+	return newStatus(http.StatusMethodNotAllowed, v1.StatusReasonMethodNotAllowed,
+		fmt.Sprintf("%s is not supported on resources of kind %q", action, qualifiedResource.String()),
+		&v1.StatusDetails{Group: qualifiedResource.Group, Kind: qualifiedResource.Resource})
+}
+
+// NewServerTimeout returns an error indicating the requested action could not be completed due to a
+// transient error, and the client should try again.
+func NewServerTimeout(qualifiedResource schema.GroupResource, operation string, retryAfterSeconds int) *StatusError {
+	return newStatus(http.StatusInternalServerError, v1.StatusReasonServerTimeout,
+		fmt.Sprintf("the server cannot complete the requested operation at this time, try again later (%s %s)", operation, qualifiedResource.String()),
+		&v1.StatusDetails{
+			Group:             qualifiedResource.Group,
+			Kind:              qualifiedResource.Resource,
+			Name:              operation,
+			RetryAfterSeconds: int32(retryAfterSeconds),
+		})
+}
+
+// NewInternalError returns an error indicating the item is invalid and cannot be processed.
+func NewInternalError(err error) *StatusError {
+	return newStatus(http.StatusInternalServerError, v1.StatusReasonInternalError,
+		fmt.Sprintf("internal error occurred: %v", err),
+		&v1.StatusDetails{Causes: []v1.StatusCause{{Message: err.Error()}}})
+}
+
+// NewTimeoutError returns an error indicating that a timeout occurred before the request
+// could be completed.  Clients may retry, but the operation may still complete.
+func NewTimeoutError(message string, retryAfterSeconds int) *StatusError {
+	return newStatus(http.StatusGatewayTimeout, v1.StatusReasonTimeout,
+		fmt.Sprintf("timeout: %s", message),
+		&v1.StatusDetails{RetryAfterSeconds: int32(retryAfterSeconds)})
+}
+
+// NewTooLargeResourceVersionError returns a timeout error indicating that the request could not be completed
+// because the requested resource version is newer than the data observed by the server.
+func NewTooLargeResourceVersionError(minimumResourceVersion, currentRevision int64, retryAfterSeconds int) *StatusError {
+	err := NewTimeoutError(fmt.Sprintf("too large resource version: %d, current: %d", minimumResourceVersion, currentRevision), retryAfterSeconds)
+	err.ErrStatus.Details.Causes = []v1.StatusCause{
+		{
+			Type:    v1.CauseTypeResourceVersionTooLarge,
+			Message: "too large resource version",
+		},
+	}
+	return err
+}
+
+// NewRequestEntityTooLargeError returns an error indicating that the request
+// entity was too large.
+func NewRequestEntityTooLargeError(message string) *StatusError {
+	return newStatus(http.StatusRequestEntityTooLarge, v1.StatusReasonRequestEntityTooLarge,
+		fmt.Sprintf("request entity too large: %s", message), nil)
+}
+
+// NewNotAcceptable returns an error indicating that none of the media types
+// the client accepts can be served.
+func NewNotAcceptable(accepted []string) *StatusError {
+	return newStatus(http.StatusNotAcceptable, v1.StatusReasonNotAcceptable,
+		fmt.Sprintf("only the following media types are accepted: %v", strings.Join(accepted, ", ")), nil)
+}
+
+// NewUnsupportedMediaType returns an error indicating that the request body
+// is in a format the server cannot read.
+func NewUnsupportedMediaType(contentType string) *StatusError {
+	return newStatus(http.StatusUnsupportedMediaType, v1.StatusReasonUnsupportedMediaType,
+		fmt.Sprintf("the body of the request was in an unknown format: %s", contentType), nil)
+}
+
+// NewGenericServerResponse returns a new error for server responses that are
+// not in a recognizable form.
+func NewGenericServerResponse(code int, verb string, qualifiedResource schema.GroupResource, name, serverMessage string) *StatusError {
+	reason := v1.StatusReasonUnknown
+	message := fmt.Sprintf("the server responded with the status code %d but did not return more information", code)
+	switch code {
+	case http.StatusConflict:
+		if verb == "POST" {
+			reason = v1.StatusReasonAlreadyExists
+		} else {
+			reason = v1.StatusReasonConflict
+		}
+		message = "the server reported a conflict"
+	case http.StatusNotFound:
+		reason = v1.StatusReasonNotFound
+		message = "the server could not find the requested resource"
+	case http.StatusBadRequest:
+		reason = v1.StatusReasonBadRequest
+		message = "the server rejected our request for an unknown reason"
+	case http.StatusUnauthorized:
+		reason = v1.StatusReasonUnauthorized
+		message = "the server has asked for the client to provide credentials"
+	case http.StatusForbidden:
+		reason = v1.StatusReasonForbidden
+		message = "the server does not allow access to the requested resource"
+	case http.StatusMethodNotAllowed:
+		reason = v1.StatusReasonMethodNotAllowed
+		message = "the server does not allow this method on the requested resource"
+	case http.StatusUnprocessableEntity:
+		reason = v1.StatusReasonInvalid
+		message = "the server rejected our request due to an error in our request"
+	case http.StatusServiceUnavailable:
+		reason = v1.StatusReasonServiceUnavailable
+		message = "the server is currently unable to handle the request"
+	case http.StatusGatewayTimeout:
+		reason = v1.StatusReasonTimeout
+		message = "the server was unable to return a response in the time allotted, but may still be processing the request"
+	case http.StatusTooManyRequests:
+		reason = v1.StatusReasonTooManyRequests
+		message = "the server has received too many requests and has asked us to try again later"
+	default:
+		if code >= 500 {
+			reason = v1.StatusReasonInternalError
+			message = fmt.Sprintf("an error on the server (%q) has prevented the request from succeeding", serverMessage)
+		}
+	}
+	switch {
+	case !qualifiedResource.Empty() && len(name) > 0:
+		message = fmt.Sprintf("%s (%s %s %s)", message, strings.ToLower(verb), qualifiedResource.String(), name)
+	case !qualifiedResource.Empty():
+		message = fmt.Sprintf("%s (%s %s)", message, strings.ToLower(verb), qualifiedResource.String())
+	}
+	var causes []v1.StatusCause
+	if len(serverMessage) > 0 {
+		causes = append(causes, v1.StatusCause{
+			Type:    v1.CauseTypeUnexpectedServerResponse,
+			Message: serverMessage,
+		})
+	}
+	return newStatus(int32(code), reason, message, &v1.StatusDetails{
+		Group:  qualifiedResource.Group,
+		Kind:   qualifiedResource.Resource,
+		Name:   name,
+		Causes: causes,
+	})
+}
+
+// IsNotFound returns true if the specified error was created by NewNotFound.
+// It supports wrapped errors and returns false when the error is nil.
+func IsNotFound(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonNotFound {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusNotFound {
+		return true
+	}
+	return false
+}
+
+// IsAlreadyExists determines if the err is an error which indicates that a specified resource already exists.
+// It supports wrapped errors and returns false when the error is nil.
+func IsAlreadyExists(err error) bool {
+	return ReasonForError(err) == v1.StatusReasonAlreadyExists
+}
+
+// IsConflict determines if the err is an error which indicates the provided update conflicts.
+// It supports wrapped errors and returns false when the error is nil.
+func IsConflict(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonConflict {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusConflict {
+		return true
+	}
+	return false
+}
+
+// IsInvalid determines if the err is an error which indicates the provided resource is not valid.
+// It supports wrapped errors and returns false when the error is nil.
+func IsInvalid(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonInvalid {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusUnprocessableEntity {
+		return true
+	}
+	return false
+}
+
+// IsGone is true if the error indicates the requested resource is no longer available.
+// It supports wrapped errors and returns false when the error is nil.
+func IsGone(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonGone {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusGone {
+		return true
+	}
+	return false
+}
+
+// IsResourceExpired is true if the error indicates the resource has expired and the current action is
+// no longer possible.
+// It supports wrapped errors and returns false when the error is nil.
+func IsResourceExpired(err error) bool {
+	return ReasonForError(err) == v1.StatusReasonExpired
+}
+
+// IsNotAcceptable determines if err is an error which indicates that the request failed due to an invalid Accept header
+// It supports wrapped errors and returns false when the error is nil.
+func IsNotAcceptable(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonNotAcceptable {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusNotAcceptable {
+		return true
+	}
+	return false
+}
+
+// IsUnsupportedMediaType determines if err is an error which indicates that the request failed due to an invalid Content-Type header
+// It supports wrapped errors and returns false when the error is nil.
+func IsUnsupportedMediaType(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonUnsupportedMediaType {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusUnsupportedMediaType {
+		return true
+	}
+	return false
+}
+
+// IsMethodNotSupported determines if the err is an error which indicates the provided action could not
+// be performed because it is not supported by the server.
+// It supports wrapped errors and returns false when the error is nil.
+func IsMethodNotSupported(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonMethodNotAllowed {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusMethodNotAllowed {
+		return true
+	}
+	return false
+}
+
+// IsServiceUnavailable is true if the error indicates the underlying service is no longer available.
+// It supports wrapped errors and returns false when the error is nil.
+func IsServiceUnavailable(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonServiceUnavailable {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusServiceUnavailable {
+		return true
+	}
+	return false
+}
+
+// IsBadRequest determines if err is an error which indicates that the request is invalid.
+// It supports wrapped errors and returns false when the error is nil.
+func IsBadRequest(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonBadRequest {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusBadRequest {
+		return true
+	}
+	return false
+}
+
+// IsUnauthorized determines if err is an error which indicates that the request is unauthorized and
+// requires authentication by the user.
+// It supports wrapped errors and returns false when the error is nil.
+func IsUnauthorized(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonUnauthorized {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusUnauthorized {
+		return true
+	}
+	return false
+}
+
+// IsForbidden determines if err is an error which indicates that the request is forbidden and cannot
+// be completed as requested.
+// It supports wrapped errors and returns false when the error is nil.
+func IsForbidden(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonForbidden {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusForbidden {
+		return true
+	}
+	return false
+}
+
+// IsTimeout determines if err is an error which indicates that request times out due to long
+// processing.
+// It supports wrapped errors and returns false when the error is nil.
+func IsTimeout(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonTimeout {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusGatewayTimeout {
+		return true
+	}
+	return false
+}
+
+// IsServerTimeout determines if err is an error which indicates that the request needs to be retried
+// by the client.
+// It supports wrapped errors and returns false when the error is nil.
+func IsServerTimeout(err error) bool {
+	// do not check the status code, because no https status code exists that can
+	// be scoped to retryable timeouts.
+	return ReasonForError(err) == v1.StatusReasonServerTimeout
+}
+
+// IsInternalError determines if err is an error which indicates an internal server error.
+// It supports wrapped errors and returns false when the error is nil.
+func IsInternalError(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonInternalError {
+		return true
+	}
+	if _, ok := knownReasons[reason]; !ok && code == http.StatusInternalServerError {
+		return true
+	}
+	return false
+}
+
+// IsTooManyRequests determines if err is an error which indicates that there are too many requests
+// that the server cannot handle.
+// It supports wrapped errors and returns false when the error is nil.
+func IsTooManyRequests(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonTooManyRequests {
+		return true
+	}
+
+	// IsTooManyRequests' checking of code predates the checking of the code in
+	// the other Is* functions. In order to maintain backward compatibility, this
+	// does not check that the reason is unknown.
+	if code == http.StatusTooManyRequests {
+		return true
+	}
+	return false
+}
+
+// IsRequestEntityTooLargeError determines if err is an error which indicates
+// the request entity is too large.
+// It supports wrapped errors and returns false when the error is nil.
+func IsRequestEntityTooLargeError(err error) bool {
+	reason, code := reasonAndCodeForError(err)
+	if reason == v1.StatusReasonRequestEntityTooLarge {
+		return true
+	}
+
+	// IsRequestEntityTooLargeError's checking of code predates the checking of
+	// the code in the other Is* functions. In order to maintain backward
+	// compatibility, this does not check that the reason is unknown.
+	if code == http.StatusRequestEntityTooLarge {
+		return true
+	}
+	return false
+}
+
+// IsUnexpectedServerError returns true if the server response was not in the expected API format,
+// and may be the result of another HTTP actor.
+// It supports wrapped errors and returns false when the error is nil.
+func IsUnexpectedServerError(err error) bool {
+	status, ok := err.(APIStatus)
+	if (ok || errors.As(err, &status)) && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type == v1.CauseTypeUnexpectedServerResponse {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsUnexpectedObjectError determines if err is due to an unexpected object from the master.
+// It supports wrapped errors and returns false when the error is nil.
+func IsUnexpectedObjectError(err error) bool {
+	uoe, ok := err.(*UnexpectedObjectError)
+	return err != nil && (ok || errors.As(err, &uoe))
+}
+
+// SuggestsClientDelay returns true if this error suggests a client delay as well as the
+// suggested seconds to wait, or false if the error does not imply a wait. It does not
+// address whether the error *should* be retried, since some errors (like a 3xx) may
+// request delay without retry.
+// It supports wrapped errors and returns false when the error is nil.
+func SuggestsClientDelay(err error) (int, bool) {
+	t, ok := err.(APIStatus)
+	if (ok || errors.As(err, &t)) && t.Status().Details != nil {
+		switch t.Status().Reason {
+		// this StatusReason explicitly requests the caller to delay the action
+		case v1.StatusReasonServerTimeout:
+			return int(t.Status().Details.RetryAfterSeconds), true
+		}
+		// If the client requests that we retry after a certain number of seconds
+		if t.Status().Details.RetryAfterSeconds > 0 {
+			return int(t.Status().Details.RetryAfterSeconds), true
+		}
+	}
+	return 0, false
+}
+
+// ReasonForError returns the HTTP status for a particular error.
+// It supports wrapped errors and returns StatusReasonUnknown when
+// the error is nil or doesn't have a status.
+func ReasonForError(err error) v1.StatusReason {
+	if status, ok := err.(APIStatus); ok || errors.As(err, &status) {
+		return status.Status().Reason
+	}
+	return v1.StatusReasonUnknown
+}
+
+func reasonAndCodeForError(err error) (v1.StatusReason, int32) {
+	if status, ok := err.(APIStatus); ok || errors.As(err, &status) {
+		return status.Status().Reason, status.Status().Code
+	}
+	return v1.StatusReasonUnknown, 0
+}
+
+var knownReasons = map[v1.StatusReason]struct{}{
+	// v1.StatusReasonUnknown : {}
+	v1.StatusReasonUnauthorized:          {},
+	v1.StatusReasonForbidden:             {},
+	v1.StatusReasonNotFound:              {},
+	v1.StatusReasonAlreadyExists:         {},
+	v1.StatusReasonConflict:              {},
+	v1.StatusReasonGone:                  {},
+	v1.StatusReasonInvalid:               {},
+	v1.StatusReasonServerTimeout:         {},
+	v1.StatusReasonTimeout:               {},
+	v1.StatusReasonTooManyRequests:       {},
+	v1.StatusReasonBadRequest:            {},
+	v1.StatusReasonMethodNotAllowed:      {},
+	v1.StatusReasonNotAcceptable:         {},
+	v1.StatusReasonRequestEntityTooLarge: {},
+	v1.StatusReasonUnsupportedMediaType:  {},
+	v1.StatusReasonInternalError:         {},
+	v1.StatusReasonExpired:               {},
+	v1.StatusReasonServiceUnavailable:    {},
+}
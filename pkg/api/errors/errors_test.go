@@ -0,0 +1,277 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+func resource(resource string) schema.GroupResource {
+	return schema.GroupResource{Group: "", Resource: resource}
+}
+
+func kind(kind string) schema.GroupKind {
+	return schema.GroupKind{Group: "", Kind: kind}
+}
+
+// roundTrip encodes the status of err and decodes it again, like a client
+// reading the response of a failed request.
+func roundTrip(t *testing.T, err *StatusError) error {
+	t.Helper()
+	codec := scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion)
+	data, encodeErr := runtime.Encode(codec, &err.ErrStatus)
+	if encodeErr != nil {
+		t.Fatal(encodeErr)
+	}
+	obj, decodeErr := runtime.Decode(codec, data)
+	if decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	return FromObject(obj)
+}
+
+func TestErrorNew(t *testing.T) {
+	err := NewAlreadyExists(resource("tests"), "1")
+	if !IsAlreadyExists(err) {
+		t.Errorf("expected to be %s", v1.StatusReasonAlreadyExists)
+	}
+	if IsConflict(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonConflict)
+	}
+	if IsNotFound(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonNotFound)
+	}
+	if IsInvalid(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonInvalid)
+	}
+	if IsBadRequest(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonBadRequest)
+	}
+	if IsForbidden(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonForbidden)
+	}
+	if IsServerTimeout(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonServerTimeout)
+	}
+	if IsMethodNotSupported(err) {
+		t.Errorf("expected to not be %s", v1.StatusReasonMethodNotAllowed)
+	}
+
+	if !IsConflict(NewConflict(resource("tests"), "2", errors.New("message"))) {
+		t.Errorf("expected to be conflict")
+	}
+	if !IsNotFound(NewNotFound(resource("tests"), "3")) {
+		t.Errorf("expected to be %s", v1.StatusReasonNotFound)
+	}
+	if !IsInvalid(NewInvalid(kind("Test"), "2", nil)) {
+		t.Errorf("expected to be %s", v1.StatusReasonInvalid)
+	}
+	if !IsBadRequest(NewBadRequest("reason")) {
+		t.Errorf("expected to be %s", v1.StatusReasonBadRequest)
+	}
+	if !IsForbidden(NewForbidden(resource("tests"), "2", errors.New("reason"))) {
+		t.Errorf("expected to be %s", v1.StatusReasonForbidden)
+	}
+	if !IsUnauthorized(NewUnauthorized("reason")) {
+		t.Errorf("expected to be %s", v1.StatusReasonUnauthorized)
+	}
+	if !IsServerTimeout(NewServerTimeout(resource("tests"), "reason", 0)) {
+		t.Errorf("expected to be %s", v1.StatusReasonServerTimeout)
+	}
+	if !IsMethodNotSupported(NewMethodNotSupported(resource("foos"), "delete")) {
+		t.Errorf("expected to be %s", v1.StatusReasonMethodNotAllowed)
+	}
+	if !IsTooManyRequests(NewTooManyRequests("reason", 10)) {
+		t.Errorf("expected to be %s", v1.StatusReasonTooManyRequests)
+	}
+	if !IsResourceExpired(NewResourceExpired("reason")) {
+		t.Errorf("expected to be %s", v1.StatusReasonExpired)
+	}
+
+	if time, ok := SuggestsClientDelay(NewServerTimeout(resource("tests"), "doing something", 10)); time != 10 || !ok {
+		t.Errorf("unexpected %d", time)
+	}
+	if time, ok := SuggestsClientDelay(NewServerTimeout(resource("tests"), "doing something", 0)); time != 0 || !ok {
+		t.Errorf("unexpected %d", time)
+	}
+	if time, ok := SuggestsClientDelay(NewTooManyRequests("doing something", 10)); time != 10 || !ok {
+		t.Errorf("unexpected %d", time)
+	}
+	if time, ok := SuggestsClientDelay(NewTooManyRequests("doing something", 0)); time != 0 || ok {
+		t.Errorf("unexpected %d", time)
+	}
+	if time, ok := SuggestsClientDelay(NewGenericServerResponse(429, "get", resource("tests"), "test", "doing something")); time != 0 || ok {
+		t.Errorf("unexpected %d", time)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	testCases := []struct {
+		Err     *field.Error
+		Details *v1.StatusDetails
+	}{
+		{
+			field.Duplicate(field.NewPath("field[0].name"), "bar"),
+			&v1.StatusDetails{
+				Kind: "Kind",
+				Name: "name",
+				Causes: []v1.StatusCause{{
+					Type:  v1.CauseTypeFieldValueDuplicate,
+					Field: "field[0].name",
+				}},
+			},
+		},
+		{
+			field.Invalid(field.NewPath("field[0].name"), "bar", "detail"),
+			&v1.StatusDetails{
+				Kind: "Kind",
+				Name: "name",
+				Causes: []v1.StatusCause{{
+					Type:  v1.CauseTypeFieldValueInvalid,
+					Field: "field[0].name",
+				}},
+			},
+		},
+		{
+			field.NotFound(field.NewPath("field[0].name"), "bar"),
+			&v1.StatusDetails{
+				Kind: "Kind",
+				Name: "name",
+				Causes: []v1.StatusCause{{
+					Type:  v1.CauseTypeFieldValueNotFound,
+					Field: "field[0].name",
+				}},
+			},
+		},
+		{
+			field.NotSupported(field.NewPath("field[0].name"), "bar", nil),
+			&v1.StatusDetails{
+				Kind: "Kind",
+				Name: "name",
+				Causes: []v1.StatusCause{{
+					Type:  v1.CauseTypeFieldValueNotSupported,
+					Field: "field[0].name",
+				}},
+			},
+		},
+		{
+			field.Required(field.NewPath("field[0].name"), ""),
+			&v1.StatusDetails{
+				Kind: "Kind",
+				Name: "name",
+				Causes: []v1.StatusCause{{
+					Type:  v1.CauseTypeFieldValueRequired,
+					Field: "field[0].name",
+				}},
+			},
+		},
+	}
+	for i, testCase := range testCases {
+		vErr, expected := testCase.Err, testCase.Details
+		expected.Causes[0].Message = vErr.ErrorBody()
+		err := NewInvalid(kind("Kind"), "name", field.ErrorList{vErr})
+		status := err.ErrStatus
+		if status.Code != 422 || status.Reason != v1.StatusReasonInvalid {
+			t.Errorf("%d: unexpected status: %#v", i, status)
+		}
+		if !reflect.DeepEqual(expected, status.Details) {
+			t.Errorf("%d: expected %#v, got %#v", i, expected, status.Details)
+		}
+	}
+}
+
+func TestAcrossTheWire(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   *StatusError
+		check func(error) bool
+	}{
+		{"not found", NewNotFound(resource("pods"), "web"), IsNotFound},
+		{"already exists", NewAlreadyExists(resource("pods"), "web"), IsAlreadyExists},
+		{"conflict", NewConflict(resource("pods"), "web", errors.New("modified")), IsConflict},
+		{"invalid", NewInvalid(kind("pod"), "web", field.ErrorList{field.Required(field.NewPath("spec", "containers"), "")}), IsInvalid},
+		{"forbidden", NewForbidden(resource("pods"), "web", errors.New("denied")), IsForbidden},
+		{"too many requests", NewTooManyRequests("slow down", 5), IsTooManyRequests},
+		{"expired", NewResourceExpired("too old"), IsResourceExpired},
+		{"internal", NewInternalError(errors.New("boom")), IsInternalError},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded := roundTrip(t, tc.err)
+			if !tc.check(decoded) {
+				t.Errorf("predicate does not hold for the decoded error %#v", decoded)
+			}
+			if got := decoded.(APIStatus).Status(); !reflect.DeepEqual(got.Details, tc.err.ErrStatus.Details) {
+				t.Errorf("expected details %#v, got %#v", tc.err.ErrStatus.Details, got.Details)
+			}
+		})
+	}
+
+	// the offending field survives the trip so clients can point at it
+	decoded := roundTrip(t, NewInvalid(kind("pod"), "web", field.ErrorList{
+		field.Required(field.NewPath("spec", "containers").Index(0).Child("name"), ""),
+	}))
+	cause, ok := StatusCause(decoded, v1.CauseTypeFieldValueRequired)
+	if !ok || cause.Field != "spec.containers[0].name" {
+		t.Errorf("expected a cause for spec.containers[0].name, got %#v", cause)
+	}
+}
+
+func TestStatusCodeFallback(t *testing.T) {
+	// a status with an unknown reason is still recognized by its code
+	err := &StatusError{v1.Status{Code: http.StatusNotFound, Reason: "some_new_reason"}}
+	if !IsNotFound(err) {
+		t.Errorf("expected an unknown reason with code 404 to be not found")
+	}
+	err = &StatusError{v1.Status{Code: http.StatusNotFound, Reason: v1.StatusReasonConflict}}
+	if IsNotFound(err) {
+		t.Errorf("expected a known reason to take precedence over the code")
+	}
+}
+
+func TestWrapped(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewNotFound(resource("pods"), "web"))
+	if !IsNotFound(err) {
+		t.Errorf("expected wrapped error to be not found")
+	}
+	if ReasonForError(err) != v1.StatusReasonNotFound {
+		t.Errorf("unexpected reason %q", ReasonForError(err))
+	}
+	if IsNotFound(nil) || IsNotFound(errors.New("not a status")) {
+		t.Errorf("expected nil and plain errors not to be not found")
+	}
+}
+
+func TestFromObject(t *testing.T) {
+	obj := &v1.Status{Status: v1.StatusFailure, Reason: v1.StatusReasonConflict, Code: http.StatusConflict}
+	if err := FromObject(obj); !IsConflict(err) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+	if err := FromObject(&v1.Pod{}); !IsUnexpectedObjectError(err) {
+		t.Errorf("expected unexpected object error, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Package scheme holds the scheme that every carry.i API type is registered in.
+package scheme
+
+import (
+	"github.com/opencarry/carry/pkg/api/defaulting"
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/serializer"
+)
+
+// Scheme is the default instance of runtime.Scheme to which all API types are registered.
+var Scheme = runtime.NewScheme()
+
+// Codecs provides access to encoding and decoding for the scheme.
+var Codecs = serializer.NewCodecFactory(Scheme)
+
+// AddToScheme adds all types of the known groups to the given scheme.
+var AddToScheme = localSchemeBuilder.AddToScheme
+
+var localSchemeBuilder = runtime.SchemeBuilder{
+	v1.AddToScheme,
+	rbacv1.AddToScheme,
+	auditv1.AddToScheme,
+	defaulting.AddToScheme,
+}
+
+func init() {
+	if err := AddToScheme(Scheme); err != nil {
+		panic(err)
+	}
+}
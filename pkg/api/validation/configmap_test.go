@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func TestValidateConfigMap(t *testing.T) {
+	newConfigMap := func(data map[string]string, binaryData map[string][]byte) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{Name: "config", Namespace: "default"},
+			Data:       data,
+			BinaryData: binaryData,
+		}
+	}
+
+	valid := newConfigMap(map[string]string{"app.conf": "a=b", "KEY_NAME": "v"}, map[string][]byte{"cert.pem": []byte("x")})
+	if errs := ValidateConfigMap(valid); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		cfg   *v1.ConfigMap
+		field string
+	}{
+		"invalid key":        {newConfigMap(map[string]string{"a/b": "v"}, nil), "data[a/b]"},
+		"invalid binary key": {newConfigMap(nil, map[string][]byte{"..": nil}), "binary_data[..]"},
+		"duplicate key":      {newConfigMap(map[string]string{"k": "v"}, map[string][]byte{"k": nil}), "data[k]"},
+		"too large":          {newConfigMap(map[string]string{"k": strings.Repeat("a", MaxConfigMapSize+1)}, nil), "[]"},
+	}
+	for name, tc := range cases {
+		expectErrorOn(t, name, ValidateConfigMap(tc.cfg), tc.field)
+	}
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+var supportedDaemonSetStrategyTypes = []string{string(v1.InplaceUpdateDaemonSetStrategyType)}
+
+var supportedDaemonSetConditionTypes = sets.NewString(string(v1.DaemonSetAvailable))
+
+func ValidateDaemonSetName(name string, prefix bool) []string {
+	return NameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateDaemonSetSpec tests if required fields in the DaemonSet spec are set.
+func ValidateDaemonSetSpec(spec *v1.DaemonSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.RevisionHistoryLimit, fldPath.Child("revision_history_limit"))...)
+	allErrs = append(allErrs, ValidateWorkloadSelector(spec.Selector, "daemonset", fldPath.Child("selector"))...)
+	allErrs = append(allErrs, ValidatePodTemplateSpecForWorkload(&spec.Template, spec.Selector, []v1.RestartPolicy{v1.RestartPolicyAlways}, fldPath.Child("template"))...)
+
+	switch spec.Strategy.Type {
+	case v1.InplaceUpdateDaemonSetStrategyType:
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("strategy", "type"), ""))
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("strategy", "type"), spec.Strategy.Type, supportedDaemonSetStrategyTypes))
+	}
+
+	return allErrs
+}
+
+// ValidateDaemonSet validates a DaemonSet.
+func ValidateDaemonSet(ds *v1.DaemonSet) field.ErrorList {
+	allErrs := ValidateObjectMeta(&ds.ObjectMeta, true, ValidateDaemonSetName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateDaemonSetSpec(&ds.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateDaemonSetUpdate tests if an update to a DaemonSet is valid.
+func ValidateDaemonSetUpdate(ds, oldDs *v1.DaemonSet) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&ds.ObjectMeta, &oldDs.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateDaemonSetSpec(&ds.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, ValidateImmutableField(ds.Spec.Selector, oldDs.Spec.Selector, field.NewPath("spec", "selector"))...)
+	return allErrs
+}
+
+// ValidateDaemonSetStatusUpdate tests if an update to a DaemonSet status is valid.
+func ValidateDaemonSetStatusUpdate(ds, oldDs *v1.DaemonSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&ds.ObjectMeta, &oldDs.ObjectMeta, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateDaemonSetStatus(&ds.Status, field.NewPath("status"))...)
+	return allErrs
+}
+
+// ValidateDaemonSetStatus validates a given DaemonSetStatus.
+func ValidateDaemonSetStatus(status *v1.DaemonSetStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.DesiredNumberScheduled), fldPath.Child("desired_number_scheduled"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.CurrentNumberScheduled), fldPath.Child("current_number_scheduled"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.NumberReady), fldPath.Child("number_ready"))...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(int64(status.NumberReady), "number_ready", int64(status.DesiredNumberScheduled), "desired_number_scheduled", fldPath)...)
+
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	allErrs = append(allErrs, validateConditions(conditions, supportedDaemonSetConditionTypes, fldPath.Child("conditions"))...)
+	return allErrs
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+var supportedDeploymentStrategyTypes = []string{string(v1.InplaceUpdateDeploymentStrategyType)}
+
+var supportedDeploymentConditionTypes = sets.NewString(
+	string(v1.DeploymentAvailable),
+	string(v1.DeploymentProgressing),
+	string(v1.DeploymentReplicaFailure),
+)
+
+func ValidateDeploymentName(name string, prefix bool) []string {
+	return NameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateDeploymentSpec tests if required fields in the Deployment spec are set.
+func ValidateDeploymentSpec(spec *v1.DeploymentSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.Replicas, fldPath.Child("replicas"))...)
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.RevisionHistoryLimit, fldPath.Child("revision_history_limit"))...)
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.ProgressDeadlineSeconds, fldPath.Child("progress_deadline_seconds"))...)
+	allErrs = append(allErrs, ValidateWorkloadSelector(spec.Selector, "deployment", fldPath.Child("selector"))...)
+	allErrs = append(allErrs, ValidatePodTemplateSpecForWorkload(&spec.Template, spec.Selector, []v1.RestartPolicy{v1.RestartPolicyAlways}, fldPath.Child("template"))...)
+
+	switch spec.Strategy.Type {
+	case v1.InplaceUpdateDeploymentStrategyType:
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("strategy", "type"), ""))
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("strategy", "type"), spec.Strategy.Type, supportedDeploymentStrategyTypes))
+	}
+
+	return allErrs
+}
+
+// ValidateDeployment validates a Deployment.
+func ValidateDeployment(deployment *v1.Deployment) field.ErrorList {
+	allErrs := ValidateObjectMeta(&deployment.ObjectMeta, true, ValidateDeploymentName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateDeploymentSpec(&deployment.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateDeploymentUpdate tests if an update to a Deployment is valid.
+func ValidateDeploymentUpdate(deployment, oldDeployment *v1.Deployment) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&deployment.ObjectMeta, &oldDeployment.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateDeploymentSpec(&deployment.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, ValidateImmutableField(deployment.Spec.Selector, oldDeployment.Spec.Selector, field.NewPath("spec", "selector"))...)
+	return allErrs
+}
+
+// ValidateDeploymentStatusUpdate tests if an update to a Deployment status is valid.
+func ValidateDeploymentStatusUpdate(deployment, oldDeployment *v1.Deployment) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&deployment.ObjectMeta, &oldDeployment.ObjectMeta, field.NewPath("metadata"))...)
+	fldPath := field.NewPath("status")
+	allErrs = append(allErrs, ValidateDeploymentStatus(&deployment.Status, fldPath)...)
+	allErrs = append(allErrs, validateObservedGeneration(deployment.Status.ObservedGeneration, oldDeployment.Status.ObservedGeneration, fldPath.Child("observed_generation"))...)
+	return allErrs
+}
+
+// ValidateDeploymentStatus validates a given DeploymentStatus.
+func ValidateDeploymentStatus(status *v1.DeploymentStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.Replicas), fldPath.Child("replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.UpdatedReplicas), fldPath.Child("updated_replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.ReadyReplicas), fldPath.Child("ready_replicas"))...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(int64(status.UpdatedReplicas), "updated_replicas", int64(status.Replicas), "replicas", fldPath)...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(int64(status.ReadyReplicas), "ready_replicas", int64(status.Replicas), "replicas", fldPath)...)
+
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	allErrs = append(allErrs, validateConditions(conditions, supportedDeploymentConditionTypes, fldPath.Child("conditions"))...)
+	return allErrs
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+var eventTypes = sets.NewString(v1.EventTypeNormal, v1.EventTypeWarning)
+
+// ValidateEventName can be used to check whether the given event name is valid.
+var ValidateEventName = NameIsDNSSubdomain
+
+// ValidateEvent makes sure that the event makes sense.
+func ValidateEvent(event *v1.Event) field.ErrorList {
+	allErrs := ValidateObjectMeta(&event.ObjectMeta, true, ValidateEventName, field.NewPath("metadata"))
+
+	involvedPath := field.NewPath("involved_object")
+	if len(event.InvolvedObject.Kind) == 0 {
+		allErrs = append(allErrs, field.Required(involvedPath.Child("kind"), ""))
+	}
+	if len(event.InvolvedObject.Name) == 0 {
+		allErrs = append(allErrs, field.Required(involvedPath.Child("name"), ""))
+	}
+	// 事件必须和所关联的对象在同一namespace下，集群级别的对象(node、namespace)除外
+	if len(event.InvolvedObject.Namespace) != 0 && event.InvolvedObject.Namespace != event.Namespace {
+		allErrs = append(allErrs, field.Invalid(involvedPath.Child("namespace"), event.InvolvedObject.Namespace, "does not match event.namespace"))
+	}
+
+	if len(event.Type) != 0 && !eventTypes.Has(event.Type) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("type"), event.Type, eventTypes.List()))
+	}
+	allErrs = append(allErrs, ValidateNonnegativeField(event.Count, field.NewPath("count"))...)
+	if !event.FirstTime.IsZero() && !event.LastTime.IsZero() && event.LastTime.Before(event.FirstTime) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("last_time"), event.LastTime, "must not be before first_time"))
+	}
+
+	return allErrs
+}
+
+// ValidateEventUpdate makes sure that an update to an event makes sense. The
+// involved object can not change once the event is recorded.
+func ValidateEventUpdate(newEvent, oldEvent *v1.Event) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newEvent.ObjectMeta, &oldEvent.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateEvent(newEvent)...)
+	allErrs = append(allErrs, ValidateImmutableField(newEvent.InvolvedObject, oldEvent.InvolvedObject, field.NewPath("involved_object"))...)
+	allErrs = append(allErrs, ValidateImmutableField(newEvent.Source, oldEvent.Source, field.NewPath("source"))...)
+	allErrs = append(allErrs, ValidateImmutableField(newEvent.FirstTime, oldEvent.FirstTime, field.NewPath("first_time"))...)
+	return allErrs
+}
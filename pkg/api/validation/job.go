@@ -0,0 +1,75 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+var supportedJobConditionTypes = sets.NewString(
+	string(v1.JobSuspended),
+	string(v1.JobComplete),
+	string(v1.JobFailed),
+)
+
+func ValidateJobName(name string, prefix bool) []string {
+	return NameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateJobSpec tests if required fields in the Job spec are set.
+func ValidateJobSpec(spec *v1.JobSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.Parallelism, fldPath.Child("parallelism"))...)
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.Completions, fldPath.Child("completions"))...)
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.BackoffLimit, fldPath.Child("backoff_limit"))...)
+	if spec.ActiveDeadlineSeconds != nil && *spec.ActiveDeadlineSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("active_deadline_seconds"), *spec.ActiveDeadlineSeconds, "must be greater than 0"))
+	}
+	allErrs = append(allErrs, ValidateWorkloadSelector(spec.Selector, "job", fldPath.Child("selector"))...)
+	// job的pod运行结束后不再重启
+	allErrs = append(allErrs, ValidatePodTemplateSpecForWorkload(&spec.Template, spec.Selector, []v1.RestartPolicy{v1.RestartPolicyNever, v1.RestartPolicyOnFailure}, fldPath.Child("template"))...)
+
+	return allErrs
+}
+
+// ValidateJob validates a Job.
+func ValidateJob(job *v1.Job) field.ErrorList {
+	allErrs := ValidateObjectMeta(&job.ObjectMeta, true, ValidateJobName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateJobSpec(&job.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateJobUpdate tests if an update to a Job is valid.
+func ValidateJobUpdate(job, oldJob *v1.Job) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&job.ObjectMeta, &oldJob.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateJobSpec(&job.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, ValidateImmutableField(job.Spec.Selector, oldJob.Spec.Selector, field.NewPath("spec", "selector"))...)
+	return allErrs
+}
+
+// ValidateJobStatusUpdate tests if an update to a Job status is valid.
+func ValidateJobStatusUpdate(job, oldJob *v1.Job) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&job.ObjectMeta, &oldJob.ObjectMeta, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateJobStatus(&job.Status, field.NewPath("status"))...)
+	return allErrs
+}
+
+// ValidateJobStatus validates a given JobStatus.
+func ValidateJobStatus(status *v1.JobStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.Active), fldPath.Child("active"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.Succeeded), fldPath.Child("succeeded"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(int64(status.Failed), fldPath.Child("failed"))...)
+	if !status.StartTime.IsZero() && !status.CompletionTime.IsZero() && status.CompletionTime.Before(status.StartTime) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("completion_time"), status.CompletionTime, "must not be before start_time"))
+	}
+
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	allErrs = append(allErrs, validateConditions(conditions, supportedJobConditionTypes, fldPath.Child("conditions"))...)
+	return allErrs
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"fmt"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateLimitRangeName can be used to check whether the given limit range name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+var ValidateLimitRangeName = NameIsDNSSubdomain
+
+// ValidateLimitRange tests if required fields in the LimitRange are set.
+func ValidateLimitRange(limitRange *v1.LimitRange) field.ErrorList {
+	allErrs := ValidateObjectMeta(&limitRange.ObjectMeta, true, ValidateLimitRangeName, field.NewPath("metadata"))
+
+	limitsPath := field.NewPath("spec", "limits")
+	limitTypes := map[v1.LimitType]bool{}
+	for i := range limitRange.Spec.Limits {
+		idxPath := limitsPath.Index(i)
+		limit := &limitRange.Spec.Limits[i]
+
+		if limit.Type != v1.LimitTypeContainer {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), limit.Type, []string{string(v1.LimitTypeContainer)}))
+		} else if limitTypes[limit.Type] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("type"), limit.Type))
+		}
+		limitTypes[limit.Type] = true
+
+		allErrs = append(allErrs, validateNodeResourceList(limit.Max, idxPath.Child("max"))...)
+		allErrs = append(allErrs, validateNodeResourceList(limit.Min, idxPath.Child("min"))...)
+		allErrs = append(allErrs, validateNodeResourceList(limit.Default, idxPath.Child("default"))...)
+		allErrs = append(allErrs, validateNodeResourceList(limit.DefaultRequest, idxPath.Child("default_request"))...)
+
+		// min <= default_request <= default <= max
+		for resourceName, min := range limit.Min {
+			if max, ok := limit.Max[resourceName]; ok && min.Cmp(max) > 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("min").Key(string(resourceName)), min.String(),
+					fmt.Sprintf("min value %s is greater than max value %s", min.String(), max.String())))
+			}
+		}
+		for resourceName, value := range limit.DefaultRequest {
+			if min, ok := limit.Min[resourceName]; ok && min.Cmp(value) > 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("default_request").Key(string(resourceName)), value.String(),
+					fmt.Sprintf("min value %s is greater than default request value %s", min.String(), value.String())))
+			}
+			if def, ok := limit.Default[resourceName]; ok && value.Cmp(def) > 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("default_request").Key(string(resourceName)), value.String(),
+					fmt.Sprintf("default request value %s is greater than default limit value %s", value.String(), def.String())))
+			}
+		}
+		for resourceName, value := range limit.Default {
+			if max, ok := limit.Max[resourceName]; ok && value.Cmp(max) > 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("default").Key(string(resourceName)), value.String(),
+					fmt.Sprintf("default value %s is greater than max value %s", value.String(), max.String())))
+			}
+			if min, ok := limit.Min[resourceName]; ok && min.Cmp(value) > 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("default").Key(string(resourceName)), value.String(),
+					fmt.Sprintf("min value %s is greater than default value %s", min.String(), value.String())))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateLimitRangeUpdate tests to see if the update is legal for an end user to make.
+func ValidateLimitRangeUpdate(newLimitRange, oldLimitRange *v1.LimitRange) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newLimitRange.ObjectMeta, &oldLimitRange.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateLimitRange(newLimitRange)...)
+	return allErrs
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/resource"
+)
+
+func TestValidateLimitRange(t *testing.T) {
+	limitRange := &v1.LimitRange{
+		ObjectMeta: v1.ObjectMeta{Name: "limits", Namespace: "team-a"},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+			Type:           v1.LimitTypeContainer,
+			Max:            v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			Min:            v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			Default:        v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			DefaultRequest: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+		}}},
+	}
+	if errs := ValidateLimitRange(limitRange); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	invalid := limitRange.DeepCopy()
+	invalid.Spec.Limits[0].Default[v1.ResourceCPU] = resource.MustParse("4")
+	expectErrorOn(t, "default above max", ValidateLimitRange(invalid), "spec.limits[0].default[cpu]")
+
+	invalid = limitRange.DeepCopy()
+	invalid.Spec.Limits[0].DefaultRequest[v1.ResourceCPU] = resource.MustParse("50m")
+	expectErrorOn(t, "default request below min", ValidateLimitRange(invalid), "spec.limits[0].default_request[cpu]")
+
+	invalid = limitRange.DeepCopy()
+	invalid.Spec.Limits[0].Type = "pod"
+	expectErrorOn(t, "unknown type", ValidateLimitRange(invalid), "spec.limits[0].type")
+}
@@ -0,0 +1,30 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateNamespace tests if required fields are set.
+func ValidateNamespace(namespace *v1.Namespace) field.ErrorList {
+	allErrs := ValidateObjectMeta(&namespace.ObjectMeta, false, ValidateNamespaceName, field.NewPath("metadata"))
+	return allErrs
+}
+
+// ValidateNamespaceUpdate tests to make sure a namespace update can be applied.
+func ValidateNamespaceUpdate(newNamespace *v1.Namespace, oldNamespace *v1.Namespace) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newNamespace.ObjectMeta, &oldNamespace.ObjectMeta, field.NewPath("metadata"))
+	return allErrs
+}
+
+// ValidateNamespaceStatusUpdate tests to see if the update is legal for an end user to make.
+func ValidateNamespaceStatusUpdate(newNamespace, oldNamespace *v1.Namespace) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newNamespace.ObjectMeta, &oldNamespace.ObjectMeta, field.NewPath("metadata"))
+	switch newNamespace.Status.Phase {
+	case v1.NamespaceActive, v1.NamespaceTerminating:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("status", "phase"), newNamespace.Status.Phase,
+			[]string{string(v1.NamespaceActive), string(v1.NamespaceTerminating)}))
+	}
+	return allErrs
+}
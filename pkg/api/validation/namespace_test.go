@@ -0,0 +1,15 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func TestValidateNamespace(t *testing.T) {
+	if errs := ValidateNamespace(&v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "team-a"}}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	expectErrorOn(t, "invalid name", ValidateNamespace(&v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "team.a"}}), "metadata.name")
+	expectErrorOn(t, "namespace set", ValidateNamespace(&v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "a", Namespace: "b"}}), "metadata.namespace")
+}
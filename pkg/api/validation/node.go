@@ -0,0 +1,43 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateNode tests if required fields in the node are set.
+func ValidateNode(node *v1.Node) field.ErrorList {
+	allErrs := ValidateObjectMeta(&node.ObjectMeta, false, ValidateNodeName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateNodeSpec(&node.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateNodeSpec tests if required fields in the node spec are set.
+func ValidateNodeSpec(spec *v1.NodeSpec, fldPath *field.Path) field.ErrorList {
+	return validateNodeResourceList(spec.Capacity, fldPath.Child("capacity"))
+}
+
+// validateNodeResourceList 节点容量只允许使用已知的资源名
+func validateNodeResourceList(resources v1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for resourceName, quantity := range resources {
+		resPath := fldPath.Key(string(resourceName))
+		allErrs = append(allErrs, validateResourceName(string(resourceName), resPath)...)
+		allErrs = append(allErrs, validateBasicResource(quantity, resPath)...)
+	}
+	return allErrs
+}
+
+// ValidateNodeUpdate tests to make sure a node update can be applied.
+func ValidateNodeUpdate(node, oldNode *v1.Node) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&node.ObjectMeta, &oldNode.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateNodeSpec(&node.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateNodeStatusUpdate tests to make sure a node status update can be applied.
+func ValidateNodeStatusUpdate(node, oldNode *v1.Node) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&node.ObjectMeta, &oldNode.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateNodeResourceList(node.Status.Capacity, field.NewPath("status", "capacity"))...)
+	return allErrs
+}
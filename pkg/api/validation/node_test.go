@@ -0,0 +1,23 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/resource"
+)
+
+func TestValidateNode(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: "host-1"},
+		Spec: v1.NodeSpec{Capacity: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("4"),
+			v1.ResourceMemory: resource.MustParse("8Gi"),
+		}},
+	}
+	if errs := ValidateNode(node); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	node.Spec.Capacity["gpu"] = resource.MustParse("1")
+	expectErrorOn(t, "unknown resource", ValidateNode(node), "spec.capacity[gpu]")
+}
@@ -29,10 +29,13 @@ func ValidateObjectMetaAccessor(meta metav1.Object, requiresNamespace bool, name
 		}
 	}
 
+	// 未指定name时，由服务端根据generate_name生成
 	if len(meta.GetName()) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name or generate_name is required"))
+		if len(meta.GetGenerateName()) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name or generate_name is required"))
+		}
 	} else {
-		for _, msg := range nameFn(meta.GetName(), true) {
+		for _, msg := range nameFn(meta.GetName(), false) {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), meta.GetName(), msg))
 		}
 	}
@@ -46,8 +49,8 @@ func ValidateObjectMetaAccessor(meta metav1.Object, requiresNamespace bool, name
 			}
 		}
 	} else {
-		if len(meta.GetNamespace()) == 0 {
-			allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), "not allow on this type"))
+		if len(meta.GetNamespace()) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("namespace"), "not allowed on this type"))
 		}
 	}
 
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+func TestValidateObjectMetaName(t *testing.T) {
+	tests := []struct {
+		name        string
+		meta        v1.ObjectMeta
+		expectError string
+	}{
+		{name: "name", meta: v1.ObjectMeta{Name: "foo", Namespace: "default"}},
+		{name: "generate name only", meta: v1.ObjectMeta{GenerateName: "foo-", Namespace: "default"}},
+		{name: "name and generate name", meta: v1.ObjectMeta{Name: "foo", GenerateName: "foo-", Namespace: "default"}},
+		{name: "neither", meta: v1.ObjectMeta{Namespace: "default"}, expectError: "metadata.name"},
+		{name: "invalid generate name", meta: v1.ObjectMeta{GenerateName: "Foo-", Namespace: "default"}, expectError: "metadata.generate_name"},
+		// 前缀可以以'-'结尾，完整的名称不可以
+		{name: "name ending with dash", meta: v1.ObjectMeta{Name: "foo-", Namespace: "default"}, expectError: "metadata.name"},
+	}
+	for _, tc := range tests {
+		errs := ValidateObjectMeta(&tc.meta, true, NameIsDNSSubdomain, field.NewPath("metadata"))
+		if tc.expectError == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: unexpected errors: %v", tc.name, errs)
+			}
+			continue
+		}
+		expectErrorOn(t, tc.name, errs, tc.expectError)
+	}
+}
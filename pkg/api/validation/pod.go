@@ -595,6 +595,7 @@ func ValidatePodUpdate(newPod, oldPod *v1.Pod) field.ErrorList {
 	// 4.  spec.UninstallationContainers[*].image
 	// 5.  spec.activeDeadlineSeconds
 	// 6.  spec.terminationGracePeriodSeconds
+	// 7.  spec.suspended
 	containerErrs, stop := ValidateContainerUpdates(newPod.Spec.Containers, oldPod.Spec.Containers, specPath.Child("containers"))
 	allErrs = append(allErrs, containerErrs...)
 	if stop {
@@ -680,6 +681,9 @@ func ValidatePodUpdate(newPod, oldPod *v1.Pod) field.ErrorList {
 		mungedPodSpec.ActiveDeadlineSeconds = &activeDeadlineSeconds
 	}
 
+	// munged spec.suspended, pods are stopped and started again through it
+	mungedPodSpec.Suspended = oldPod.Spec.Suspended
+
 	// Relax validation of immutable fields to allow it to be set to 1 if it was previously negative.
 	if oldPod.Spec.TerminationGracePeriodSeconds != nil && *oldPod.Spec.TerminationGracePeriodSeconds < 0 &&
 		mungedPodSpec.TerminationGracePeriodSeconds != nil && *mungedPodSpec.TerminationGracePeriodSeconds == 1 {
@@ -688,7 +692,7 @@ func ValidatePodUpdate(newPod, oldPod *v1.Pod) field.ErrorList {
 
 	if !reflect.DeepEqual(mungedPodSpec, oldPod.Spec) {
 		specDiff := cmp.Diff(oldPod.Spec, mungedPodSpec)
-		allErrs = append(allErrs, field.Forbidden(specPath, fmt.Sprintf("pod updates may not change fields other than `spec.containers[*].image`, `spec.init_containers[*].image`, `spec.active_deadline_seconds` or `spec.termination_grace_period_seconds` (allow it to be set to 1 if it was previously negative)\n%v", specDiff)))
+		allErrs = append(allErrs, field.Forbidden(specPath, fmt.Sprintf("pod updates may not change fields other than `spec.containers[*].image`, `spec.init_containers[*].image`, `spec.active_deadline_seconds`, `spec.suspended` or `spec.termination_grace_period_seconds` (allow it to be set to 1 if it was previously negative)\n%v", specDiff)))
 	}
 
 	return allErrs
@@ -734,3 +738,81 @@ func ValidateContainerUpdates(newContainers, oldContainers []v1.Container, fldPa
 	}
 	return allErrs, false
 }
+
+var supportedPodPhases = sets.NewString(
+	string(v1.PodPending),
+	string(v1.PodRunning),
+	string(v1.PodSucceeded),
+	string(v1.PodFailed),
+	string(v1.PodSuspended),
+	string(v1.PodUnknown),
+)
+
+var supportedPodConditionTypes = sets.NewString(
+	string(v1.PodScheduled),
+	string(v1.PodContainersReady),
+	string(v1.PodInstalled),
+	string(v1.PodInitialized),
+	string(v1.PodReady),
+	string(v1.PodReasonUnschedulable),
+)
+
+// ValidatePodStatusUpdate tests to see if the update is legal for an end user to make.
+// newPod is updated with fields that cannot be changed.
+func ValidatePodStatusUpdate(newPod, oldPod *v1.Pod) field.ErrorList {
+	fldPath := field.NewPath("metadata")
+	allErrs := ValidateObjectMetaUpdate(&newPod.ObjectMeta, &oldPod.ObjectMeta, fldPath)
+	allErrs = append(allErrs, ValidatePodSpecificAnnotationUpdates(newPod, oldPod, fldPath.Child("annotations"))...)
+	allErrs = append(allErrs, ValidatePodStatus(&newPod.Status, &newPod.Spec, field.NewPath("status"))...)
+	return allErrs
+}
+
+// ValidatePodStatus validates the status of a pod against its spec.
+func ValidatePodStatus(status *v1.PodStatus, spec *v1.PodSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(status.Phase) != 0 && !supportedPodPhases.Has(string(status.Phase)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("phase"), status.Phase, supportedPodPhases.List()))
+	}
+	if len(status.HostIp) != 0 {
+		for _, msg := range validation.IsValidIP(status.HostIp) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("host_ip"), status.HostIp, msg))
+		}
+	}
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(status.TaintRestarts, fldPath.Child("taint_restarts"))...)
+
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	allErrs = append(allErrs, validateConditions(conditions, supportedPodConditionTypes, fldPath.Child("conditions"))...)
+
+	allErrs = append(allErrs, validateContainerStatuses(status.ContainerStatuses, spec.Containers, fldPath.Child("container_statuses"))...)
+	allErrs = append(allErrs, validateContainerStatuses(status.InitContainerStatuses, spec.InitContainers, fldPath.Child("init_container_statuses"))...)
+	allErrs = append(allErrs, validateContainerStatuses(status.InstallationContainerStatuses, spec.InstallationContainers, fldPath.Child("installation_container_statuses"))...)
+	allErrs = append(allErrs, validateContainerStatuses(status.UninstallationContainerStatuses, spec.UninstallationContainers, fldPath.Child("uninstallation_container_statuses"))...)
+
+	return allErrs
+}
+
+// validateContainerStatuses checks that every status refers to exactly one of containers.
+func validateContainerStatuses(statuses []v1.ContainerStatus, containers []v1.Container, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	containerNames := sets.NewString()
+	for _, c := range containers {
+		containerNames.Insert(c.Name)
+	}
+	seen := sets.NewString()
+	for i, s := range statuses {
+		idxPath := fldPath.Index(i)
+		if !containerNames.Has(s.Name) {
+			allErrs = append(allErrs, field.NotFound(idxPath.Child("name"), s.Name))
+		} else if seen.Has(s.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), s.Name))
+		} else {
+			seen.Insert(s.Name)
+		}
+		allErrs = append(allErrs, ValidateNonnegativeField(s.RestartCount, idxPath.Child("restart_count"))...)
+	}
+	return allErrs
+}
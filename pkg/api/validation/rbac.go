@@ -0,0 +1,123 @@
+package validation
+
+import (
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateRBACName can be used to check whether the given name of a role or
+// binding is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+var ValidateRBACName = NameIsDNSSubdomain
+
+var supportedSubjectKinds = sets.NewString(rbacv1.UserKind, rbacv1.GroupKind)
+
+// ValidateRole tests if required fields in the Role are set.
+func ValidateRole(role *rbacv1.Role) field.ErrorList {
+	allErrs := ValidateObjectMeta(&role.ObjectMeta, true, ValidateRBACName, field.NewPath("metadata"))
+	allErrs = append(allErrs, validatePolicyRules(role.Rules, field.NewPath("rules"))...)
+	return allErrs
+}
+
+// ValidateRoleUpdate tests to see if the update is legal for an end user to make.
+func ValidateRoleUpdate(newRole, oldRole *rbacv1.Role) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newRole.ObjectMeta, &oldRole.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateRole(newRole)...)
+	return allErrs
+}
+
+// ValidateClusterRole tests if required fields in the ClusterRole are set.
+func ValidateClusterRole(role *rbacv1.ClusterRole) field.ErrorList {
+	allErrs := ValidateObjectMeta(&role.ObjectMeta, false, ValidateRBACName, field.NewPath("metadata"))
+	allErrs = append(allErrs, validatePolicyRules(role.Rules, field.NewPath("rules"))...)
+	return allErrs
+}
+
+// ValidateClusterRoleUpdate tests to see if the update is legal for an end user to make.
+func ValidateClusterRoleUpdate(newRole, oldRole *rbacv1.ClusterRole) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newRole.ObjectMeta, &oldRole.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateClusterRole(newRole)...)
+	return allErrs
+}
+
+// ValidateRoleBinding tests if required fields in the RoleBinding are set.
+func ValidateRoleBinding(binding *rbacv1.RoleBinding) field.ErrorList {
+	allErrs := ValidateObjectMeta(&binding.ObjectMeta, true, ValidateRBACName, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateRoleRef(binding.RoleRef, []string{rbacv1.RoleKind, rbacv1.ClusterRoleKind}, field.NewPath("role_ref"))...)
+	allErrs = append(allErrs, validateSubjects(binding.Subjects, field.NewPath("subjects"))...)
+	return allErrs
+}
+
+// ValidateRoleBindingUpdate tests to see if the update is legal for an end
+// user to make. The role a binding refers to cannot change.
+func ValidateRoleBindingUpdate(newBinding, oldBinding *rbacv1.RoleBinding) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newBinding.ObjectMeta, &oldBinding.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateImmutableField(newBinding.RoleRef, oldBinding.RoleRef, field.NewPath("role_ref"))...)
+	allErrs = append(allErrs, ValidateRoleBinding(newBinding)...)
+	return allErrs
+}
+
+// ValidateClusterRoleBinding tests if required fields in the ClusterRoleBinding are set.
+func ValidateClusterRoleBinding(binding *rbacv1.ClusterRoleBinding) field.ErrorList {
+	allErrs := ValidateObjectMeta(&binding.ObjectMeta, false, ValidateRBACName, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateRoleRef(binding.RoleRef, []string{rbacv1.ClusterRoleKind}, field.NewPath("role_ref"))...)
+	allErrs = append(allErrs, validateSubjects(binding.Subjects, field.NewPath("subjects"))...)
+	return allErrs
+}
+
+// ValidateClusterRoleBindingUpdate tests to see if the update is legal for an
+// end user to make. The role a binding refers to cannot change.
+func ValidateClusterRoleBindingUpdate(newBinding, oldBinding *rbacv1.ClusterRoleBinding) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newBinding.ObjectMeta, &oldBinding.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateImmutableField(newBinding.RoleRef, oldBinding.RoleRef, field.NewPath("role_ref"))...)
+	allErrs = append(allErrs, ValidateClusterRoleBinding(newBinding)...)
+	return allErrs
+}
+
+func validatePolicyRules(rules []rbacv1.PolicyRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, rule := range rules {
+		idxPath := fldPath.Index(i)
+		if len(rule.Verbs) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("verbs"), "verbs must contain at least one value"))
+		}
+		if len(rule.APIGroups) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("api_groups"), "resource rules must supply at least one api group"))
+		}
+		if len(rule.Resources) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("resources"), "resource rules must supply at least one resource"))
+		}
+	}
+	return allErrs
+}
+
+func validateRoleRef(roleRef rbacv1.RoleRef, kinds []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(roleRef.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range ValidateRBACName(roleRef.Name, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), roleRef.Name, msg))
+		}
+	}
+	if !sets.NewString(kinds...).Has(roleRef.Kind) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), roleRef.Kind, kinds))
+	}
+	return allErrs
+}
+
+func validateSubjects(subjects []rbacv1.Subject, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, subject := range subjects {
+		idxPath := fldPath.Index(i)
+		if len(subject.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		}
+		if !supportedSubjectKinds.Has(subject.Kind) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("kind"), subject.Kind, supportedSubjectKinds.List()))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+)
+
+func TestValidateRole(t *testing.T) {
+	newRole := func(rule rbacv1.PolicyRule) *rbacv1.Role {
+		return &rbacv1.Role{
+			ObjectMeta: v1.ObjectMeta{Name: "pod-admin", Namespace: "team-a"},
+			Rules:      []rbacv1.PolicyRule{rule},
+		}
+	}
+	if errs := ValidateRole(newRole(rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{"carry.i"}, Resources: []string{"pods"}})); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		rule  rbacv1.PolicyRule
+		field string
+	}{
+		"no verbs":      {rbacv1.PolicyRule{APIGroups: []string{"carry.i"}, Resources: []string{"pods"}}, "rules[0].verbs"},
+		"no api groups": {rbacv1.PolicyRule{Verbs: []string{"get"}, Resources: []string{"pods"}}, "rules[0].api_groups"},
+		"no resources":  {rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{"carry.i"}}, "rules[0].resources"},
+	}
+	for name, tc := range cases {
+		expectErrorOn(t, name, ValidateRole(newRole(tc.rule)), tc.field)
+	}
+	expectErrorOn(t, "cluster role in a namespace", ValidateClusterRole(&rbacv1.ClusterRole{ObjectMeta: v1.ObjectMeta{Name: "viewer", Namespace: "team-a"}}), "metadata.namespace")
+}
+
+func TestValidateRoleBinding(t *testing.T) {
+	newBinding := func(roleRef rbacv1.RoleRef, subjects ...rbacv1.Subject) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "binding", Namespace: "team-a"},
+			Subjects:   subjects,
+			RoleRef:    roleRef,
+		}
+	}
+	valid := newBinding(rbacv1.RoleRef{Kind: rbacv1.ClusterRoleKind, Name: "viewer"}, rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"})
+	if errs := ValidateRoleBinding(valid); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		binding *rbacv1.RoleBinding
+		field   string
+	}{
+		"no role name":    {newBinding(rbacv1.RoleRef{Kind: rbacv1.RoleKind}), "role_ref.name"},
+		"bad role kind":   {newBinding(rbacv1.RoleRef{Kind: "pod", Name: "x"}), "role_ref.kind"},
+		"bad subject":     {newBinding(valid.RoleRef, rbacv1.Subject{Kind: "serviceaccount", Name: "x"}), "subjects[0].kind"},
+		"no subject name": {newBinding(valid.RoleRef, rbacv1.Subject{Kind: rbacv1.GroupKind}), "subjects[0].name"},
+	}
+	for name, tc := range cases {
+		expectErrorOn(t, name, ValidateRoleBinding(tc.binding), tc.field)
+	}
+
+	changed := valid.DeepCopy()
+	changed.ResourceVersion = "1"
+	old := changed.DeepCopy()
+	changed.RoleRef.Name = "admin"
+	expectErrorOn(t, "changed role", ValidateRoleBindingUpdate(changed, old), "role_ref")
+
+	clusterBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "binding"},
+		RoleRef:    rbacv1.RoleRef{Kind: rbacv1.RoleKind, Name: "pod-admin"},
+	}
+	expectErrorOn(t, "cluster binding to a role", ValidateClusterRoleBinding(clusterBinding), "role_ref.kind")
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+var supportedReplicaSetStrategyTypes = []string{string(v1.InplaceUpdateReplicaSetStrategyType)}
+
+var supportedReplicaSetConditionTypes = sets.NewString(string(v1.ReplicaSetReplicaFailure))
+
+func ValidateReplicaSetName(name string, prefix bool) []string {
+	return NameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateReplicaSetSpec tests if required fields in the ReplicaSet spec are set.
+func ValidateReplicaSetSpec(spec *v1.ReplicaSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(spec.Replicas, fldPath.Child("replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(spec.MinReadySeconds, fldPath.Child("min_ready_seconds"))...)
+	allErrs = append(allErrs, ValidateWorkloadSelector(spec.Selector, "replicaset", fldPath.Child("selector"))...)
+	allErrs = append(allErrs, ValidatePodTemplateSpecForWorkload(&spec.Template, spec.Selector, []v1.RestartPolicy{v1.RestartPolicyAlways}, fldPath.Child("template"))...)
+
+	switch spec.Strategy.Type {
+	case v1.InplaceUpdateReplicaSetStrategyType:
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("strategy", "type"), ""))
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("strategy", "type"), spec.Strategy.Type, supportedReplicaSetStrategyTypes))
+	}
+
+	return allErrs
+}
+
+// ValidateReplicaSet validates a ReplicaSet.
+func ValidateReplicaSet(rs *v1.ReplicaSet) field.ErrorList {
+	allErrs := ValidateObjectMeta(&rs.ObjectMeta, true, ValidateReplicaSetName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateReplicaSetSpec(&rs.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateReplicaSetUpdate tests if an update to a ReplicaSet is valid.
+func ValidateReplicaSetUpdate(rs, oldRs *v1.ReplicaSet) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&rs.ObjectMeta, &oldRs.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateReplicaSetSpec(&rs.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, ValidateImmutableField(rs.Spec.Selector, oldRs.Spec.Selector, field.NewPath("spec", "selector"))...)
+	return allErrs
+}
+
+// ValidateReplicaSetStatusUpdate tests if an update to a ReplicaSet status is valid.
+func ValidateReplicaSetStatusUpdate(rs, oldRs *v1.ReplicaSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&rs.ObjectMeta, &oldRs.ObjectMeta, field.NewPath("metadata"))...)
+	fldPath := field.NewPath("status")
+	allErrs = append(allErrs, ValidateReplicaSetStatus(&rs.Status, fldPath)...)
+	allErrs = append(allErrs, validateObservedGeneration(rs.Status.ObservedGeneration, oldRs.Status.ObservedGeneration, fldPath.Child("observed_generation"))...)
+	return allErrs
+}
+
+// ValidateReplicaSetStatus validates a given ReplicaSetStatus.
+func ValidateReplicaSetStatus(status *v1.ReplicaSetStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateNonnegativeField(status.Replicas, fldPath.Child("replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.FullyLabeledReplicas, fldPath.Child("fully_labeled_replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.UpdatedReplicas, fldPath.Child("updated_replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.ReadyReplicas, fldPath.Child("ready_replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.AvailableReplicas, fldPath.Child("available_replicas"))...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.FullyLabeledReplicas, "fully_labeled_replicas", status.Replicas, "replicas", fldPath)...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.UpdatedReplicas, "updated_replicas", status.Replicas, "replicas", fldPath)...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.ReadyReplicas, "ready_replicas", status.Replicas, "replicas", fldPath)...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.AvailableReplicas, "available_replicas", status.ReadyReplicas, "ready_replicas", fldPath)...)
+
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	allErrs = append(allErrs, validateConditions(conditions, supportedReplicaSetConditionTypes, fldPath.Child("conditions"))...)
+	return allErrs
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"fmt"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateResourceQuotaName can be used to check whether the given resource quota name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+var ValidateResourceQuotaName = NameIsDNSSubdomain
+
+// quotaResourceNames 可以在ResourceQuota中限制的资源名
+var quotaResourceNames = []string{
+	string(v1.ResourcePods),
+	string(v1.ResourceServices),
+	string(v1.ResourceConfigMaps),
+	string(v1.ResourceCPU),
+	string(v1.ResourceMemory),
+	string(v1.ResourceRequestsCPU),
+	string(v1.ResourceRequestsMemory),
+	string(v1.ResourceLimitsCPU),
+	string(v1.ResourceLimitsMemory),
+}
+
+// ValidateResourceQuota tests if required fields in the ResourceQuota are set.
+func ValidateResourceQuota(quota *v1.ResourceQuota) field.ErrorList {
+	allErrs := ValidateObjectMeta(&quota.ObjectMeta, true, ValidateResourceQuotaName, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateQuotaResourceList(quota.Spec.Hard, field.NewPath("spec", "hard"))...)
+	return allErrs
+}
+
+// ValidateResourceQuotaUpdate tests to see if the update is legal for an end user to make.
+func ValidateResourceQuotaUpdate(newQuota, oldQuota *v1.ResourceQuota) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newQuota.ObjectMeta, &oldQuota.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateQuotaResourceList(newQuota.Spec.Hard, field.NewPath("spec", "hard"))...)
+	return allErrs
+}
+
+// ValidateResourceQuotaStatusUpdate tests to see if the status update is legal for an end user to make.
+func ValidateResourceQuotaStatusUpdate(newQuota, oldQuota *v1.ResourceQuota) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&newQuota.ObjectMeta, &oldQuota.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateQuotaResourceList(newQuota.Status.Hard, field.NewPath("status", "hard"))...)
+	allErrs = append(allErrs, validateQuotaResourceList(newQuota.Status.Used, field.NewPath("status", "used"))...)
+	return allErrs
+}
+
+func validateQuotaResourceList(resources v1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for resourceName, quantity := range resources {
+		resPath := fldPath.Key(string(resourceName))
+		if !isQuotaResourceName(resourceName) {
+			allErrs = append(allErrs, field.NotSupported(resPath, resourceName, quotaResourceNames))
+			continue
+		}
+		allErrs = append(allErrs, validateBasicResource(quantity, resPath)...)
+		// 对象数量必须是整数
+		if isObjectCountResourceName(resourceName) && quantity.MilliValue()%1000 != 0 {
+			allErrs = append(allErrs, field.Invalid(resPath, quantity.String(), fmt.Sprintf("must be an integer for %s", resourceName)))
+		}
+	}
+	return allErrs
+}
+
+func isQuotaResourceName(name v1.ResourceName) bool {
+	for _, n := range quotaResourceNames {
+		if string(name) == n {
+			return true
+		}
+	}
+	return false
+}
+
+func isObjectCountResourceName(name v1.ResourceName) bool {
+	switch name {
+	case v1.ResourcePods, v1.ResourceServices, v1.ResourceConfigMaps:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/resource"
+)
+
+func TestValidateResourceQuota(t *testing.T) {
+	quota := &v1.ResourceQuota{
+		ObjectMeta: v1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+		Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{
+			v1.ResourcePods:         resource.MustParse("10"),
+			v1.ResourceRequestsCPU:  resource.MustParse("4"),
+			v1.ResourceLimitsMemory: resource.MustParse("8Gi"),
+		}},
+	}
+	if errs := ValidateResourceQuota(quota); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	quota.Spec.Hard[v1.ResourcePods] = resource.MustParse("1500m")
+	expectErrorOn(t, "fractional count", ValidateResourceQuota(quota), "spec.hard[pods]")
+	delete(quota.Spec.Hard, v1.ResourcePods)
+	quota.Spec.Hard["gpu"] = resource.MustParse("1")
+	expectErrorOn(t, "unknown resource", ValidateResourceQuota(quota), "spec.hard[gpu]")
+}
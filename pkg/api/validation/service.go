@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"fmt"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateServiceName can be used to check whether the given service name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+var ValidateServiceName = NameIsDNS1035Label
+
+var supportedServiceConditionTypes = sets.NewString(string(v1.ServiceAvailable))
+
+// ValidateService tests if required fields/annotations of a Service are valid.
+func ValidateService(service *v1.Service) field.ErrorList {
+	allErrs := ValidateObjectMeta(&service.ObjectMeta, true, ValidateServiceName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateServiceSpec(&service.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateServiceSpec tests if required fields in the Service spec are set.
+func ValidateServiceSpec(spec *v1.ServiceSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateLabels(spec.Selector, fldPath.Child("selector"))...)
+
+	portsPath := fldPath.Child("ports")
+	requireName := len(spec.Ports) > 1
+	allPortNames := sets.String{}
+	allPorts := sets.String{}
+	for i := range spec.Ports {
+		port := &spec.Ports[i]
+		idxPath := portsPath.Index(i)
+		allErrs = append(allErrs, validateServicePort(port, requireName, &allPortNames, idxPath)...)
+
+		// 同一地址上的端口和协议组合不能重复
+		key := fmt.Sprintf("%s:%d/%s", port.Address, port.Port, port.Protocol)
+		if allPorts.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, key))
+		} else {
+			allPorts.Insert(key)
+		}
+	}
+
+	return allErrs
+}
+
+func validateServicePort(sp *v1.ServicePort, requireName bool, allNames *sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if requireName && len(sp.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else if len(sp.Name) != 0 {
+		allErrs = append(allErrs, ValidateDNS1123Label(sp.Name, fldPath.Child("name"))...)
+		if allNames.Has(sp.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), sp.Name))
+		} else {
+			allNames.Insert(sp.Name)
+		}
+	}
+
+	if len(sp.Address) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("address"), ""))
+	} else {
+		for _, msg := range validation.IsValidIP(sp.Address) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), sp.Address, msg))
+		}
+	}
+
+	for _, msg := range validation.IsValidPortNum(sp.Port) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), sp.Port, msg))
+	}
+	for _, msg := range validation.IsValidPortNum(sp.TargetPort) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("target_port"), sp.TargetPort, msg))
+	}
+
+	if len(sp.Protocol) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("protocol"), ""))
+	} else if !supportedPortProtocols.Has(string(sp.Protocol)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), sp.Protocol, supportedPortProtocols.List()))
+	}
+
+	allErrs = append(allErrs, ValidateAnnotations(sp.Annotations, fldPath.Child("annotations"))...)
+
+	return allErrs
+}
+
+// ValidateServiceUpdate tests if required fields in the service are set during an update
+func ValidateServiceUpdate(service, oldService *v1.Service) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&service.ObjectMeta, &oldService.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateServiceSpec(&service.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateServiceStatusUpdate tests if required fields in the Service are set when updating status.
+func ValidateServiceStatusUpdate(service, oldService *v1.Service) field.ErrorList {
+	allErrs := ValidateObjectMetaUpdate(&service.ObjectMeta, &oldService.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateServiceStatus(&service.Status, field.NewPath("status"))...)
+	return allErrs
+}
+
+// ValidateServiceStatus validates a given ServiceStatus.
+func ValidateServiceStatus(status *v1.ServiceStatus, fldPath *field.Path) field.ErrorList {
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	return validateConditions(conditions, supportedServiceConditionTypes, fldPath.Child("conditions"))
+}
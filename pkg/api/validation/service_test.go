@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func validService() *v1.Service {
+	return &v1.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "default", ResourceVersion: "1"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports: []v1.ServicePort{
+				{Name: "http", Address: "10.0.0.1", Port: 80, TargetPort: 8080, Protocol: v1.ProtocolTCP},
+				{Name: "dns", Address: "10.0.0.1", Port: 53, TargetPort: 53, Protocol: v1.ProtocolUDP},
+			},
+		},
+	}
+}
+
+func TestValidateService(t *testing.T) {
+	if errs := ValidateService(validService()); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		mutate func(s *v1.Service)
+		field  string
+	}{
+		"missing port name":    {func(s *v1.Service) { s.Spec.Ports[1].Name = "" }, "spec.ports[1].name"},
+		"duplicate port name":  {func(s *v1.Service) { s.Spec.Ports[1].Name = "http" }, "spec.ports[1].name"},
+		"invalid address":      {func(s *v1.Service) { s.Spec.Ports[0].Address = "10.0.0" }, "spec.ports[0].address"},
+		"port out of range":    {func(s *v1.Service) { s.Spec.Ports[0].Port = 70000 }, "spec.ports[0].port"},
+		"target port missing":  {func(s *v1.Service) { s.Spec.Ports[0].TargetPort = 0 }, "spec.ports[0].target_port"},
+		"unsupported protocol": {func(s *v1.Service) { s.Spec.Ports[0].Protocol = "sctp" }, "spec.ports[0].protocol"},
+		"duplicate port": {func(s *v1.Service) {
+			s.Spec.Ports[1].Port = 80
+			s.Spec.Ports[1].Protocol = v1.ProtocolTCP
+		}, "spec.ports[1]"},
+	}
+	for name, tc := range cases {
+		s := validService()
+		tc.mutate(s)
+		expectErrorOn(t, name, ValidateService(s), tc.field)
+	}
+}
+
+func TestValidateServiceStatusUpdate(t *testing.T) {
+	old := validService()
+	valid := validService()
+	valid.Status.Conditions = []v1.ServiceCondition{{Type: v1.ServiceAvailable, State: v1.ConditionTrue}}
+	if errs := ValidateServiceStatusUpdate(valid, old); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		conditions []v1.ServiceCondition
+		field      string
+	}{
+		"unknown condition": {[]v1.ServiceCondition{{Type: "ready", State: v1.ConditionTrue}}, "status.conditions[0].type"},
+		"duplicate condition": {[]v1.ServiceCondition{
+			{Type: v1.ServiceAvailable, State: v1.ConditionTrue},
+			{Type: v1.ServiceAvailable, State: v1.ConditionFalse},
+		}, "status.conditions[1].type"},
+		"missing condition state": {[]v1.ServiceCondition{{Type: v1.ServiceAvailable}}, "status.conditions[0].state"},
+		"invalid condition state": {[]v1.ServiceCondition{{Type: v1.ServiceAvailable, State: "yes"}}, "status.conditions[0].state"},
+	}
+	for name, tc := range cases {
+		s := validService()
+		s.Status.Conditions = tc.conditions
+		expectErrorOn(t, name, ValidateServiceStatusUpdate(s, old), tc.field)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"reflect"
 
 	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
 	"github.com/opencarry/carry/pkg/util/validation/field"
 )
 
+var supportedStatefulSetConditionTypes = sets.NewString(string(v1.StatefulSetAvailable))
+
 func ValidateStatefulSetName(name string, prefix bool) []string {
 	return NameIsDNSSubdomain(name, prefix)
 }
@@ -80,6 +83,39 @@ func ValidateStatefulSetUpdate(statefulSet, oldStatefulSet *v1.StatefulSet) fiel
 func ValidateStatefulSetStatusUpdate(statefulSet, oldStatefulSet *v1.StatefulSet) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, ValidateObjectMetaUpdate(&statefulSet.ObjectMeta, &oldStatefulSet.ObjectMeta, field.NewPath("metadata"))...)
-	// TODO: Validate status.
+	fldPath := field.NewPath("status")
+	allErrs = append(allErrs, ValidateStatefulSetStatus(&statefulSet.Status, fldPath)...)
+
+	if statefulSet.Status.ObservedGeneration != nil {
+		var oldGeneration int64
+		if oldStatefulSet.Status.ObservedGeneration != nil {
+			oldGeneration = *oldStatefulSet.Status.ObservedGeneration
+		}
+		allErrs = append(allErrs, validateObservedGeneration(*statefulSet.Status.ObservedGeneration, oldGeneration, fldPath.Child("observed_generation"))...)
+	}
+	if statefulSet.Status.CollisionCount != nil && oldStatefulSet.Status.CollisionCount != nil && *statefulSet.Status.CollisionCount < *oldStatefulSet.Status.CollisionCount {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("collision_count"), *statefulSet.Status.CollisionCount, "cannot be decremented"))
+	}
+	return allErrs
+}
+
+// ValidateStatefulSetStatus validates a StatefulSetStatus.
+func ValidateStatefulSetStatus(status *v1.StatefulSetStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateNonnegativeField(status.Replicas, fldPath.Child("replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.ReadyReplicas, fldPath.Child("ready_replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.CurrentReplicas, fldPath.Child("current_replicas"))...)
+	allErrs = append(allErrs, ValidateNonnegativeField(status.UpdatedReplicas, fldPath.Child("updated_replicas"))...)
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(status.ObservedGeneration, fldPath.Child("observed_generation"))...)
+	allErrs = append(allErrs, ValidateOptionalNonnegativeField(status.CollisionCount, fldPath.Child("collision_count"))...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.ReadyReplicas, "ready_replicas", status.Replicas, "replicas", fldPath)...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.CurrentReplicas, "current_replicas", status.Replicas, "replicas", fldPath)...)
+	allErrs = append(allErrs, validateReplicasNotGreaterThan(status.UpdatedReplicas, "updated_replicas", status.Replicas, "replicas", fldPath)...)
+
+	conditions := make([]statusCondition, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, statusCondition{Type: string(c.Type), State: c.State})
+	}
+	allErrs = append(allErrs, validateConditions(conditions, supportedStatefulSetConditionTypes, fldPath.Child("conditions"))...)
 	return allErrs
 }
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/sets"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+var supportedConditionStates = sets.NewString(string(v1.ConditionTrue), string(v1.ConditionFalse), string(v1.ConditionUnknown))
+
+// statusCondition is the part of a kind specific condition that is validated
+// the same way for every kind.
+type statusCondition struct {
+	Type  string
+	State v1.ConditionState
+}
+
+// validateConditions checks that every condition has a known type, appears at
+// most once and has a valid state.
+func validateConditions(conditions []statusCondition, knownTypes sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, c := range conditions {
+		idxPath := fldPath.Index(i)
+		if len(c.Type) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("type"), ""))
+		} else if !knownTypes.Has(c.Type) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), c.Type, knownTypes.List()))
+		} else if seen.Has(c.Type) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("type"), c.Type))
+		} else {
+			seen.Insert(c.Type)
+		}
+
+		if len(c.State) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("state"), ""))
+		} else if !supportedConditionStates.Has(string(c.State)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("state"), c.State, supportedConditionStates.List()))
+		}
+	}
+	return allErrs
+}
+
+// validateObservedGeneration checks that the observed generation is not negative
+// and that it never goes backwards.
+func validateObservedGeneration(newGeneration, oldGeneration int64, fldPath *field.Path) field.ErrorList {
+	allErrs := ValidateNonnegativeField(newGeneration, fldPath)
+	if newGeneration < oldGeneration {
+		allErrs = append(allErrs, field.Invalid(fldPath, newGeneration, "cannot be decremented"))
+	}
+	return allErrs
+}
+
+// validateReplicasNotGreaterThan checks that a replica counter does not exceed
+// the replica counter it is a subset of.
+func validateReplicasNotGreaterThan(value int64, name string, limit int64, limitName string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value > limit {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, "cannot be greater than status."+limitName))
+	}
+	return allErrs
+}
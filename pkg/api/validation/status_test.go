@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func TestValidateReplicaSetStatusUpdate(t *testing.T) {
+	newReplicaSet := func(status v1.ReplicaSetStatus) *v1.ReplicaSet {
+		return &v1.ReplicaSet{
+			ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "default", ResourceVersion: "1"},
+			Status:     status,
+		}
+	}
+	old := newReplicaSet(v1.ReplicaSetStatus{Replicas: 3, ObservedGeneration: 2})
+
+	valid := newReplicaSet(v1.ReplicaSetStatus{
+		Replicas:           3,
+		ReadyReplicas:      2,
+		AvailableReplicas:  1,
+		ObservedGeneration: 3,
+		Conditions:         []v1.ReplicaSetCondition{{Type: v1.ReplicaSetReplicaFailure, State: v1.ConditionFalse}},
+	})
+	if errs := ValidateReplicaSetStatusUpdate(valid, old); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		status v1.ReplicaSetStatus
+		field  string
+	}{
+		"negative replicas":    {v1.ReplicaSetStatus{Replicas: -1, ObservedGeneration: 2}, "status.replicas"},
+		"ready above replicas": {v1.ReplicaSetStatus{Replicas: 1, ReadyReplicas: 2, ObservedGeneration: 2}, "status.ready_replicas"},
+		"generation backwards": {v1.ReplicaSetStatus{Replicas: 3, ObservedGeneration: 1}, "status.observed_generation"},
+		"unknown condition": {v1.ReplicaSetStatus{ObservedGeneration: 2, Conditions: []v1.ReplicaSetCondition{
+			{Type: "unknown", State: v1.ConditionTrue},
+		}}, "status.conditions[0].type"},
+		"duplicate condition": {v1.ReplicaSetStatus{ObservedGeneration: 2, Conditions: []v1.ReplicaSetCondition{
+			{Type: v1.ReplicaSetReplicaFailure, State: v1.ConditionTrue},
+			{Type: v1.ReplicaSetReplicaFailure, State: v1.ConditionFalse},
+		}}, "status.conditions[1].type"},
+		"invalid condition state": {v1.ReplicaSetStatus{ObservedGeneration: 2, Conditions: []v1.ReplicaSetCondition{
+			{Type: v1.ReplicaSetReplicaFailure, State: "yes"},
+		}}, "status.conditions[0].state"},
+	}
+	for name, tc := range cases {
+		expectErrorOn(t, name, ValidateReplicaSetStatusUpdate(newReplicaSet(tc.status), old), tc.field)
+	}
+}
+
+func TestValidatePodStatusUpdate(t *testing.T) {
+	old := &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "web-0", Namespace: "default", ResourceVersion: "1"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init"}},
+			Containers:     []v1.Container{{Name: "web"}, {Name: "sidecar"}},
+		},
+	}
+	pod := old.DeepCopy()
+	pod.Status = v1.PodStatus{
+		Phase:  v1.PodRunning,
+		HostIp: "192.168.1.10",
+		Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, State: v1.ConditionTrue},
+			{Type: v1.PodScheduled, State: v1.ConditionTrue},
+		},
+		ContainerStatuses:     []v1.ContainerStatus{{Name: "web"}, {Name: "sidecar", RestartCount: 1}},
+		InitContainerStatuses: []v1.ContainerStatus{{Name: "init"}},
+	}
+	if errs := ValidatePodStatusUpdate(pod, old); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		mutate func(s *v1.PodStatus)
+		field  string
+	}{
+		"unknown phase":             {func(s *v1.PodStatus) { s.Phase = "sleeping" }, "status.phase"},
+		"invalid host ip":           {func(s *v1.PodStatus) { s.HostIp = "host-12" }, "status.host_ip"},
+		"unknown container":         {func(s *v1.PodStatus) { s.ContainerStatuses[1].Name = "db" }, "status.container_statuses[1].name"},
+		"duplicate container":       {func(s *v1.PodStatus) { s.ContainerStatuses[1].Name = "web" }, "status.container_statuses[1].name"},
+		"init status in containers": {func(s *v1.PodStatus) { s.ContainerStatuses[0].Name = "init" }, "status.container_statuses[0].name"},
+		"unknown installation": {func(s *v1.PodStatus) {
+			s.InstallationContainerStatuses = []v1.ContainerStatus{{Name: "web"}}
+		}, "status.installation_container_statuses[0].name"},
+		"negative restart count": {func(s *v1.PodStatus) { s.ContainerStatuses[0].RestartCount = -1 }, "status.container_statuses[0].restart_count"},
+		"duplicate condition":    {func(s *v1.PodStatus) { s.Conditions[1].Type = v1.PodReady }, "status.conditions[1].type"},
+	}
+	for name, tc := range cases {
+		p := pod.DeepCopy()
+		tc.mutate(&p.Status)
+		expectErrorOn(t, name, ValidatePodStatusUpdate(p, old), tc.field)
+	}
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// ValidateWorkloadSelector validates the pod selector of a workload. The selector
+// is required and must not be empty, otherwise it would select every pod in the namespace.
+func ValidateWorkloadSelector(selector *v1.LabelSelector, kind string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if selector == nil {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+		return allErrs
+	}
+	allErrs = append(allErrs, ValidateLabelSelector(selector, fldPath)...)
+	if len(selector.MatchLabels)+len(selector.MatchExpressions) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, selector, "empty selector is not valid for "+kind+"."))
+	}
+	return allErrs
+}
+
+// ValidatePodTemplateSpecForWorkload validates the pod template of a workload: the
+// template labels must be matched by selector, the pod spec must be valid and its
+// restart policy must be one of restartPolicies.
+func ValidatePodTemplateSpecForWorkload(template *v1.PodTemplateSpec, selector *v1.LabelSelector, restartPolicies []v1.RestartPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if template == nil {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+		return allErrs
+	}
+
+	if selector != nil {
+		s, err := v1.LabelSelectorAsSelector(selector)
+		if err == nil && !s.Empty() && !s.Matches(labels.Set(template.Labels)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metadata", "labels"), template.Labels, "`selector` does not match template `labels`"))
+		}
+	}
+	allErrs = append(allErrs, ValidateLabels(template.Labels, fldPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, ValidateAnnotations(template.Annotations, fldPath.Child("metadata", "annotations"))...)
+	allErrs = append(allErrs, ValidatePodSpecificAnnotations(template.Annotations, &template.Spec, fldPath.Child("metadata", "annotations"))...)
+	allErrs = append(allErrs, ValidatePodSpec(&template.Spec, fldPath.Child("spec"))...)
+
+	// ValidatePodSpec 已经校验了restart_policy的合法性，这里只限制各类workload允许的取值
+	restartPolicy := template.Spec.RestartPolicy
+	if restartPolicy != "" && !containsRestartPolicy(restartPolicies, restartPolicy) {
+		validValues := make([]string, 0, len(restartPolicies))
+		for _, p := range restartPolicies {
+			validValues = append(validValues, string(p))
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("spec", "restart_policy"), restartPolicy, validValues))
+	}
+	return allErrs
+}
+
+// ValidateOptionalNonnegativeField validates that value, if set, is not negative.
+func ValidateOptionalNonnegativeField(value *int64, fldPath *field.Path) field.ErrorList {
+	if value == nil {
+		return field.ErrorList{}
+	}
+	return ValidateNonnegativeField(*value, fldPath)
+}
+
+func containsRestartPolicy(policies []v1.RestartPolicy, policy v1.RestartPolicy) bool {
+	for _, p := range policies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func validPodTemplate(restartPolicy v1.RestartPolicy) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{
+		ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{
+			RestartPolicy: restartPolicy,
+			Containers: []v1.Container{{
+				Name:               "web",
+				Image:              "nginx:1.21",
+				ImagePullPolicy:    v1.PullIfNotPresent,
+				ImageDeploymentDir: "/opt/web",
+			}},
+		},
+	}
+}
+
+func validSelector() *v1.LabelSelector {
+	return &v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+}
+
+func validDeployment() *v1.Deployment {
+	return &v1.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "default", ResourceVersion: "1"},
+		Spec: v1.DeploymentSpec{
+			Selector: validSelector(),
+			Template: validPodTemplate(v1.RestartPolicyAlways),
+			Replicas: int64Ptr(1),
+			Strategy: v1.DeploymentStrategy{Type: v1.InplaceUpdateDeploymentStrategyType},
+		},
+	}
+}
+
+func validJob() *v1.Job {
+	return &v1.Job{
+		ObjectMeta: v1.ObjectMeta{Name: "batch", Namespace: "default", ResourceVersion: "1"},
+		Spec: v1.JobSpec{
+			Selector: validSelector(),
+			Template: validPodTemplate(v1.RestartPolicyNever),
+		},
+	}
+}
+
+func expectErrorOn(t *testing.T, name string, errs field.ErrorList, fieldPath string) {
+	t.Helper()
+	if len(errs) == 0 {
+		t.Errorf("%s: expected error on %s, got none", name, fieldPath)
+		return
+	}
+	for _, err := range errs {
+		if strings.HasPrefix(err.Field, fieldPath) {
+			return
+		}
+	}
+	t.Errorf("%s: expected error on %s, got %v", name, fieldPath, errs)
+}
+
+func TestValidateDeployment(t *testing.T) {
+	if errs := ValidateDeployment(validDeployment()); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cases := map[string]struct {
+		mutate func(d *v1.Deployment)
+		field  string
+	}{
+		"missing selector": {func(d *v1.Deployment) { d.Spec.Selector = nil }, "spec.selector"},
+		"empty selector":   {func(d *v1.Deployment) { d.Spec.Selector = &v1.LabelSelector{} }, "spec.selector"},
+		"selector mismatch": {func(d *v1.Deployment) {
+			d.Spec.Selector = &v1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+		}, "spec.template.metadata.labels"},
+		"negative replicas":    {func(d *v1.Deployment) { d.Spec.Replicas = int64Ptr(-1) }, "spec.replicas"},
+		"unsupported strategy": {func(d *v1.Deployment) { d.Spec.Strategy.Type = "recreate" }, "spec.strategy.type"},
+		"restart policy never": {func(d *v1.Deployment) { d.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyNever }, "spec.template.spec.restart_policy"},
+	}
+	for name, tc := range cases {
+		d := validDeployment()
+		tc.mutate(d)
+		expectErrorOn(t, name, ValidateDeployment(d), tc.field)
+	}
+}
+
+func TestValidateDeploymentUpdateSelectorImmutable(t *testing.T) {
+	old := validDeployment()
+	d := validDeployment()
+	d.Spec.Selector.MatchLabels["tier"] = "frontend"
+	d.Spec.Template.Labels["tier"] = "frontend"
+	expectErrorOn(t, "selector change", ValidateDeploymentUpdate(d, old), "spec.selector")
+
+	d = validDeployment()
+	d.Spec.Replicas = int64Ptr(3)
+	if errs := ValidateDeploymentUpdate(d, old); len(errs) != 0 {
+		t.Errorf("unexpected errors scaling deployment: %v", errs)
+	}
+}
+
+func TestValidateJob(t *testing.T) {
+	if errs := ValidateJob(validJob()); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	onFailure := validJob()
+	onFailure.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyOnFailure
+	if errs := ValidateJob(onFailure); len(errs) != 0 {
+		t.Errorf("unexpected errors for on_failure job: %v", errs)
+	}
+
+	cases := map[string]struct {
+		mutate func(j *v1.Job)
+		field  string
+	}{
+		"restart policy always": {func(j *v1.Job) { j.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyAlways }, "spec.template.spec.restart_policy"},
+		"negative parallelism":  {func(j *v1.Job) { j.Spec.Parallelism = int64Ptr(-1) }, "spec.parallelism"},
+		"negative completions":  {func(j *v1.Job) { j.Spec.Completions = int64Ptr(-1) }, "spec.completions"},
+		"negative backoff":      {func(j *v1.Job) { j.Spec.BackoffLimit = int64Ptr(-1) }, "spec.backoff_limit"},
+		"missing selector":      {func(j *v1.Job) { j.Spec.Selector = nil }, "spec.selector"},
+	}
+	for name, tc := range cases {
+		j := validJob()
+		tc.mutate(j)
+		expectErrorOn(t, name, ValidateJob(j), tc.field)
+	}
+
+	old := validJob()
+	j := validJob()
+	j.Spec.Selector = &v1.LabelSelector{MatchLabels: map[string]string{"app": "web", "x": "y"}}
+	j.Spec.Template.Labels["x"] = "y"
+	expectErrorOn(t, "selector change", ValidateJobUpdate(j, old), "spec.selector")
+}
@@ -0,0 +1,7 @@
+// +k8s:deepcopy-gen=package
+// +groupName=audit.carry.i
+
+// Package v1 contains the audit.carry.i/v1 API types: the audit events
+// recorded for the requests to the API and the policy that decides how much
+// of a request is recorded.
+package v1
@@ -0,0 +1,25 @@
+package v1
+
+var levels = map[Level]int{
+	LevelNone:            0,
+	LevelMetadata:        1,
+	LevelRequest:         2,
+	LevelRequestResponse: 3,
+}
+
+// Less returns true if the level is less than the other level.
+func (l Level) Less(other Level) bool {
+	return levels[l] < levels[other]
+}
+
+// GreaterOrEqual returns true if the level is greater than or equal to the
+// other level.
+func (l Level) GreaterOrEqual(other Level) bool {
+	return levels[l] >= levels[other]
+}
+
+// IsValid reports whether l is one of the audit levels.
+func (l Level) IsValid() bool {
+	_, ok := levels[l]
+	return ok
+}
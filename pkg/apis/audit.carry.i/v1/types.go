@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"encoding/json"
+	"time"
+
+	carryv1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+// Level defines the amount of information logged during auditing
+type Level string
+
+// Valid audit levels
+const (
+	// LevelNone disables auditing
+	LevelNone Level = "none"
+	// LevelMetadata provides the basic level of auditing: who sent which
+	// request about which object, the response code and the latency.
+	LevelMetadata Level = "metadata"
+	// LevelRequest provides Metadata level of auditing, and additionally
+	// logs the request object (does not apply for non-resource requests).
+	LevelRequest Level = "request"
+	// LevelRequestResponse provides Request level of auditing, and additionally
+	// logs the response object (does not apply for non-resource requests).
+	LevelRequestResponse Level = "request_response"
+)
+
+// Event captures all the information that can be included in an API audit log.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type Event struct {
+	carryv1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+
+	// AuditLevel at which event was generated
+	Level Level `json:"level" protobuf:"bytes,2,opt,name=level"`
+	// Unique audit ID, generated for each request.
+	AuditID carryv1.UID `json:"audit_id" protobuf:"bytes,3,opt,name=auditID"`
+	// RequestURI is the request URI as sent by the client to a server.
+	RequestURI string `json:"request_uri" protobuf:"bytes,4,opt,name=requestURI"`
+	// Verb is the verb associated with the request, like "get", "list",
+	// "watch", "create", "update", "patch" or "delete". For non-resource
+	// requests, this is the lower-cased HTTP method.
+	Verb string `json:"verb" protobuf:"bytes,5,opt,name=verb"`
+	// Authenticated user information.
+	User carryv1.UserInfo `json:"user" protobuf:"bytes,6,opt,name=user"`
+	// Source IPs, from where the request originated.
+	SourceIPs []string `json:"source_ips,omitempty" protobuf:"bytes,7,rep,name=sourceIPs"`
+	// UserAgent records the user agent string reported by the client.
+	UserAgent string `json:"user_agent,omitempty" protobuf:"bytes,8,opt,name=userAgent"`
+	// Object reference this request is targeted at. Its kind is the kind of
+	// the resource requested, also for lists, and its name is empty for
+	// requests about a whole collection. Does not apply for non-resource
+	// requests.
+	ObjectRef *carryv1.ObjectReference `json:"object_ref,omitempty" protobuf:"bytes,9,opt,name=objectRef"`
+	// Subresource is the subresource requested, if any, like "status".
+	Subresource string `json:"subresource,omitempty" protobuf:"bytes,10,opt,name=subresource"`
+	// ResponseCode is the HTTP status code of the response.
+	ResponseCode int32 `json:"response_code,omitempty" protobuf:"varint,11,opt,name=responseCode"`
+	// API object from the request, in JSON format. The RequestObject is
+	// recorded as decoded and defaulted, prior to admission or merging;
+	// patches are recorded as sent. Only logged at Request level and higher.
+	RequestObject json.RawMessage `json:"request_object,omitempty" protobuf:"bytes,12,opt,name=requestObject"`
+	// API object returned in the response, in JSON. Only logged at
+	// RequestResponse level.
+	ResponseObject json.RawMessage `json:"response_object,omitempty" protobuf:"bytes,13,opt,name=responseObject"`
+	// Time the request reached the server.
+	RequestReceivedTimestamp time.Time `json:"request_received_timestamp" protobuf:"bytes,14,opt,name=requestReceivedTimestamp"`
+	// LatencyMicroseconds is how long the server took to answer the request.
+	LatencyMicroseconds int64 `json:"latency_microseconds" protobuf:"varint,15,opt,name=latencyMicroseconds"`
+}
+
+// EventList is a list of audit Events.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type EventList struct {
+	carryv1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	carryv1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []Event `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
+
+// Policy defines the configuration of audit logging, and the rules for how
+// different request categories are logged.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type Policy struct {
+	carryv1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+
+	// Rules specify the audit Level a request should be recorded at.
+	// A request may match multiple rules, in which case the FIRST matching
+	// rule is used. Requests that match no rule are not recorded.
+	Rules []PolicyRule `json:"rules" protobuf:"bytes,2,rep,name=rules"`
+}
+
+// PolicyRule maps requests based off metadata to an audit Level.
+// Requests must match the rules of every field (an intersection of rules).
+type PolicyRule struct {
+	// The Level that requests matching this rule are recorded at.
+	Level Level `json:"level" protobuf:"bytes,1,opt,name=level"`
+
+	// The users (by authenticated user name) this rule applies to.
+	// An empty list implies every user.
+	Users []string `json:"users,omitempty" protobuf:"bytes,2,rep,name=users"`
+	// The user groups this rule applies to. A user is considered matching
+	// if it is a member of any of the UserGroups.
+	// An empty list implies every user group.
+	UserGroups []string `json:"user_groups,omitempty" protobuf:"bytes,3,rep,name=userGroups"`
+
+	// The verbs that match this rule.
+	// An empty list implies every verb.
+	Verbs []string `json:"verbs,omitempty" protobuf:"bytes,4,rep,name=verbs"`
+
+	// Kinds this rule matches, like "pod" or "configmap". An empty list
+	// implies every kind, and also non-resource requests.
+	Kinds []string `json:"kinds,omitempty" protobuf:"bytes,5,rep,name=kinds"`
+	// Namespaces that this rule matches.
+	// The empty string "" matches non-namespaced resources.
+	// An empty list implies every namespace.
+	Namespaces []string `json:"namespaces,omitempty" protobuf:"bytes,6,rep,name=namespaces"`
+}
@@ -0,0 +1,79 @@
+package v1
+
+import "encoding/json"
+
+// AdmissionReview 是api server发给admission webhook的请求，webhook在同一个kind的对象里返回响应
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type AdmissionReview struct {
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+
+	// Request describes the attributes for the admission request.
+	Request *AdmissionRequest `json:"request,omitempty" protobuf:"bytes,2,opt,name=request"`
+	// Response describes the attributes for the admission response.
+	Response *AdmissionResponse `json:"response,omitempty" protobuf:"bytes,3,opt,name=response"`
+}
+
+// AdmissionRequest describes the admission.Attributes for the admission request.
+type AdmissionRequest struct {
+	// UID is an identifier for the individual request/response. The webhook
+	// copies it into its response.
+	UID UID `json:"uid" protobuf:"bytes,1,opt,name=uid"`
+	// Kind is the kind of the object being submitted, like "pod".
+	Kind string `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	// Resource is the resource being requested, like "pods".
+	Resource string `json:"resource" protobuf:"bytes,3,opt,name=resource"`
+	// SubResource is the subresource being requested, if any, like "status".
+	SubResource string `json:"sub_resource,omitempty" protobuf:"bytes,4,opt,name=subResource"`
+	// Name is the name of the object as presented in the request. It is empty
+	// on a create whose name is generated.
+	Name string `json:"name,omitempty" protobuf:"bytes,5,opt,name=name"`
+	// Namespace is the namespace associated with the request, if any.
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,6,opt,name=namespace"`
+	// Operation is the operation being performed: create, update or delete.
+	Operation string `json:"operation" protobuf:"bytes,7,opt,name=operation"`
+	// UserInfo is information about the requesting user.
+	UserInfo UserInfo `json:"user_info" protobuf:"bytes,8,opt,name=userInfo"`
+	// Object is the object from the incoming request, empty for deletes.
+	Object json.RawMessage `json:"object,omitempty" protobuf:"bytes,9,opt,name=object"`
+	// OldObject is the existing object, only set for updates and deletes.
+	OldObject json.RawMessage `json:"old_object,omitempty" protobuf:"bytes,10,opt,name=oldObject"`
+}
+
+// AdmissionResponse describes an admission response.
+type AdmissionResponse struct {
+	// UID is the identifier of the request this responds to.
+	UID UID `json:"uid" protobuf:"bytes,1,opt,name=uid"`
+	// Allowed indicates whether or not the admission request was permitted.
+	Allowed bool `json:"allowed" protobuf:"varint,2,opt,name=allowed"`
+	// Result contains extra details into why an admission request was denied.
+	// This field IS NOT consulted in any way if "allowed" is "true".
+	Result *Status `json:"status,omitempty" protobuf:"bytes,3,opt,name=result"`
+	// Patch is the patch body, a JSON patch (RFC 6902) of the object. Only
+	// mutating webhooks may return one.
+	Patch json.RawMessage `json:"patch,omitempty" protobuf:"bytes,4,opt,name=patch"`
+	// PatchType is the type of Patch, required when Patch is set.
+	PatchType *PatchType `json:"patch_type,omitempty" protobuf:"bytes,5,opt,name=patchType"`
+}
+
+// PatchType is the type of patch being used to represent the mutated object
+type PatchType string
+
+const (
+	// PatchTypeJSONPatch 即RFC 6902 JSON patch
+	PatchTypeJSONPatch PatchType = "json_patch"
+)
+
+// UserInfo holds the information about the user needed to implement the
+// user.Info interface.
+type UserInfo struct {
+	// The name that uniquely identifies this user among all active users.
+	Username string `json:"username,omitempty" protobuf:"bytes,1,opt,name=username"`
+	// A unique value that identifies this user across time. If this user is
+	// deleted and another user by the same name is added, they will have
+	// different UIDs.
+	UID string `json:"uid,omitempty" protobuf:"bytes,2,opt,name=uid"`
+	// The names of groups this user is a part of.
+	Groups []string `json:"groups,omitempty" protobuf:"bytes,3,rep,name=groups"`
+	// Any additional information provided by the authenticator.
+	Extra map[string][]string `json:"extra,omitempty" protobuf:"bytes,4,rep,name=extra"`
+}
@@ -1,18 +1,20 @@
 package v1
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type ConfigMap struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
 	// 每个键必须由字母数字和字符'-', '_' or '.'组成
-	Data map[string]string `json:"data,omitempty"`
+	Data map[string]string `json:"data,omitempty" protobuf:"bytes,3,rep,name=data"`
 	// 每个键必须由字母数字和字符'-', '_' or '.'组成
-	BinaryData map[string][]byte `json:"binary_data,omitempty"`
+	BinaryData map[string][]byte `json:"binary_data,omitempty" protobuf:"bytes,4,rep,name=binaryData"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type ConfigMapList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []ConfigMap `json:"items"`
+	Items []ConfigMap `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
@@ -2,36 +2,38 @@ package v1
 
 import "time"
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type DaemonSet struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec DaemonSetSpec `json:"spec,omitempty"`
+	Spec DaemonSetSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status DaemonSetStatus `json:"status,omitempty"`
+	Status DaemonSetStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type DaemonSetList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []DaemonSet `json:"items"`
+	Items []DaemonSet `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type DaemonSetSpec struct {
 	// required
-	Selector *LabelSelector `json:"selector"`
+	Selector *LabelSelector `json:"selector" protobuf:"bytes,1,opt,name=selector"`
 	// required, same as pod
-	Template PodTemplateSpec `json:"template"`
+	Template PodTemplateSpec `json:"template" protobuf:"bytes,2,opt,name=template"`
 	// strategy to use to replace existing pods.
-	Strategy DaemonSetStrategy `json:"strategy,omitempty"`
+	Strategy DaemonSetStrategy `json:"strategy,omitempty" protobuf:"bytes,3,opt,name=strategy"`
 	// optional, defaults to 10
-	RevisionHistoryLimit *int64 `json:"revision_history_limit,omitempty"`
+	RevisionHistoryLimit *int64 `json:"revision_history_limit,omitempty" protobuf:"varint,4,opt,name=revisionHistoryLimit"`
 }
 
 type DaemonSetStrategy struct {
 	// required
-	Type DaemonSetStrategyType `json:"type"`
+	Type DaemonSetStrategyType `json:"type" protobuf:"bytes,1,opt,name=type"`
 }
 
 type DaemonSetStrategyType string
@@ -43,24 +45,24 @@ const (
 
 type DaemonSetStatus struct {
 	// The total number of nodes that should be running the daemon pod (including nodes correctly running the daemon pod).
-	DesiredNumberScheduled int `json:"desired_number_scheduled"`
+	DesiredNumberScheduled int `json:"desired_number_scheduled" protobuf:"varint,1,opt,name=desiredNumberScheduled"`
 	// The number of nodes that are running at least 1 daemon pod and are supposed to run the daemon pod
-	CurrentNumberScheduled int `json:"current_number_scheduled"`
+	CurrentNumberScheduled int `json:"current_number_scheduled" protobuf:"varint,2,opt,name=currentNumberScheduled"`
 	// numberReady is the number of nodes that should be running the daemon pod and have one or more of the daemon pod running with a Ready Condition
-	NumberReady int `json:"number_ready"`
+	NumberReady int `json:"number_ready" protobuf:"varint,3,opt,name=numberReady"`
 
-	Conditions []DaemonSetCondition `json:"conditions,omitempty"`
+	Conditions []DaemonSetCondition `json:"conditions,omitempty" protobuf:"bytes,4,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 type DaemonSetCondition struct {
-	Type  DaemonSetConditionType `json:"type"`
-	State ConditionState         `json:"state"`
+	Type  DaemonSetConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	State ConditionState         `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
-	LastUpdateTime     time.Time `json:"last_update_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
+	LastUpdateTime     time.Time `json:"last_update_time,omitempty" protobuf:"bytes,4,opt,name=lastUpdateTime"`
 
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
 type DaemonSetConditionType string
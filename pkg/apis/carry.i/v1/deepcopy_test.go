@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"flag"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/opencarry/carry/pkg/resource"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	quantityType = reflect.TypeOf(resource.Quantity{})
+)
+
+// 复现失败时用 -seed 指定测试日志中打印的种子
+var seed = flag.Int64("seed", 1, "seed of the random objects of TestDeepCopyRoundTrip")
+
+// fill 用随机值填充v，所有指针、map、slice都非空，保证deepcopy的每个分支都被覆盖
+func fill(r *rand.Rand, v reflect.Value) {
+	switch v.Type() {
+	case timeType:
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(1<<32), 0)))
+		return
+	case quantityType:
+		v.Set(reflect.ValueOf(*resource.NewQuantity(r.Int63n(1<<20), resource.DecimalSI)))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(randString(r))
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Intn(100)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(r.Intn(100)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.Float64())
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(r, v.Elem())
+	case reflect.Slice:
+		n := 1 + r.Intn(3)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			fill(r, v.Index(i))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		for i := 1 + r.Intn(3); i > 0; i-- {
+			key := reflect.New(v.Type().Key()).Elem()
+			fill(r, key)
+			val := reflect.New(v.Type().Elem()).Elem()
+			fill(r, val)
+			v.SetMapIndex(key, val)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			fill(r, v.Field(i))
+		}
+	}
+}
+
+func randString(r *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 1+r.Intn(8))
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// checkNoAlias 检查copied中的指针、map和slice不与original共享内存
+func checkNoAlias(t *testing.T, path string, original, copied reflect.Value) {
+	switch original.Type() {
+	case timeType, quantityType:
+		return
+	}
+	switch original.Kind() {
+	case reflect.Ptr:
+		if original.IsNil() {
+			return
+		}
+		if original.Pointer() == copied.Pointer() {
+			t.Errorf("%s: pointer is shared with the original", path)
+			return
+		}
+		checkNoAlias(t, path, original.Elem(), copied.Elem())
+	case reflect.Slice:
+		if original.Len() == 0 {
+			return
+		}
+		if original.Pointer() == copied.Pointer() {
+			t.Errorf("%s: slice is shared with the original", path)
+			return
+		}
+		for i := 0; i < original.Len(); i++ {
+			checkNoAlias(t, path+"[]", original.Index(i), copied.Index(i))
+		}
+	case reflect.Map:
+		if original.Len() == 0 {
+			return
+		}
+		if original.Pointer() == copied.Pointer() {
+			t.Errorf("%s: map is shared with the original", path)
+			return
+		}
+		for _, key := range original.MapKeys() {
+			checkNoAlias(t, path+"[]", original.MapIndex(key), copied.MapIndex(key))
+		}
+	case reflect.Struct:
+		for i := 0; i < original.NumField(); i++ {
+			if original.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			checkNoAlias(t, path+"."+original.Type().Field(i).Name, original.Field(i), copied.Field(i))
+		}
+	}
+}
+
+func TestDeepCopyRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("seed: %d", *seed)
+	r := rand.New(rand.NewSource(*seed))
+	// 按固定顺序遍历，同一个种子得到同样的对象
+	var gvks []schema.GroupVersionKind
+	for gvk := range scheme.AllKnownTypes() {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+	for _, gvk := range gvks {
+		for i := 0; i < 20; i++ {
+			obj, err := scheme.New(gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fill(r, reflect.ValueOf(obj).Elem())
+
+			copied := obj.DeepCopyObject()
+			if !reflect.DeepEqual(obj, copied) {
+				t.Errorf("%s: copy is not equal to the original\n%#v\n%#v", gvk.Kind, obj, copied)
+				continue
+			}
+			checkNoAlias(t, gvk.Kind, reflect.ValueOf(obj), reflect.ValueOf(copied))
+		}
+	}
+}
+
+func TestDeepCopyNil(t *testing.T) {
+	var pod *Pod
+	if pod.DeepCopy() != nil {
+		t.Errorf("expected nil copy of nil pod")
+	}
+	if pod.DeepCopyObject() != nil {
+		t.Errorf("expected nil object copy of nil pod")
+	}
+	var list ResourceList
+	if list.DeepCopy() != nil {
+		t.Errorf("expected nil copy of nil resource list")
+	}
+}
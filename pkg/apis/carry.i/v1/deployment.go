@@ -4,40 +4,42 @@ import (
 	"time"
 )
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Deployment struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec DeploymentSpec `json:"spec,omitempty"`
+	Spec DeploymentSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status DeploymentStatus `json:"status,omitempty"`
+	Status DeploymentStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type DeploymentList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Deployment `json:"items"`
+	Items []Deployment `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type DeploymentSpec struct {
 	// required
-	Selector *LabelSelector `json:"selector"`
+	Selector *LabelSelector `json:"selector" protobuf:"bytes,1,opt,name=selector"`
 	// required, same as pod
-	Template PodTemplateSpec `json:"template"`
+	Template PodTemplateSpec `json:"template" protobuf:"bytes,2,opt,name=template"`
 	// optional, defaults to 1
-	Replicas *int64 `json:"replicas,omitempty"`
+	Replicas *int64 `json:"replicas,omitempty" protobuf:"varint,3,opt,name=replicas"`
 	// The deployment strategy to use to replace existing pods.
-	Strategy DeploymentStrategy `json:"strategy,omitempty"`
+	Strategy DeploymentStrategy `json:"strategy,omitempty" protobuf:"bytes,4,opt,name=strategy"`
 	// optional, defaults to 10
-	RevisionHistoryLimit *int64 `json:"revision_history_limit,omitempty"`
+	RevisionHistoryLimit *int64 `json:"revision_history_limit,omitempty" protobuf:"varint,5,opt,name=revisionHistoryLimit"`
 	// optional, defaults to 600
-	ProgressDeadlineSeconds *int64 `json:"progress_deadline_seconds,omitempty"`
+	ProgressDeadlineSeconds *int64 `json:"progress_deadline_seconds,omitempty" protobuf:"varint,6,opt,name=progressDeadlineSeconds"`
 }
 
 type DeploymentStrategy struct {
 	// required
-	Type DeploymentStrategyType `json:"type"`
+	Type DeploymentStrategyType `json:"type" protobuf:"bytes,1,opt,name=type"`
 }
 
 type DeploymentStrategyType string
@@ -48,24 +50,24 @@ const (
 )
 
 type DeploymentStatus struct {
-	Replicas        int `json:"replicas,omitempty"`
-	UpdatedReplicas int `json:"updated_replicas,omitempty"`
-	ReadyReplicas   int `json:"ready_replicas,omitempty"`
+	Replicas        int `json:"replicas,omitempty" protobuf:"varint,1,opt,name=replicas"`
+	UpdatedReplicas int `json:"updated_replicas,omitempty" protobuf:"varint,2,opt,name=updatedReplicas"`
+	ReadyReplicas   int `json:"ready_replicas,omitempty" protobuf:"varint,3,opt,name=readyReplicas"`
 
-	Conditions []DeploymentCondition `json:"conditions,omitempty"`
+	Conditions []DeploymentCondition `json:"conditions,omitempty" protobuf:"bytes,4,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 	// The generation observed by the deployment controller.
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	ObservedGeneration int64 `json:"observed_generation,omitempty" protobuf:"varint,5,opt,name=observedGeneration"`
 }
 
 type DeploymentCondition struct {
-	Type  DeploymentConditionType `json:"type"`
-	State ConditionState          `json:"state"`
+	Type  DeploymentConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	State ConditionState          `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
-	LastUpdateTime     time.Time `json:"last_update_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
+	LastUpdateTime     time.Time `json:"last_update_time,omitempty" protobuf:"bytes,4,opt,name=lastUpdateTime"`
 
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
 type DeploymentConditionType string
@@ -1,120 +0,0 @@
-package v1
-
-func (in *Deployment) DeepCopy() *Deployment {
-	if in == nil {
-		return nil
-	}
-	out := new(Deployment)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *Deployment) DeepCopyInto(out *Deployment) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-	return
-}
-
-func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(DeploymentSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
-	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(int64)
-			**out = **in
-		}
-	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(LabelSelector)
-			(*in).DeepCopyInto(*out)
-		}
-	}
-	in.Template.DeepCopyInto(&out.Template)
-	in.Strategy.DeepCopyInto(&out.Strategy)
-	if in.RevisionHistoryLimit != nil {
-		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(int64)
-			**out = **in
-		}
-	}
-	if in.ProgressDeadlineSeconds != nil {
-		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(int64)
-			**out = **in
-		}
-	}
-	return
-}
-
-func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(DeploymentStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DeploymentCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	return
-}
-
-func (in *DeploymentStrategy) DeepCopy() *DeploymentStrategy {
-	if in == nil {
-		return nil
-	}
-	out := new(DeploymentStrategy)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *DeploymentStrategy) DeepCopyInto(out *DeploymentStrategy) {
-	*out = *in
-	return
-}
-
-func (in *DeploymentCondition) DeepCopy() *DeploymentCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(DeploymentCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *DeploymentCondition) DeepCopyInto(out *DeploymentCondition) {
-	*out = *in
-	return
-}
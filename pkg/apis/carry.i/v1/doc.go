@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package
+// +groupName=carry.i
+
+// Package v1 contains the carry.i/v1 API types.
+package v1
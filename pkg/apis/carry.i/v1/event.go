@@ -11,31 +11,33 @@ const (
 	EventTypeWarning string = "warning"
 )
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Event struct {
-	TypeMeta       `json:",inline"`
-	ObjectMeta     `json:"metadata,omitempty"`
-	InvolvedObject ObjectReference `json:"involved_object"`
+	TypeMeta       `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta     `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+	InvolvedObject ObjectReference `json:"involved_object" protobuf:"bytes,3,opt,name=involvedObject"`
 	// Count 事件合并, 相同事件出现的次数
-	Count int64 `json:"count,omitempty"`
+	Count int64 `json:"count,omitempty" protobuf:"varint,4,opt,name=count"`
 	// Type Warning...
-	Type      string      `json:"type,omitempty"`
-	Reason    string      `json:"reason,omitempty"`
-	Message   string      `json:"message,omitempty"`
-	Source    EventSource `json:"source,omitempty"`
-	FirstTime time.Time   `json:"first_time,omitempty"`
-	LastTime  time.Time   `json:"last_time,omitempty"`
+	Type      string      `json:"type,omitempty" protobuf:"bytes,5,opt,name=type"`
+	Reason    string      `json:"reason,omitempty" protobuf:"bytes,6,opt,name=reason"`
+	Message   string      `json:"message,omitempty" protobuf:"bytes,7,opt,name=message"`
+	Source    EventSource `json:"source,omitempty" protobuf:"bytes,8,opt,name=source"`
+	FirstTime time.Time   `json:"first_time,omitempty" protobuf:"bytes,9,opt,name=firstTime"`
+	LastTime  time.Time   `json:"last_time,omitempty" protobuf:"bytes,10,opt,name=lastTime"`
 }
 
 type EventSource struct {
 	// Component from which the event is generated.
-	Component string
+	Component string `protobuf:"bytes,1,opt,name=component"`
 	// Node name on which the event is generated.
-	Host string
+	Host string `protobuf:"bytes,2,opt,name=host"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type EventList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Event `json:"items"`
+	Items []Event `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
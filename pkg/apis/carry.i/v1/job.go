@@ -2,50 +2,52 @@ package v1
 
 import "time"
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Job struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec JobSpec `json:"spec,omitempty"`
+	Spec JobSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status JobStatus `json:"status,omitempty"`
+	Status JobStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type JobList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Job `json:"items"`
+	Items []Job `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type JobSpec struct {
 	// Specifies the number of retries before marking this job failed. Defaults to 6
-	BackoffLimit *int64 `json:"backoff_limit,omitempty"`
+	BackoffLimit *int64 `json:"backoff_limit,omitempty" protobuf:"varint,1,opt,name=backoffLimit"`
 
-	Completions *int64 `json:"completions,omitempty"`
+	Completions *int64 `json:"completions,omitempty" protobuf:"varint,2,opt,name=completions"`
 
-	Parallelism *int64 `json:"parallelism,omitempty"`
+	Parallelism *int64 `json:"parallelism,omitempty" protobuf:"varint,3,opt,name=parallelism"`
 	// Specifies the duration in seconds relative to the startTime that the job may be continuously active
 	// before the system tries to terminate it; value must be positive integer.
-	ActiveDeadlineSeconds *int64 `json:"active_deadline_seconds,omitempty"`
+	ActiveDeadlineSeconds *int64 `json:"active_deadline_seconds,omitempty" protobuf:"varint,4,opt,name=activeDeadlineSeconds"`
 
-	Selector *LabelSelector `json:"selector,omitempty"`
+	Selector *LabelSelector `json:"selector,omitempty" protobuf:"bytes,5,opt,name=selector"`
 
-	Template PodTemplateSpec `json:"template"`
+	Template PodTemplateSpec `json:"template" protobuf:"bytes,6,opt,name=template"`
 }
 
 type JobStatus struct {
-	CompletionTime time.Time `json:"completion_time,omitempty"`
+	CompletionTime time.Time `json:"completion_time,omitempty" protobuf:"bytes,1,opt,name=completionTime"`
 
-	StartTime time.Time `json:"start_time,omitempty"`
+	StartTime time.Time `json:"start_time,omitempty" protobuf:"bytes,2,opt,name=startTime"`
 	// The number of pods which reached phase Succeeded.
-	Succeeded int `json:"succeeded,omitempty"`
+	Succeeded int `json:"succeeded,omitempty" protobuf:"varint,3,opt,name=succeeded"`
 	// The number of pods which reached phase Failed.
-	Failed int `json:"failed,omitempty"`
+	Failed int `json:"failed,omitempty" protobuf:"varint,4,opt,name=failed"`
 	// The number of pending and running pods.
-	Active int `json:"active,omitempty"`
+	Active int `json:"active,omitempty" protobuf:"varint,5,opt,name=active"`
 
-	Conditions []JobCondition `json:"conditions,omitempty"`
+	Conditions []JobCondition `json:"conditions,omitempty" protobuf:"bytes,6,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 type JobConditionType string
@@ -61,15 +63,15 @@ const (
 )
 
 type JobCondition struct {
-	Type JobConditionType `json:"type"`
+	Type JobConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
 
-	LastProbeTime time.Time `json:"last_probe_time,omitempty"`
+	LastProbeTime time.Time `json:"last_probe_time,omitempty" protobuf:"bytes,2,opt,name=lastProbeTime"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
 
-	State ConditionState `json:"state"`
+	State ConditionState `json:"state" protobuf:"bytes,4,opt,name=state"`
 
-	Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
 
-	Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
@@ -0,0 +1,40 @@
+package v1
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type LimitRange struct {
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+
+	Spec LimitRangeSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type LimitRangeList struct {
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []LimitRange `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
+
+type LimitRangeSpec struct {
+	Limits []LimitRangeItem `json:"limits" protobuf:"bytes,1,rep,name=limits"`
+}
+
+type LimitType string
+
+const (
+	// LimitTypeContainer 限制namespace内每个容器的资源
+	LimitTypeContainer LimitType = "container"
+)
+
+type LimitRangeItem struct {
+	Type LimitType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// 容器limits和requests的上限
+	Max ResourceList `json:"max,omitempty" protobuf:"bytes,2,rep,name=max"`
+	// 容器limits和requests的下限
+	Min ResourceList `json:"min,omitempty" protobuf:"bytes,3,rep,name=min"`
+	// 容器未设置limits时使用的默认值
+	Default ResourceList `json:"default,omitempty" protobuf:"bytes,4,rep,name=default"`
+	// 容器未设置requests时使用的默认值，未指定时使用limits
+	DefaultRequest ResourceList `json:"default_request,omitempty" protobuf:"bytes,5,rep,name=defaultRequest"`
+}
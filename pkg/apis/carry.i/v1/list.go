@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+// IsListType returns true if the provided Object has a slice called Items.
+func IsListType(obj runtime.Object) bool {
+	_, err := getItemsPtr(obj)
+	return err == nil
+}
+
+// ExtractList returns obj's Items element as an array of runtime.Objects.
+// Returns an error if obj is not a List type (does not have an Items slice).
+func ExtractList(obj runtime.Object) ([]runtime.Object, error) {
+	itemsPtr, err := getItemsPtr(obj)
+	if err != nil {
+		return nil, err
+	}
+	items := reflect.ValueOf(itemsPtr).Elem()
+	list := make([]runtime.Object, items.Len())
+	for i := range list {
+		raw := items.Index(i)
+		item, ok := raw.Addr().Interface().(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("%v: item[%v]: Expected object, got %#v(%s)", obj, i, raw.Interface(), raw.Kind())
+		}
+		list[i] = item
+	}
+	return list, nil
+}
+
+// SetList sets the given list object's Items member to have the elements given in
+// objects. Returns an error if list is not a List type (does not have an Items slice),
+// or if any of the objects are not of the right type.
+func SetList(list runtime.Object, objects []runtime.Object) error {
+	itemsPtr, err := getItemsPtr(list)
+	if err != nil {
+		return err
+	}
+	items := reflect.ValueOf(itemsPtr).Elem()
+	slice := reflect.MakeSlice(items.Type(), len(objects), len(objects))
+	for i := range objects {
+		dest := slice.Index(i)
+		src := reflect.ValueOf(objects[i])
+		if src.Kind() == reflect.Ptr {
+			src = src.Elem()
+		}
+		if src.Type() != dest.Type() {
+			return fmt.Errorf("item[%d]: can't assign type %v to %v", i, src.Type(), dest.Type())
+		}
+		dest.Set(src)
+	}
+	items.Set(slice)
+	return nil
+}
+
+// getItemsPtr returns a pointer to the list object's Items member.
+func getItemsPtr(list runtime.Object) (interface{}, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, fmt.Errorf("expected pointer, but got %v type", v.Type())
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, but got %v type", v.Type())
+	}
+	items := v.FieldByName("Items")
+	if !items.IsValid() || items.Kind() != reflect.Slice {
+		return nil, errExpectSliceItems
+	}
+	return items.Addr().Interface(), nil
+}
+
+var errExpectSliceItems = fmt.Errorf("object does not have an Items slice")
@@ -22,6 +22,8 @@ type Object interface {
 	SetUID(uid UID)
 	GetResourceVersion() string
 	SetResourceVersion(version string)
+	GetGeneration() int64
+	SetGeneration(generation int64)
 	GetCreationTime() time.Time
 	SetCreationTime(time time.Time)
 	GetDeletionTime() time.Time
@@ -34,6 +36,8 @@ type Object interface {
 	SetAnnotations(annotations map[string]string)
 	GetOwnerReferences() []OwnerReference
 	SetOwnerReferences([]OwnerReference)
+	GetManagedFields() []ManagedFieldsEntry
+	SetManagedFields(managedFields []ManagedFieldsEntry)
 }
 
 type ListMetaAccessor interface {
@@ -65,9 +69,9 @@ type UID string
 
 type TypeMeta struct {
 	// 小写模式
-	Kind string `json:"kind,omitempty"`
+	Kind string `json:"kind,omitempty" protobuf:"bytes,1,opt,name=kind"`
 	//
-	APIVersion string `json:"api_version,omitempty"`
+	APIVersion string `json:"api_version,omitempty" protobuf:"bytes,2,opt,name=apiVersion"`
 }
 
 func (obj *TypeMeta) GetObjectKind() schema.ObjectKind { return obj }
@@ -84,11 +88,11 @@ func (obj *TypeMeta) GroupVersionKind() schema.GroupVersionKind {
 
 type ListMeta struct {
 	// etcd ModRevision
-	ResourceVersion string `json:"resource_version,omitempty"`
+	ResourceVersion string `json:"resource_version,omitempty" protobuf:"bytes,1,opt,name=resourceVersion"`
 	// 分页时会赋值
-	Continue string `json:"continue,omitempty"`
+	Continue string `json:"continue,omitempty" protobuf:"bytes,2,opt,name=continue"`
 	// 剩余条数
-	RemainingItemCount *int64 `json:"remaining_item_count,omitempty"`
+	RemainingItemCount *int64 `json:"remaining_item_count,omitempty" protobuf:"varint,3,opt,name=remainingItemCount"`
 }
 
 func (l *ListMeta) GetListMeta() ListInterface { return l }
@@ -104,53 +108,87 @@ type ObjectMeta struct {
 	// 资源名称，在命令空间下唯一
 	// 由域名DNS_LABEL组成，最长128字节
 	// 不允许更新
-	Name string `json:"name,omitempty"`
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
 	// GenerateName is an optional prefix, used by the server, to generate a unique
 	// name ONLY IF the Name field has not been provided.
 	// Applied only if Name is not specified.
-	GenerateName string `json:"generate_name,omitempty"`
+	GenerateName string `json:"generate_name,omitempty" protobuf:"bytes,2,opt,name=generateName"`
 	// 命名空间，默认default
 	// 由域名DNS_LABEL组成，不允许更新
-	Namespace string `json:"namespace,omitempty"`
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
 
-	Labels map[string]string `json:"labels,omitempty"`
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,4,rep,name=labels"`
 	// 非结构化描述类型信息
 	// carry.i/ 开头是系统保留前缀
-	Annotations map[string]string `json:"annotations,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,5,rep,name=annotations"`
 	// 依赖的资源列表
 	// 例如pod，如果这个列表里资源全部被删除了，那么当前这个Pod也会被回收
-	OwnerReferences []OwnerReference `json:"owner_references,omitempty"`
+	OwnerReferences []OwnerReference `json:"owner_references,omitempty" protobuf:"bytes,6,rep,name=ownerReferences"`
 
 	// 资源创建时间，格式：RFC3339，其它地方时间格式同样
 	// 由服务器端设置，不允许更新
-	CreationTime time.Time `json:"creation_time,omitempty"`
+	CreationTime time.Time `json:"creation_time,omitempty" protobuf:"bytes,7,opt,name=creationTime"`
 	// 删除时间，到此时间，资源将被从系统中删除
 	// 当资源被请求优雅删除时，系统会设置该时间，只要该字段被设置，系统将启动该资源的删除流程
 	// 举例子：某Pod资源被优雅删除，该值设置为30s后的时间点，carry感知到之后开始执行如下流程：
 	// 发送TERM信号（若有则执行termination_command）--> （若时间到deletion_time还未终止）发送KILL信号（等2s）  --> 执行uninstallation_containers --> （若卸载成功）carry请求server删除Pod
 	// 整个过程status字段相应字段要有对应设置
 	// 不允许更新
-	DeletionTime time.Time `json:"deletion_time,omitempty"`
+	DeletionTime time.Time `json:"deletion_time,omitempty" protobuf:"bytes,8,opt,name=deletionTime"`
 	// 留给优雅终止的时间秒数，超过这个时间资源将被从系统中删除
 	// 只有deletion_time字段有值时，此字段才能被设置，时间要比deletion_time小
-	DeletionGracePeriodSeconds *int64 `json:"deletion_grace_period_seconds,omitempty"`
+	DeletionGracePeriodSeconds *int64 `json:"deletion_grace_period_seconds,omitempty" protobuf:"varint,9,opt,name=deletionGracePeriodSeconds"`
 
 	// etcd ModRevision
 	// 系统内部标识资源的版本，不透明的字符串，client端不要对此字段作任何假设，只需在需要的地方原样传回server端
 	// 可用于判断资源是否更新
 	// 可用于控制并发更新资源时导致的冲突
 	// 可用于watch资源时用的位置游标
-	ResourceVersion string `json:"resource_version,omitempty"`
+	ResourceVersion string `json:"resource_version,omitempty" protobuf:"bytes,10,opt,name=resourceVersion"`
 
 	// 资源的唯一ID，格式UUID
 	// 为了区别同样name的资源，比如有个name=foo的Pod被删除后，又创建一个同名的Pod
 	// 不允许更新
-	UID UID `json:"uid,omitempty"`
+	UID UID `json:"uid,omitempty" protobuf:"bytes,11,opt,name=uid"`
 
 	// A sequence number representing a specific generation of the desired state.
 	// 由服务器端设置，不允许更新
 	// 可选
-	Generation int64 `json:"generation,omitempty"`
+	Generation int64 `json:"generation,omitempty" protobuf:"varint,12,opt,name=generation"`
+
+	// 记录各个写入方（field manager）拥有的字段，由服务器端维护
+	// server-side apply据此检测不同写入方之间的冲突
+	ManagedFields []ManagedFieldsEntry `json:"managed_fields,omitempty" protobuf:"bytes,13,rep,name=managedFields"`
+}
+
+// ManagedFieldsOperationType is the type of operation which lead to a
+// ManagedFieldsEntry being created.
+type ManagedFieldsOperationType string
+
+const (
+	// ManagedFieldsOperationApply 通过server-side apply写入的字段
+	ManagedFieldsOperationApply ManagedFieldsOperationType = "apply"
+	// ManagedFieldsOperationUpdate 通过create、update或patch写入的字段
+	ManagedFieldsOperationUpdate ManagedFieldsOperationType = "update"
+)
+
+// ManagedFieldsEntry is the set of fields a manager owns through one kind of
+// operation.
+type ManagedFieldsEntry struct {
+	// Manager is the name of the workflow managing these fields, like "ci" or
+	// "replicaset-controller".
+	Manager string `json:"manager,omitempty" protobuf:"bytes,1,opt,name=manager"`
+	// Operation is the type of operation which lead to this entry.
+	Operation ManagedFieldsOperationType `json:"operation,omitempty" protobuf:"bytes,2,opt,name=operation"`
+	// APIVersion is the version of the object the fields belong to.
+	APIVersion string `json:"api_version,omitempty" protobuf:"bytes,3,opt,name=apiVersion"`
+	// Time is when these fields were last set by the manager.
+	Time time.Time `json:"time,omitempty" protobuf:"bytes,4,opt,name=time"`
+	// Fields are the owned field paths in field.Path notation. Elements of
+	// lists merged by key and entries of maps are selected by their key, like
+	// "spec.template.spec.containers[web].image" or "metadata.labels[app]";
+	// a path ending in such a key stands for the element itself.
+	Fields []string `json:"fields,omitempty" protobuf:"bytes,5,rep,name=fields"`
 }
 
 func (meta *ObjectMeta) GetObjectMeta() Object { return meta }
@@ -165,6 +203,8 @@ func (meta *ObjectMeta) GetUID() UID                                  { return m
 func (meta *ObjectMeta) SetUID(uid UID)                               { meta.UID = uid }
 func (meta *ObjectMeta) GetResourceVersion() string                   { return meta.ResourceVersion }
 func (meta *ObjectMeta) SetResourceVersion(version string)            { meta.ResourceVersion = version }
+func (meta *ObjectMeta) GetGeneration() int64                         { return meta.Generation }
+func (meta *ObjectMeta) SetGeneration(generation int64)               { meta.Generation = generation }
 func (meta *ObjectMeta) GetCreationTime() time.Time                   { return meta.CreationTime }
 func (meta *ObjectMeta) SetCreationTime(creationTime time.Time)       { meta.CreationTime = creationTime }
 func (meta *ObjectMeta) GetDeletionTime() time.Time                   { return meta.DeletionTime }
@@ -180,6 +220,11 @@ func (meta *ObjectMeta) SetDeletionGracePeriodSeconds(deletionGracePeriodSeconds
 	meta.DeletionGracePeriodSeconds = deletionGracePeriodSeconds
 }
 
+func (meta *ObjectMeta) GetManagedFields() []ManagedFieldsEntry { return meta.ManagedFields }
+func (meta *ObjectMeta) SetManagedFields(managedFields []ManagedFieldsEntry) {
+	meta.ManagedFields = managedFields
+}
+
 func (meta *ObjectMeta) GetOwnerReferences() []OwnerReference {
 	ret := make([]OwnerReference, len(meta.OwnerReferences))
 	for i := 0; i < len(meta.OwnerReferences); i++ {
@@ -211,12 +256,12 @@ func (meta *ObjectMeta) SetOwnerReferences(references []OwnerReference) {
 }
 
 type OwnerReference struct {
-	APIVersion string `json:"api_version"`
-	Kind       string `json:"kind"`
-	Name       string `json:"name"`
-	UID        UID    `json:"uid"`
+	APIVersion string `json:"api_version" protobuf:"bytes,1,opt,name=apiVersion"`
+	Kind       string `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	Name       string `json:"name" protobuf:"bytes,3,opt,name=name"`
+	UID        UID    `json:"uid" protobuf:"bytes,4,opt,name=uid"`
 	// 控制器类资源只能有一个
-	Controller *bool `json:"controller,omitempty"`
+	Controller *bool `json:"controller,omitempty" protobuf:"varint,5,opt,name=controller"`
 }
 
 var errNotList = fmt.Errorf("object does not implement the List interfaces")
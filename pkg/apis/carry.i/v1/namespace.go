@@ -1,24 +1,26 @@
 package v1
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Namespace struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
-	Spec       NamespaceSpec   `json:"spec,omitempty"`
-	Status     NamespaceStatus `json:"status,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+	Spec       NamespaceSpec   `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
+	Status     NamespaceStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type NamespaceList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Namespace `json:"items"`
+	Items []Namespace `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type NamespaceSpec struct {
 }
 
 type NamespaceStatus struct {
-	Phase NamespacePhase `json:"phase,omitempty"`
+	Phase NamespacePhase `json:"phase,omitempty" protobuf:"bytes,1,opt,name=phase"`
 }
 
 type NamespacePhase string
@@ -27,3 +29,8 @@ const (
 	NamespaceActive      NamespacePhase = "active"
 	NamespaceTerminating NamespacePhase = "terminating"
 )
+
+const (
+	// NamespaceAll 表示所有namespace，用于list和watch
+	NamespaceAll string = ""
+)
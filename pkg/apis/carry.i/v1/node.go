@@ -2,41 +2,43 @@ package v1
 
 import "time"
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Node struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec NodeSpec `json:"spec,omitempty"`
+	Spec NodeSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status NodeStatus `json:"status,omitempty"`
+	Status NodeStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type NodeList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Node `json:"items"`
+	Items []Node `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type NodeSpec struct {
-	Unschedulable bool `json:"unschedulable,omitempty"`
+	Unschedulable bool `json:"unschedulable,omitempty" protobuf:"varint,1,opt,name=unschedulable"`
 	// 允许使用的资源容量
-	Capacity ResourceList `json:"capacity,omitempty"`
+	Capacity ResourceList `json:"capacity,omitempty" protobuf:"bytes,2,rep,name=capacity"`
 }
 
 type NodeStatus struct {
-	Capacity   ResourceList     `json:"capacity,omitempty"`
-	Phase      NodePhase        `json:"phase,omitempty"`
-	Conditions []NodeCondition  `json:"conditions,omitempty"`
-	Addresses  []NodeAddress    `json:"addresses,omitempty"`
-	NodeInfo   NodeSystemInfo   `json:"node_info,omitempty"`
-	Images     []ContainerImage `json:"images,omitempty"`
+	Capacity   ResourceList     `json:"capacity,omitempty" protobuf:"bytes,1,rep,name=capacity"`
+	Phase      NodePhase        `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase"`
+	Conditions []NodeCondition  `json:"conditions,omitempty" protobuf:"bytes,3,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
+	Addresses  []NodeAddress    `json:"addresses,omitempty" protobuf:"bytes,4,rep,name=addresses"`
+	NodeInfo   NodeSystemInfo   `json:"node_info,omitempty" protobuf:"bytes,5,opt,name=nodeInfo"`
+	Images     []ContainerImage `json:"images,omitempty" protobuf:"bytes,6,rep,name=images"`
 }
 
 type ContainerImage struct {
-	Names []string `json:"names"`
+	Names []string `json:"names" protobuf:"bytes,1,rep,name=names"`
 	// The size of the image in bytes.
-	SizeBytes int64 `json:"size_bytes,omitempty"`
+	SizeBytes int64 `json:"size_bytes,omitempty" protobuf:"varint,2,opt,name=sizeBytes"`
 }
 
 type NodePhase string
@@ -48,8 +50,8 @@ const (
 )
 
 type NodeAddress struct {
-	Type    NodeAddressType `json:"type"`
-	Address string          `json:"address"`
+	Type    NodeAddressType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	Address string          `json:"address" protobuf:"bytes,2,opt,name=address"`
 }
 type NodeAddressType string
 
@@ -59,14 +61,14 @@ const (
 )
 
 type NodeSystemInfo struct {
-	Architecture    string `json:"architecture"`
-	OperatingSystem string `json:"operating_system"`
-	KernelVersion   string `json:"kernel_version"`
-	CarryVersion    string `json:"carry_version"`
-	OSImage         string `json:"os_image"`
-	Cpu             string `json:"cpu"`
-	Memory          int64  `json:"memory"`
-	Disk            int64  `json:"disk"`
+	Architecture    string `json:"architecture" protobuf:"bytes,1,opt,name=architecture"`
+	OperatingSystem string `json:"operating_system" protobuf:"bytes,2,opt,name=operatingSystem"`
+	KernelVersion   string `json:"kernel_version" protobuf:"bytes,3,opt,name=kernelVersion"`
+	CarryVersion    string `json:"carry_version" protobuf:"bytes,4,opt,name=carryVersion"`
+	OSImage         string `json:"os_image" protobuf:"bytes,5,opt,name=osImage"`
+	Cpu             string `json:"cpu" protobuf:"bytes,6,opt,name=cpu"`
+	Memory          int64  `json:"memory" protobuf:"varint,7,opt,name=memory"`
+	Disk            int64  `json:"disk" protobuf:"varint,8,opt,name=disk"`
 }
 
 type NodeConditionType string
@@ -79,15 +81,15 @@ const (
 )
 
 type NodeCondition struct {
-	Type NodeConditionType `json:"type"`
+	Type NodeConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
 
-	State ConditionState `json:"state"`
+	State ConditionState `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastProbeTime time.Time `json:"last_probe_time,omitempty"`
+	LastProbeTime time.Time `json:"last_probe_time,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
 
-	Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
 
-	Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
@@ -0,0 +1,60 @@
+package v1
+
+// ListOptions is the query of list and watch requests.
+type ListOptions struct {
+	// A selector to restrict the list of returned objects by their labels.
+	// Defaults to everything.
+	LabelSelector string `json:"label_selector,omitempty"`
+	// A selector to restrict the list of returned objects by their fields.
+	// Defaults to everything.
+	FieldSelector string `json:"field_selector,omitempty"`
+
+	// Watch for changes to the described resources and return them as a
+	// stream of add, update, and remove notifications.
+	Watch bool `json:"watch,omitempty"`
+	// allow_watch_bookmarks requests watch events with type "bookmark".
+	AllowWatchBookmarks bool `json:"allow_watch_bookmarks,omitempty"`
+
+	// For a watch, the resource version to start watching from; the changes
+	// after it are streamed. For a list, the resource version the returned
+	// list must be at least as recent as.
+	ResourceVersion string `json:"resource_version,omitempty"`
+	// Timeout for the watch call, regardless of any activity or inactivity.
+	TimeoutSeconds *int64 `json:"timeout_seconds,omitempty"`
+
+	// limit is a maximum number of responses to return for a list call. If
+	// more items exist, the server will set the continue field on the list
+	// metadata to a value that can be used with the same initial query to
+	// retrieve the next set of results.
+	Limit int64 `json:"limit,omitempty"`
+	// The continue option should be set when retrieving more results from
+	// the server, to the continue of the metadata of the previous list.
+	Continue string `json:"continue,omitempty"`
+}
+
+// CreateOptions is the query of create requests.
+type CreateOptions struct {
+	// field_manager is a name associated with the actor or entity that is
+	// making these changes, recorded in the managed_fields of the object.
+	// Defaults to the program name of the user agent.
+	FieldManager string `json:"field_manager,omitempty"`
+}
+
+// UpdateOptions is the query of update requests.
+type UpdateOptions struct {
+	// field_manager is a name associated with the actor or entity that is
+	// making these changes, recorded in the managed_fields of the object.
+	// Defaults to the program name of the user agent.
+	FieldManager string `json:"field_manager,omitempty"`
+}
+
+// PatchOptions is the query of patch requests.
+type PatchOptions struct {
+	// field_manager is a name associated with the actor or entity that is
+	// making these changes. It is required for apply patches.
+	FieldManager string `json:"field_manager,omitempty"`
+	// Force is going to "force" apply requests. It means user will
+	// re-acquire conflicting fields owned by other people. It must be unset
+	// for other patch types.
+	Force bool `json:"force,omitempty"`
+}
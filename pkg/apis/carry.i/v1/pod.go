@@ -8,26 +8,28 @@ const (
 	DefaultDeploymentUniqueLabelKey string = "pod-template-hash"
 )
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Pod struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec PodSpec `json:"spec,omitempty"`
+	Spec PodSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status PodStatus `json:"status,omitempty"`
+	Status PodStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type PodList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Pod `json:"items"`
+	Items []Pod `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type PodTemplateSpec struct {
-	ObjectMeta `json:"metadata,omitempty"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=objectMeta"`
 
-	Spec PodSpec `json:"spec,omitempty"`
+	Spec PodSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
 }
 
 type PodSpec struct {
@@ -37,55 +39,55 @@ type PodSpec struct {
 	// 安装容器需要自己负责判断是安装或升级的场景
 	// 单纯Pod重启过程不会执行installation_containers
 	// !!!不建议使用installation_containers，能不用就不用，因为安装过程脱离了carry的控制，不确定性的错误增加
-	InstallationContainers []Container `json:"installation_containers,omitempty"`
+	InstallationContainers []Container `json:"installation_containers,omitempty" protobuf:"bytes,1,rep,name=installationContainers" patchStrategy:"merge" patchMergeKey:"name"`
 
 	// 卸载容器，对应installation_containers，负责卸载程序
 	// 可以定义多个容器，按顺序执行，程序结束返回0视为成功
 	// 删除Pod时，主容器全部停止后，触发卸载容器执行
-	UninstallationContainers []Container `json:"uninstallation_containers,omitempty"`
+	UninstallationContainers []Container `json:"uninstallation_containers,omitempty" protobuf:"bytes,2,rep,name=uninstallationContainers" patchStrategy:"merge" patchMergeKey:"name"`
 
 	// 初始化容器，安装容器执行完毕后执行
 	// 多个按顺序执行，只要有一个执行失败，则视为Pod失败，重启策略取决spec.restart_policy
 	// 启动/重启Pod时执行
-	InitContainers []Container `json:"init_containers,omitempty"`
+	InitContainers []Container `json:"init_containers,omitempty" protobuf:"bytes,3,rep,name=initContainers" patchStrategy:"merge" patchMergeKey:"name"`
 
 	// 主容器列表，同时启动，多个容器不保证启动顺序有规律
 	// 【必填】至少要有一个容器
-	Containers []Container `json:"containers"`
+	Containers []Container `json:"containers" protobuf:"bytes,4,rep,name=containers" patchStrategy:"merge" patchMergeKey:"name"`
 
-	Volumes []Volume `json:"volumes,omitempty"`
+	Volumes []Volume `json:"volumes,omitempty" protobuf:"bytes,5,rep,name=volumes" patchStrategy:"merge" patchMergeKey:"name"`
 
 	// pod被调度到此Node，如果此值为空，scheduler负责填充
-	NodeName string `json:"node_name,omitempty"`
+	NodeName string `json:"node_name,omitempty" protobuf:"bytes,6,opt,name=nodeName"`
 
-	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	NodeSelector map[string]string `json:"node_selector,omitempty" protobuf:"bytes,7,rep,name=nodeSelector"`
 
 	// 一组亲和性调度规则
-	Affinity *Affinity `json:"affinity,omitempty"`
+	Affinity *Affinity `json:"affinity,omitempty" protobuf:"bytes,8,opt,name=affinity"`
 
 	// Defaults to always
-	RestartPolicy RestartPolicy `json:"restart_policy,omitempty"`
+	RestartPolicy RestartPolicy `json:"restart_policy,omitempty" protobuf:"bytes,9,opt,name=restartPolicy"`
 
-	SecurityContext *PodSecurityContext `json:"security_context,omitempty"`
+	SecurityContext *PodSecurityContext `json:"security_context,omitempty" protobuf:"bytes,10,opt,name=securityContext"`
 
 	// 优雅删除时间周期，若为0，则立即删除
 	// 默认为30
-	TerminationGracePeriodSeconds *int64 `json:"termination_grace_period_seconds,omitempty"`
+	TerminationGracePeriodSeconds *int64 `json:"termination_grace_period_seconds,omitempty" protobuf:"varint,11,opt,name=terminationGracePeriodSeconds"`
 
 	// Pod存活时间，时间到如果还未结束则carry会主动终止
 	// 可用于Job类型的Pod
-	ActiveDeadlineSeconds *int64 `json:"active_deadline_seconds,omitempty"`
+	ActiveDeadlineSeconds *int64 `json:"active_deadline_seconds,omitempty" protobuf:"varint,12,opt,name=activeDeadlineSeconds"`
 
 	// 如果想停止Pod，将此值置为true，默认为false
 	// carry 感知到此值为true后，将Pod中所有容器停止
-	Suspended *bool `json:"suspended,omitempty"`
+	Suspended *bool `json:"suspended,omitempty" protobuf:"varint,13,opt,name=suspended"`
 
 	// 可选，默认 default-scheduler
-	SchedulerName string `json:"scheduler_name,omitempty"`
+	SchedulerName string `json:"scheduler_name,omitempty" protobuf:"bytes,14,opt,name=schedulerName"`
 
-	Hostname string `json:"hostname,omitempty"`
+	Hostname string `json:"hostname,omitempty" protobuf:"bytes,15,opt,name=hostname"`
 
-	Subdomain string `json:"subdomain,omitempty"`
+	Subdomain string `json:"subdomain,omitempty" protobuf:"bytes,16,opt,name=subdomain"`
 }
 
 type RestartPolicy string
@@ -97,38 +99,38 @@ const (
 )
 
 type PodSecurityContext struct {
-	RunAsUser    string `json:"run_as_user,omitempty"`
-	RunAsGroup   string `json:"run_as_group,omitempty"`
-	RunAsNonRoot *bool  `json:"run_as_non_root,omitempty"`
+	RunAsUser    string `json:"run_as_user,omitempty" protobuf:"bytes,1,opt,name=runAsUser"`
+	RunAsGroup   string `json:"run_as_group,omitempty" protobuf:"bytes,2,opt,name=runAsGroup"`
+	RunAsNonRoot *bool  `json:"run_as_non_root,omitempty" protobuf:"varint,3,opt,name=runAsNonRoot"`
 }
 
 type Container struct {
 	// DNS_LABEL.
-	Name string `json:"name"`
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
 	// mountain image name
-	Image string `json:"image"`
+	Image string `json:"image" protobuf:"bytes,2,opt,name=image"`
 	// Defaults to always if :latest tag is specified, or if_not_present otherwise
-	ImagePullPolicy PullPolicy `json:"image_pull_policy,omitempty"`
+	ImagePullPolicy PullPolicy `json:"image_pull_policy,omitempty" protobuf:"bytes,3,opt,name=imagePullPolicy"`
 	// 部署目录，绝对路径
-	ImageDeploymentDir string `json:"image_deployment_dir"`
+	ImageDeploymentDir string `json:"image_deployment_dir" protobuf:"bytes,4,opt,name=imageDeploymentDir"`
 
-	Env []EnvVar `json:"env,omitempty"`
+	Env []EnvVar `json:"env,omitempty" protobuf:"bytes,5,rep,name=env" patchStrategy:"merge" patchMergeKey:"name"`
 	// 容器进程工作目录
-	WorkingDir string `json:"working_dir,omitempty"`
+	WorkingDir string `json:"working_dir,omitempty" protobuf:"bytes,6,opt,name=workingDir"`
 	// 启动程序命令
 	// 建议不放在shell里启动，比如程序bin文件是passport，那么command直接写成passport，而不是封装一个脚本start.sh来启动
-	Command []string `json:"command"`
+	Command []string `json:"command" protobuf:"bytes,7,rep,name=command"`
 	// 停止程序命令
-	TerminationCommand []string `json:"termination_command,omitempty"`
+	TerminationCommand []string `json:"termination_command,omitempty" protobuf:"bytes,8,rep,name=terminationCommand"`
 
-	SecurityContext *SecurityContext `json:"security_context,omitempty"`
+	SecurityContext *SecurityContext `json:"security_context,omitempty" protobuf:"bytes,9,opt,name=securityContext"`
 
 	// 容器暴露的监听端口列表，为了让外界知道怎么连接进来
-	Ports []ContainerPort `json:"ports,omitempty"`
+	Ports []ContainerPort `json:"ports,omitempty" protobuf:"bytes,10,rep,name=ports" patchStrategy:"merge" patchMergeKey:"container_port"`
 
-	VolumeMounts []VolumeMount `json:"volume_mounts,omitempty"`
+	VolumeMounts []VolumeMount `json:"volume_mounts,omitempty" protobuf:"bytes,11,rep,name=volumeMounts"`
 
-	Resources ResourceRequirements `json:"resources,omitempty"`
+	Resources ResourceRequirements `json:"resources,omitempty" protobuf:"bytes,12,opt,name=resources"`
 }
 
 // PullPolicy describes a policy for if/when to pull a container image
@@ -141,68 +143,68 @@ const (
 )
 
 type EnvVar struct {
-	Name      string        `json:"name"`
-	Value     string        `json:"value,omitempty"`
-	ValueFrom *EnvVarSource `json:"value_from,omitempty"`
+	Name      string        `json:"name" protobuf:"bytes,1,opt,name=name"`
+	Value     string        `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
+	ValueFrom *EnvVarSource `json:"value_from,omitempty" protobuf:"bytes,3,opt,name=valueFrom"`
 }
 
 type EnvVarSource struct {
-	FieldRef *ObjectFieldSelector `json:"field_ref,omitempty"`
+	FieldRef *ObjectFieldSelector `json:"field_ref,omitempty" protobuf:"bytes,1,opt,name=fieldRef"`
 }
 
 type ObjectFieldSelector struct {
-	FieldPath string `json:"field_path,omitempty"`
+	FieldPath string `json:"field_path,omitempty" protobuf:"bytes,1,opt,name=fieldPath"`
 }
 
 type SecurityContext struct {
-	RunAsUser string `json:"run_as_user,omitempty"`
+	RunAsUser string `json:"run_as_user,omitempty" protobuf:"bytes,1,opt,name=runAsUser"`
 }
 
 type ContainerPort struct {
-	Name          string   `json:"name,omitempty"`
-	ContainerPort int      `json:"container_port"`
-	Protocol      Protocol `json:"protocol,omitempty"`
+	Name          string   `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	ContainerPort int      `json:"container_port" protobuf:"varint,2,opt,name=containerPort"`
+	Protocol      Protocol `json:"protocol,omitempty" protobuf:"bytes,3,opt,name=protocol"`
 }
 
 type VolumeMount struct {
-	Name      string `json:"name"`
-	MountPath string `json:"mount_path"`
-	SubPath   string `json:"sub_path,omitempty"`
+	Name      string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	MountPath string `json:"mount_path" protobuf:"bytes,2,opt,name=mountPath"`
+	SubPath   string `json:"sub_path,omitempty" protobuf:"bytes,3,opt,name=subPath"`
 }
 
 type Affinity struct {
 	// Pod节点亲和性
-	PodAffinity *PodAffinity `json:"pod_affinity,omitempty"`
+	PodAffinity *PodAffinity `json:"pod_affinity,omitempty" protobuf:"bytes,1,opt,name=podAffinity"`
 
 	// Pod反亲和性
-	PodAntiAffinity *PodAntiAffinity `json:"pod_anti_affinity,omitempty"`
+	PodAntiAffinity *PodAntiAffinity `json:"pod_anti_affinity,omitempty" protobuf:"bytes,2,opt,name=podAntiAffinity"`
 }
 
 type PodAffinity struct {
 	// 本Pod必须调度到匹配这些规则的Pod所运行Node上
-	Required *Required `json:"required,omitempty"`
+	Required *Required `json:"required,omitempty" protobuf:"bytes,1,opt,name=required"`
 }
 
 type PodAntiAffinity struct {
 	// 本Pod必须不能调度到匹配这些规则的Pod所运行Node上
-	Required *Required `json:"required,omitempty"`
+	Required *Required `json:"required,omitempty" protobuf:"bytes,1,opt,name=required"`
 }
 
 type Required struct {
-	MatchLabels map[string]string `json:"match_labels,omitempty"`
+	MatchLabels map[string]string `json:"match_labels,omitempty" protobuf:"bytes,1,rep,name=matchLabels"`
 }
 
 type Volume struct {
-	Name         string `json:"name"`
-	VolumeSource `json:",inline"`
+	Name         string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	VolumeSource `json:",inline" protobuf:"bytes,2,opt,name=volumeSource"`
 }
 
 type VolumeSource struct {
-	ConfigMap *ConfigMapVolumeSource `json:"configMap,omitempty"`
+	ConfigMap *ConfigMapVolumeSource `json:"configMap,omitempty" protobuf:"bytes,1,opt,name=configMap"`
 }
 
 type ConfigMapVolumeSource struct {
-	LocalObjectReference `json:",inline"`
+	LocalObjectReference `json:",inline" protobuf:"bytes,1,opt,name=localObjectReference"`
 	// If unspecified, each key-value pair in the Data field of the referenced
 	// ConfigMap will be projected into the volume as a file whose name is the
 	// key and content is the value. If specified, the listed keys will be
@@ -210,37 +212,37 @@ type ConfigMapVolumeSource struct {
 	// present. If a key is specified which is not present in the ConfigMap,
 	// the volume setup will error unless it is marked optional. Paths must be
 	// relative and may not contain the '..' path or start with '..'.
-	Items []KeyToPath `json:"items,omitempty"`
+	Items []KeyToPath `json:"items,omitempty" protobuf:"bytes,2,rep,name=items"`
 	// Optional: mode bits to use on created files by default. Must be a
 	// value between 0 and 0777. Defaults to 0644.
 	// Directories within the path are not affected by this setting.
 	// This might be in conflict with other options that affect the file
 	// mode, like fsGroup, and the result can be other mode bits set.
-	DefaultMode *int64 `json:"default_mode,omitempty"`
+	DefaultMode *int64 `json:"default_mode,omitempty" protobuf:"varint,3,opt,name=defaultMode"`
 	// Specify whether the ConfigMap or it's keys must be defined
-	Optional *bool `json:"optional,omitempty"`
+	Optional *bool `json:"optional,omitempty" protobuf:"varint,4,opt,name=optional"`
 }
 
 type LocalObjectReference struct {
 	// Name of the referent.
-	Name string `json:"name,omitempty"`
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
 }
 
 // KeyToPath Maps a string key to a path within a volume.
 type KeyToPath struct {
 	// The key to project.
-	Key string `json:"key"`
+	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
 
 	// The relative path of the file to map the key to.
 	// May not be an absolute path.
 	// May not contain the path element '..'.
 	// May not start with the string '..'.
-	Path string `json:"path"`
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
 	// Optional: mode bits to use on this file, must be a value between 0
 	// and 0777. If not specified, the volume defaultMode will be used.
 	// This might be in conflict with other options that affect the file
 	// mode, like fsGroup, and the result can be other mode bits set.
-	Mode *int32 `json:"mode,omitempty"`
+	Mode *int32 `json:"mode,omitempty" protobuf:"varint,3,opt,name=mode"`
 }
 
 // PodPhase is a label for the condition of a pod at the current time.
@@ -263,28 +265,28 @@ const (
 )
 
 type PodStatus struct {
-	Phase PodPhase `json:"phase,omitempty"`
+	Phase PodPhase `json:"phase,omitempty" protobuf:"bytes,1,opt,name=phase"`
 	// carry首次感知到此Pod的时间，在拉取镜像之前
-	StartTime time.Time `json:"start_time,omitempty"`
+	StartTime time.Time `json:"start_time,omitempty" protobuf:"bytes,2,opt,name=startTime"`
 	// 玷污重启，默认为0
 	// 如果想重启，则将此值置为1，carry感知到此值后负责重启Pod，并将此值置为0
-	TaintRestarts *int64 `json:"taint_restarts,omitempty"`
+	TaintRestarts *int64 `json:"taint_restarts,omitempty" protobuf:"varint,3,opt,name=taintRestarts"`
 	// Pod部署的机器IP
-	HostIp string `json:"host_ip,omitempty"`
+	HostIp string `json:"host_ip,omitempty" protobuf:"bytes,4,opt,name=hostIp"`
 
-	Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
 
-	Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 	// conditions包含详细的Pod状态
-	Conditions []PodCondition `json:"conditions,omitempty"`
+	Conditions []PodCondition `json:"conditions,omitempty" protobuf:"bytes,7,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 
-	ContainerStatuses []ContainerStatus `json:"container_statuses,omitempty"`
+	ContainerStatuses []ContainerStatus `json:"container_statuses,omitempty" protobuf:"bytes,8,rep,name=containerStatuses"`
 
-	InitContainerStatuses []ContainerStatus `json:"init_container_statuses,omitempty"`
+	InitContainerStatuses []ContainerStatus `json:"init_container_statuses,omitempty" protobuf:"bytes,9,rep,name=initContainerStatuses"`
 
-	InstallationContainerStatuses []ContainerStatus `json:"installation_container_statuses,omitempty"`
+	InstallationContainerStatuses []ContainerStatus `json:"installation_container_statuses,omitempty" protobuf:"bytes,10,rep,name=installationContainerStatuses"`
 
-	UninstallationContainerStatuses []ContainerStatus `json:"uninstallation_container_statuses,omitempty"`
+	UninstallationContainerStatuses []ContainerStatus `json:"uninstallation_container_statuses,omitempty" protobuf:"bytes,11,rep,name=uninstallationContainerStatuses"`
 }
 
 // PodConditionType is a valid value for PodCondition.Type
@@ -313,62 +315,62 @@ type PodCondition struct {
 	// installed: 所有安装容器成功执行
 	// initialized: 所有初始化容器都已经成功运行
 	// ready: Pod已经准备好接收请求
-	Type PodConditionType `json:"type"`
+	Type PodConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
 
-	State ConditionState `json:"state"`
+	State ConditionState `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastProbeTime time.Time `json:"last_probe_time,omitempty"`
+	LastProbeTime time.Time `json:"last_probe_time,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
 
-	Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
 
-	Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
 type ContainerStatus struct {
 	// DNS_LABEL, 在同一个pod中必须是唯一的
-	Name        string `json:"name"`
-	ContainerId string `json:"container_id"`
-	Pid         *int64 `json:"pid,omitempty"`
-	Image       string `json:"image"`
+	Name        string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	ContainerId string `json:"container_id" protobuf:"bytes,2,opt,name=containerId"`
+	Pid         *int64 `json:"pid,omitempty" protobuf:"varint,3,opt,name=pid"`
+	Image       string `json:"image" protobuf:"bytes,4,opt,name=image"`
 	// sha256:xxx
-	ImageId string `json:"image_id,omitempty"`
+	ImageId string `json:"image_id,omitempty" protobuf:"bytes,5,opt,name=imageId"`
 	//
-	State ContainerState `json:"state,omitempty"`
+	State ContainerState `json:"state,omitempty" protobuf:"bytes,6,opt,name=state"`
 	// 容器是否能接收流量请求
-	Ready bool `json:"ready"`
+	Ready bool `json:"ready" protobuf:"varint,7,opt,name=ready"`
 
-	RestartCount int64 `json:"restart_count"`
+	RestartCount int64 `json:"restart_count" protobuf:"varint,8,opt,name=restartCount"`
 }
 
 type ContainerState struct {
-	Waiting    *ContainerStateWaiting    `json:"waiting,omitempty"`
-	Running    *ContainerStateRunning    `json:"running,omitempty"`
-	Terminated *ContainerStateTerminated `json:"terminated,omitempty"`
+	Waiting    *ContainerStateWaiting    `json:"waiting,omitempty" protobuf:"bytes,1,opt,name=waiting"`
+	Running    *ContainerStateRunning    `json:"running,omitempty" protobuf:"bytes,2,opt,name=running"`
+	Terminated *ContainerStateTerminated `json:"terminated,omitempty" protobuf:"bytes,3,opt,name=terminated"`
 }
 
 type ContainerStateWaiting struct {
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty" protobuf:"bytes,1,opt,name=reason"`
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
 }
 
 type ContainerStateRunning struct {
 	// 容器启动（重启）时间
-	StartTime time.Time `json:"start_time,omitempty"`
+	StartTime time.Time `json:"start_time,omitempty" protobuf:"bytes,1,opt,name=startTime"`
 }
 
 type ContainerStateTerminated struct {
 	// Exit status from the last termination of the container
-	ExitCode int `json:"exit_code"`
+	ExitCode int `json:"exit_code" protobuf:"varint,1,opt,name=exitCode"`
 	// Signal from the last termination of the container
-	Signal int `json:"signal,omitempty"`
+	Signal int `json:"signal,omitempty" protobuf:"varint,2,opt,name=signal"`
 	// (brief) reason from the last termination of the container
-	Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
 	// Message regarding the last termination of the container
-	Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
 	// Time at which previous execution of the container started
-	StartTime time.Time `json:"start_time,omitempty"`
+	StartTime time.Time `json:"start_time,omitempty" protobuf:"bytes,5,opt,name=startTime"`
 	// Time at which the container last terminated
-	FinishTime time.Time `json:"finish_time,omitempty"`
+	FinishTime time.Time `json:"finish_time,omitempty" protobuf:"bytes,6,opt,name=finishTime"`
 }
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+// GroupName is the group name used in this package
+const GroupName = "carry.i"
+
+// SchemeGroupVersion is group version used to register these objects
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
+
+// Kind takes an unqualified kind and returns a Group qualified GroupKind
+func Kind(kind string) schema.GroupKind {
+	return SchemeGroupVersion.WithKind(kind).GroupKind()
+}
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+var (
+	// SchemeBuilder collects the functions that add the types of this group to a scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme adds all types of this group to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// addKnownTypes 注册carry.i/v1下的所有kind，kind名为类型名的小写形式
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Pod{},
+		&PodList{},
+		&Binding{},
+		&Deployment{},
+		&DeploymentList{},
+		&ReplicaSet{},
+		&ReplicaSetList{},
+		&StatefulSet{},
+		&StatefulSetList{},
+		&DaemonSet{},
+		&DaemonSetList{},
+		&Job{},
+		&JobList{},
+		&Service{},
+		&ServiceList{},
+		&Node{},
+		&NodeList{},
+		&Namespace{},
+		&NamespaceList{},
+		&ConfigMap{},
+		&ConfigMapList{},
+		&Event{},
+		&EventList{},
+		&ResourceQuota{},
+		&ResourceQuotaList{},
+		&LimitRange{},
+		&LimitRangeList{},
+		&AdmissionReview{},
+		&TokenReview{},
+		&Status{},
+	)
+	return nil
+}
@@ -2,38 +2,40 @@ package v1
 
 import "time"
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type ReplicaSet struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec ReplicaSetSpec `json:"spec,omitempty"`
+	Spec ReplicaSetSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status ReplicaSetStatus `json:"status,omitempty"`
+	Status ReplicaSetStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type ReplicaSetList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []ReplicaSet `json:"items"`
+	Items []ReplicaSet `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type ReplicaSetSpec struct {
 	// required
-	Selector *LabelSelector `json:"selector"`
+	Selector *LabelSelector `json:"selector" protobuf:"bytes,1,opt,name=selector"`
 	// required, same as pod
-	Template PodTemplateSpec `json:"template"`
+	Template PodTemplateSpec `json:"template" protobuf:"bytes,2,opt,name=template"`
 	// optional, defaults to 1
-	Replicas *int64 `json:"replicas,omitempty"`
+	Replicas *int64 `json:"replicas,omitempty" protobuf:"varint,3,opt,name=replicas"`
 	// strategy to use to replace existing pods.
-	Strategy ReplicaSetStrategy `json:"strategy,omitempty"`
+	Strategy ReplicaSetStrategy `json:"strategy,omitempty" protobuf:"bytes,4,opt,name=strategy"`
 	// default to 0
-	MinReadySeconds int64 `json:"min_ready_seconds,omitempty"`
+	MinReadySeconds int64 `json:"min_ready_seconds,omitempty" protobuf:"varint,5,opt,name=minReadySeconds"`
 }
 
 type ReplicaSetStrategy struct {
 	// required
-	Type ReplicaSetStrategyType `json:"type"`
+	Type ReplicaSetStrategyType `json:"type" protobuf:"bytes,1,opt,name=type"`
 }
 
 type ReplicaSetStrategyType string
@@ -44,27 +46,27 @@ const (
 )
 
 type ReplicaSetStatus struct {
-	Replicas             int64 `json:"replicas,omitempty"`
-	FullyLabeledReplicas int64 `json:"fully_labeled_replicas,omitempty"`
-	UpdatedReplicas      int64 `json:"updated_replicas,omitempty"`
-	ReadyReplicas        int64 `json:"ready_replicas,omitempty"`
-	AvailableReplicas    int64 `json:"available_replicas,omitempty"`
+	Replicas             int64 `json:"replicas,omitempty" protobuf:"varint,1,opt,name=replicas"`
+	FullyLabeledReplicas int64 `json:"fully_labeled_replicas,omitempty" protobuf:"varint,2,opt,name=fullyLabeledReplicas"`
+	UpdatedReplicas      int64 `json:"updated_replicas,omitempty" protobuf:"varint,3,opt,name=updatedReplicas"`
+	ReadyReplicas        int64 `json:"ready_replicas,omitempty" protobuf:"varint,4,opt,name=readyReplicas"`
+	AvailableReplicas    int64 `json:"available_replicas,omitempty" protobuf:"varint,5,opt,name=availableReplicas"`
 
 	// ObservedGeneration reflects the generation of the most recently observed ReplicaSet.
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	ObservedGeneration int64 `json:"observed_generation,omitempty" protobuf:"varint,6,opt,name=observedGeneration"`
 
-	Conditions []ReplicaSetCondition `json:"conditions,omitempty"`
+	Conditions []ReplicaSetCondition `json:"conditions,omitempty" protobuf:"bytes,7,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 type ReplicaSetCondition struct {
-	Type  ReplicaSetConditionType `json:"type"`
-	State ConditionState          `json:"state"`
+	Type  ReplicaSetConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	State ConditionState          `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
-	LastUpdateTime     time.Time `json:"last_update_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
+	LastUpdateTime     time.Time `json:"last_update_time,omitempty" protobuf:"bytes,4,opt,name=lastUpdateTime"`
 
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
 type ReplicaSetConditionType string
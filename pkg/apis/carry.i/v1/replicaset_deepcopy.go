@@ -1,87 +0,0 @@
-package v1
-
-func (in *ReplicaSet) DeepCopy() *ReplicaSet {
-	if in == nil {
-		return nil
-	}
-	out := new(ReplicaSet)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *ReplicaSet) DeepCopyInto(out *ReplicaSet) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-	return
-}
-
-func (in *ReplicaSetSpec) DeepCopy() *ReplicaSetSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(ReplicaSetSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *ReplicaSetSpec) DeepCopyInto(out *ReplicaSetSpec) {
-	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(int64)
-			**out = **in
-		}
-	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(LabelSelector)
-			(*in).DeepCopyInto(*out)
-		}
-	}
-	in.Template.DeepCopyInto(&out.Template)
-	return
-}
-
-func (in *ReplicaSetStatus) DeepCopy() *ReplicaSetStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ReplicaSetStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *ReplicaSetStatus) DeepCopyInto(out *ReplicaSetStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ReplicaSetCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	return
-}
-
-func (in *ReplicaSetCondition) DeepCopy() *ReplicaSetCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(ReplicaSetCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *ReplicaSetCondition) DeepCopyInto(out *ReplicaSetCondition) {
-	*out = *in
-	return
-}
@@ -0,0 +1,48 @@
+package v1
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type ResourceQuota struct {
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+
+	Spec ResourceQuotaSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
+
+	Status ResourceQuotaStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type ResourceQuotaList struct {
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []ResourceQuota `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
+
+type ResourceQuotaSpec struct {
+	// namespace内允许使用的资源总量上限，key见下面的ResourceName常量
+	Hard ResourceList `json:"hard,omitempty" protobuf:"bytes,1,rep,name=hard"`
+}
+
+type ResourceQuotaStatus struct {
+	Hard ResourceList `json:"hard,omitempty" protobuf:"bytes,1,rep,name=hard"`
+	// 已经使用的资源量
+	Used ResourceList `json:"used,omitempty" protobuf:"bytes,2,rep,name=used"`
+}
+
+// 可以在ResourceQuota中限制的资源
+const (
+	// ResourcePods pod数量
+	ResourcePods ResourceName = "pods"
+	// ResourceServices service数量
+	ResourceServices ResourceName = "services"
+	// ResourceConfigMaps configmap数量
+	ResourceConfigMaps ResourceName = "configmaps"
+	// ResourceRequestsCPU 所有容器cpu request之和
+	ResourceRequestsCPU ResourceName = "requests.cpu"
+	// ResourceRequestsMemory 所有容器memory request之和
+	ResourceRequestsMemory ResourceName = "requests.memory"
+	// ResourceLimitsCPU 所有容器cpu limit之和
+	ResourceLimitsCPU ResourceName = "limits.cpu"
+	// ResourceLimitsMemory 所有容器memory limit之和
+	ResourceLimitsMemory ResourceName = "limits.memory"
+)
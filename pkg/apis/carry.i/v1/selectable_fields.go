@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/opencarry/carry/pkg/fields"
+	"github.com/opencarry/carry/pkg/util/sets"
+)
+
+// SelectableFieldsFunc returns the set of fields of an object that may be used in a field selector.
+type SelectableFieldsFunc func(obj interface{}) (fields.Set, error)
+
+type selectableFieldsEntry struct {
+	newFunc    func() interface{}
+	fieldsFunc SelectableFieldsFunc
+}
+
+// selectableFields 可用于field selector过滤的字段，按kind区分
+// metadata.name 对所有kind都可用，有namespace的kind还支持 metadata.namespace
+var selectableFields = map[string]selectableFieldsEntry{
+	"pod":       {func() interface{} { return &Pod{} }, podSelectableFields},
+	"event":     {func() interface{} { return &Event{} }, eventSelectableFields},
+	"node":      {func() interface{} { return &Node{} }, nodeSelectableFields},
+	"namespace": {func() interface{} { return &Namespace{} }, namespaceSelectableFields},
+}
+
+// SelectableFields returns the field set of obj that can be matched against a
+// fields.Selector. Kinds without an entry in the table only support metadata fields.
+func SelectableFields(kind string, obj interface{}) (fields.Set, error) {
+	if entry, ok := selectableFields[kind]; ok {
+		return entry.fieldsFunc(obj)
+	}
+	accessor, err := Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return ObjectMetaFieldsSet(accessor, true), nil
+}
+
+// SupportedFieldSelectors returns the field labels that may be used in a field
+// selector for the given kind.
+func SupportedFieldSelectors(kind string) sets.String {
+	entry, ok := selectableFields[kind]
+	if !ok {
+		return sets.NewString("metadata.name", "metadata.namespace")
+	}
+	set, err := entry.fieldsFunc(entry.newFunc())
+	if err != nil {
+		return sets.NewString()
+	}
+	return sets.StringKeySet(set)
+}
+
+// ValidateFieldSelector checks that every requirement of selector refers to a
+// field that is selectable for kind.
+func ValidateFieldSelector(kind string, selector fields.Selector) error {
+	if selector == nil {
+		return nil
+	}
+	supported := SupportedFieldSelectors(kind)
+	for _, r := range selector.Requirements() {
+		if !supported.Has(r.Field) {
+			return fmt.Errorf("%q is not a known field selector: only %q", r.Field, supported.List())
+		}
+	}
+	return nil
+}
+
+// ObjectMetaFieldsSet returns a fields that represent the ObjectMeta.
+func ObjectMetaFieldsSet(objectMeta Object, hasNamespaceField bool) fields.Set {
+	if !hasNamespaceField {
+		return fields.Set{
+			"metadata.name": objectMeta.GetName(),
+		}
+	}
+	return fields.Set{
+		"metadata.name":      objectMeta.GetName(),
+		"metadata.namespace": objectMeta.GetNamespace(),
+	}
+}
+
+// MergeFieldsSets merges a fields'set from fragment into the source.
+func MergeFieldsSets(source fields.Set, fragment fields.Set) fields.Set {
+	for k, value := range fragment {
+		source[k] = value
+	}
+	return source
+}
+
+func podSelectableFields(obj interface{}) (fields.Set, error) {
+	pod, ok := obj.(*Pod)
+	if !ok {
+		return nil, fmt.Errorf("not a pod: %T", obj)
+	}
+	return PodToSelectableFields(pod), nil
+}
+
+// PodToSelectableFields returns a field set that represents the object
+func PodToSelectableFields(pod *Pod) fields.Set {
+	podSpecificFieldsSet := fields.Set{
+		"spec.node_name":      pod.Spec.NodeName,
+		"spec.restart_policy": string(pod.Spec.RestartPolicy),
+		"spec.scheduler_name": pod.Spec.SchedulerName,
+		"status.phase":        string(pod.Status.Phase),
+		"status.host_ip":      pod.Status.HostIp,
+	}
+	return MergeFieldsSets(ObjectMetaFieldsSet(&pod.ObjectMeta, true), podSpecificFieldsSet)
+}
+
+func eventSelectableFields(obj interface{}) (fields.Set, error) {
+	event, ok := obj.(*Event)
+	if !ok {
+		return nil, fmt.Errorf("not an event: %T", obj)
+	}
+	return EventToSelectableFields(event), nil
+}
+
+// EventToSelectableFields returns a field set that represents the object
+func EventToSelectableFields(event *Event) fields.Set {
+	specificFieldsSet := fields.Set{
+		"involved_object.kind":             event.InvolvedObject.Kind,
+		"involved_object.namespace":        event.InvolvedObject.Namespace,
+		"involved_object.name":             event.InvolvedObject.Name,
+		"involved_object.uid":              string(event.InvolvedObject.UID),
+		"involved_object.api_version":      event.InvolvedObject.APIVersion,
+		"involved_object.resource_version": event.InvolvedObject.ResourceVersion,
+		"involved_object.field_path":       event.InvolvedObject.FieldPath,
+		"reason":                           event.Reason,
+		"source":                           event.Source.Component,
+		"type":                             event.Type,
+	}
+	return MergeFieldsSets(ObjectMetaFieldsSet(&event.ObjectMeta, true), specificFieldsSet)
+}
+
+func nodeSelectableFields(obj interface{}) (fields.Set, error) {
+	node, ok := obj.(*Node)
+	if !ok {
+		return nil, fmt.Errorf("not a node: %T", obj)
+	}
+	return NodeToSelectableFields(node), nil
+}
+
+// NodeToSelectableFields returns a field set that represents the object.
+func NodeToSelectableFields(node *Node) fields.Set {
+	specificFieldsSet := fields.Set{
+		"spec.unschedulable": fmt.Sprint(node.Spec.Unschedulable),
+	}
+	return MergeFieldsSets(ObjectMetaFieldsSet(&node.ObjectMeta, false), specificFieldsSet)
+}
+
+func namespaceSelectableFields(obj interface{}) (fields.Set, error) {
+	namespace, ok := obj.(*Namespace)
+	if !ok {
+		return nil, fmt.Errorf("not a namespace: %T", obj)
+	}
+	return NamespaceToSelectableFields(namespace), nil
+}
+
+// NamespaceToSelectableFields returns a field set that represents the object
+func NamespaceToSelectableFields(namespace *Namespace) fields.Set {
+	specificFieldsSet := fields.Set{
+		"status.phase": string(namespace.Status.Phase),
+	}
+	return MergeFieldsSets(ObjectMetaFieldsSet(&namespace.ObjectMeta, false), specificFieldsSet)
+}
@@ -2,45 +2,47 @@ package v1
 
 import "time"
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Service struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec ServiceSpec `json:"spec,omitempty"`
+	Spec ServiceSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status ServiceStatus `json:"status,omitempty"`
+	Status ServiceStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type ServiceList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []Service `json:"items"`
+	Items []Service `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type ServiceSpec struct {
-	Selector map[string]string `json:"selector,omitempty"`
+	Selector map[string]string `json:"selector,omitempty" protobuf:"bytes,1,rep,name=selector"`
 
-	Ports []ServicePort `json:"ports,omitempty"`
+	Ports []ServicePort `json:"ports,omitempty" protobuf:"bytes,2,rep,name=ports"`
 }
 
 type ServicePort struct {
-	Name string `json:"name,omitempty"`
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
 
-	Address string `json:"address"`
+	Address string `json:"address" protobuf:"bytes,2,opt,name=address"`
 
-	Port int `json:"port"`
+	Port int `json:"port" protobuf:"varint,3,opt,name=port"`
 
-	Protocol Protocol `json:"protocol,omitempty"`
+	Protocol Protocol `json:"protocol,omitempty" protobuf:"bytes,4,opt,name=protocol"`
 
-	TargetPort int `json:"target_port"`
+	TargetPort int `json:"target_port" protobuf:"varint,5,opt,name=targetPort"`
 
 	// 端口注解，用于扩展service，第三方插件可将自定义的注解信息写在此处供插件自身使用
-	Annotations map[string]string `json:"annotations,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,6,rep,name=annotations"`
 }
 
 type ServiceStatus struct {
-	Conditions []ServiceCondition `json:"conditions,omitempty"`
+	Conditions []ServiceCondition `json:"conditions,omitempty" protobuf:"bytes,1,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 type ServiceConditionType string
@@ -50,15 +52,15 @@ const (
 )
 
 type ServiceCondition struct {
-	Type ServiceConditionType `json:"type"`
+	Type ServiceConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
 
-	State ConditionState `json:"state"`
+	State ConditionState `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastProbeTime time.Time `json:"last_probe_time,omitempty"`
+	LastProbeTime time.Time `json:"last_probe_time,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
 
-	Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
 
-	Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
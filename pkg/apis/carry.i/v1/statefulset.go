@@ -2,40 +2,42 @@ package v1
 
 import "time"
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type StatefulSet struct {
-	TypeMeta   `json:",omitempty"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",omitempty" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	Spec StatefulSetSpec `json:"spec,omitempty"`
+	Spec StatefulSetSpec `json:"spec,omitempty" protobuf:"bytes,3,opt,name=spec"`
 
-	Status StatefulSetStatus `json:"status,omitempty"`
+	Status StatefulSetStatus `json:"status,omitempty" protobuf:"bytes,4,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type StatefulSetList struct {
-	TypeMeta `json:",inline"`
-	ListMeta `json:"metadata,omitempty"`
+	TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
 
-	Items []StatefulSet `json:"items"`
+	Items []StatefulSet `json:"items" protobuf:"bytes,3,rep,name=items"`
 }
 
 type StatefulSetSpec struct {
 	// required
-	Selector *LabelSelector `json:"selector"`
+	Selector *LabelSelector `json:"selector" protobuf:"bytes,1,opt,name=selector"`
 	// required, same as pod
-	Template PodTemplateSpec `json:"template"`
+	Template PodTemplateSpec `json:"template" protobuf:"bytes,2,opt,name=template"`
 	// optional, defaults to 1
-	Replicas *int64 `json:"replicas,omitempty"`
+	Replicas *int64 `json:"replicas,omitempty" protobuf:"varint,3,opt,name=replicas"`
 	// strategy to use to replace existing pods.
-	Strategy StatefulSetStrategy `json:"strategy,omitempty"`
+	Strategy StatefulSetStrategy `json:"strategy,omitempty" protobuf:"bytes,4,opt,name=strategy"`
 	// optional, defaults to 10
-	RevisionHistoryLimit *int64 `json:"revision_history_limit,omitempty"`
+	RevisionHistoryLimit *int64 `json:"revision_history_limit,omitempty" protobuf:"varint,5,opt,name=revisionHistoryLimit"`
 
-	ServiceName string `json:"service_name,omitempty"`
+	ServiceName string `json:"service_name,omitempty" protobuf:"bytes,6,opt,name=serviceName"`
 }
 
 type StatefulSetStrategy struct {
 	// required
-	Type StatefulSetStrategyType `json:"type"`
+	Type StatefulSetStrategyType `json:"type" protobuf:"bytes,1,opt,name=type"`
 }
 
 type StatefulSetStrategyType string
@@ -47,34 +49,34 @@ const (
 
 type StatefulSetStatus struct {
 	// replicas is the number of Pods created by the StatefulSet controller.
-	Replicas int64 `json:"replicas,omitempty"`
+	Replicas int64 `json:"replicas,omitempty" protobuf:"varint,1,opt,name=replicas"`
 	// readyReplicas is the number of pods created for this StatefulSet with a Ready Condition.
-	ReadyReplicas int64 `json:"ready_replicas,omitempty"`
+	ReadyReplicas int64 `json:"ready_replicas,omitempty" protobuf:"varint,2,opt,name=readyReplicas"`
 	// currentReplicas is the number of Pods created by the StatefulSet controller from the StatefulSet version indicated by currentRevision.
-	CurrentReplicas int64 `json:"current_replicas,omitempty"`
+	CurrentReplicas int64 `json:"current_replicas,omitempty" protobuf:"varint,3,opt,name=currentReplicas"`
 	// updatedReplicas is the number of Pods created by the StatefulSet controller from the StatefulSet version indicated by updateRevision.
-	UpdatedReplicas int64 `json:"updated_replicas,omitempty"`
+	UpdatedReplicas int64 `json:"updated_replicas,omitempty" protobuf:"varint,4,opt,name=updatedReplicas"`
 	// currentRevision, if not empty, indicates the version of the StatefulSet used to generate Pods in the sequence [0,currentReplicas).
-	CurrentRevision string `json:"current_revision,omitempty"`
+	CurrentRevision string `json:"current_revision,omitempty" protobuf:"bytes,5,opt,name=currentRevision"`
 	// updateRevision, if not empty, indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
-	UpdateRevision string `json:"update_revision,omitempty"`
+	UpdateRevision string `json:"update_revision,omitempty" protobuf:"bytes,6,opt,name=updateRevision"`
 
-	ObservedGeneration *int64 `json:"observed_generation,omitempty"`
+	ObservedGeneration *int64 `json:"observed_generation,omitempty" protobuf:"varint,7,opt,name=observedGeneration"`
 
-	CollisionCount *int64 `json:"collision_count,omitempty"`
+	CollisionCount *int64 `json:"collision_count,omitempty" protobuf:"varint,8,opt,name=collisionCount"`
 
-	Conditions []StatefulSetCondition `json:"conditions,omitempty"`
+	Conditions []StatefulSetCondition `json:"conditions,omitempty" protobuf:"bytes,9,rep,name=conditions" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 type StatefulSetCondition struct {
-	Type  StatefulSetConditionType `json:"type"`
-	State ConditionState           `json:"state"`
+	Type  StatefulSetConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	State ConditionState           `json:"state" protobuf:"bytes,2,opt,name=state"`
 
-	LastTransitionTime time.Time `json:"last_transition_time,omitempty"`
-	LastUpdateTime     time.Time `json:"last_update_time,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
+	LastUpdateTime     time.Time `json:"last_update_time,omitempty" protobuf:"bytes,4,opt,name=lastUpdateTime"`
 
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
 type StatefulSetConditionType string
@@ -46,22 +46,22 @@ func (rl *ResourceList) Memory() *resource.Quantity {
 }
 
 type ResourceRequirements struct {
-	Limits   ResourceList `json:"limits,omitempty"`
-	Requests ResourceList `json:"requests,omitempty"`
+	Limits   ResourceList `json:"limits,omitempty" protobuf:"bytes,1,rep,name=limits"`
+	Requests ResourceList `json:"requests,omitempty" protobuf:"bytes,2,rep,name=requests"`
 }
 
 type LabelSelector struct {
-	MatchLabels map[string]string `json:"match_labels,omitempty"`
+	MatchLabels map[string]string `json:"match_labels,omitempty" protobuf:"bytes,1,rep,name=matchLabels"`
 
-	MatchExpressions []LabelSelectorRequirement `json:"match_expressions,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `json:"match_expressions,omitempty" protobuf:"bytes,2,rep,name=matchExpressions"`
 }
 
 type LabelSelectorRequirement struct {
-	Key string `json:"key"`
+	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
 
-	Operator LabelSelectorOperator `json:"operator"`
+	Operator LabelSelectorOperator `json:"operator" protobuf:"bytes,2,opt,name=operator"`
 
-	Values []string `json:"values,omitempty"`
+	Values []string `json:"values,omitempty" protobuf:"bytes,3,rep,name=values"`
 }
 
 type LabelSelectorOperator string
@@ -91,12 +91,12 @@ const (
 )
 
 type ObjectReference struct {
-	Kind            string `json:"kind"`
-	Namespace       string `json:"namespace"`
-	Name            string `json:"name"`
-	UID             UID    `json:"uid"`
-	APIVersion      string `json:"api_version"`
-	ResourceVersion string `json:"resource_version"`
+	Kind            string `json:"kind" protobuf:"bytes,1,opt,name=kind"`
+	Namespace       string `json:"namespace" protobuf:"bytes,2,opt,name=namespace"`
+	Name            string `json:"name" protobuf:"bytes,3,opt,name=name"`
+	UID             UID    `json:"uid" protobuf:"bytes,4,opt,name=uid"`
+	APIVersion      string `json:"api_version" protobuf:"bytes,5,opt,name=apiVersion"`
+	ResourceVersion string `json:"resource_version" protobuf:"bytes,6,opt,name=resourceVersion"`
 	// Optional. If referring to a piece of an object instead of an entire object, this string
 	// should contain information to identify the sub-object. For example, if the object
 	// reference is to a container within a pod, this would take on a value like:
@@ -104,7 +104,7 @@ type ObjectReference struct {
 	// the event) or if no container name is specified "spec.containers[2]" (container with
 	// index 2 in this pod). This syntax is chosen only to have some well-defined way of
 	// referencing a part of an object.
-	FieldPath string `json:"field_path,omitempty"`
+	FieldPath string `json:"field_path,omitempty" protobuf:"bytes,7,opt,name=fieldPath"`
 }
 
 func (obj *ObjectReference) SetGroupVersionKind(gvk schema.GroupVersionKind) {
@@ -117,10 +117,11 @@ func (obj *ObjectReference) GroupVersionKind() schema.GroupVersionKind {
 
 func (obj *ObjectReference) GetObjectKind() schema.ObjectKind { return obj }
 
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
 type Binding struct {
-	TypeMeta   `json:",inline"`
-	ObjectMeta `json:"metadata,omitempty"`
+	TypeMeta   `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
 
-	PodID string `json:"pod_id"`
-	Host  string `json:"host"`
+	PodID string `json:"pod_id" protobuf:"bytes,3,opt,name=podID"`
+	Host  string `json:"host" protobuf:"bytes,4,opt,name=host"`
 }
@@ -0,0 +1,18 @@
+package v1
+
+import "encoding/json"
+
+// WatchEvent is the wire format of a single event of a watch. A watch
+// response is a stream of newline delimited WatchEvents.
+type WatchEvent struct {
+	// added, modified, deleted, bookmark or error
+	Type string `json:"type"`
+
+	// Object is the encoded object:
+	//  * for added and modified, the new state of the object
+	//  * for deleted, the state of the object immediately before deletion
+	//  * for bookmark, an object of the watched kind with only the resource
+	//    version set
+	//  * for error, a status describing the error
+	Object json.RawMessage `json:"object"`
+}
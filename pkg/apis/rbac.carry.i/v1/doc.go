@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package
+// +groupName=rbac.carry.i
+
+// Package v1 contains the rbac.carry.i/v1 API types, which grant users and
+// groups access to the resources of the API.
+package v1
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	metav1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+const (
+	// APIGroupAll matches every api group in a PolicyRule.
+	APIGroupAll = "*"
+	// ResourceAll matches every resource and subresource in a PolicyRule.
+	ResourceAll = "*"
+	// VerbAll matches every verb in a PolicyRule.
+	VerbAll = "*"
+
+	// UserKind is the kind of a Subject naming a user.
+	UserKind = "user"
+	// GroupKind is the kind of a Subject naming a group of users.
+	GroupKind = "group"
+
+	// RoleKind is the kind of a RoleRef to a Role.
+	RoleKind = "role"
+	// ClusterRoleKind is the kind of a RoleRef to a ClusterRole.
+	ClusterRoleKind = "clusterrole"
+)
+
+// PolicyRule holds information that describes a policy rule, but does not
+// contain information about who the rule applies to.
+type PolicyRule struct {
+	// Verbs is a list of verbs that apply to the resources, like "get",
+	// "list", "watch", "create", "update", "patch" and "delete". "*" matches
+	// all of them.
+	Verbs []string `json:"verbs" protobuf:"bytes,1,rep,name=verbs"`
+	// APIGroups is the name of the api groups that contain the resources,
+	// like "carry.i". "*" matches every group.
+	APIGroups []string `json:"api_groups,omitempty" protobuf:"bytes,2,rep,name=apiGroups"`
+	// Resources is a list of resources this rule applies to, like "pods".
+	// A subresource is written as "pods/status", "*/status" matches the
+	// status of every resource and "*" matches everything.
+	Resources []string `json:"resources,omitempty" protobuf:"bytes,3,rep,name=resources"`
+	// ResourceNames is an optional white list of names that the rule applies
+	// to. An empty set means that everything is allowed. Requests without a
+	// name, like list and create, never match a rule with resource names.
+	ResourceNames []string `json:"resource_names,omitempty" protobuf:"bytes,4,rep,name=resourceNames"`
+}
+
+// Subject is a user or a group a binding grants a role to.
+type Subject struct {
+	// Kind is "user" or "group".
+	Kind string `json:"kind" protobuf:"bytes,1,opt,name=kind"`
+	// Name of the user or group.
+	Name string `json:"name" protobuf:"bytes,2,opt,name=name"`
+}
+
+// RoleRef contains information that points to the role being used.
+type RoleRef struct {
+	// Kind is "role" or "clusterrole". A ClusterRoleBinding may only refer
+	// to a ClusterRole.
+	Kind string `json:"kind" protobuf:"bytes,1,opt,name=kind"`
+	// Name is the name of the role.
+	Name string `json:"name" protobuf:"bytes,2,opt,name=name"`
+}
+
+// Role is a namespaced set of PolicyRules, granted by a RoleBinding in the
+// same namespace.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type Role struct {
+	metav1.TypeMeta   `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+
+	// Rules holds all the PolicyRules for this Role
+	Rules []PolicyRule `json:"rules" protobuf:"bytes,3,rep,name=rules"`
+}
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type RoleList struct {
+	metav1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []Role `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
+
+// RoleBinding grants the permissions of a Role, or of a ClusterRole, to its
+// subjects within its namespace.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type RoleBinding struct {
+	metav1.TypeMeta   `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+
+	// Subjects holds references to the users and groups the role applies to.
+	Subjects []Subject `json:"subjects,omitempty" protobuf:"bytes,3,rep,name=subjects"`
+	// RoleRef can reference a Role in the namespace of the binding or a
+	// ClusterRole. It cannot be changed once the binding is created.
+	RoleRef RoleRef `json:"role_ref" protobuf:"bytes,4,opt,name=roleRef"`
+}
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type RoleBindingList struct {
+	metav1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []RoleBinding `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
+
+// ClusterRole is a cluster level set of PolicyRules. Granted by a
+// ClusterRoleBinding it applies to every namespace and to cluster scoped
+// resources, granted by a RoleBinding only to the namespace of the binding.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type ClusterRole struct {
+	metav1.TypeMeta   `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+
+	// Rules holds all the PolicyRules for this ClusterRole
+	Rules []PolicyRule `json:"rules" protobuf:"bytes,3,rep,name=rules"`
+}
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type ClusterRoleList struct {
+	metav1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []ClusterRole `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
+
+// ClusterRoleBinding grants the permissions of a ClusterRole to its subjects
+// in every namespace.
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type ClusterRoleBinding struct {
+	metav1.TypeMeta   `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=objectMeta"`
+
+	// Subjects holds references to the users and groups the role applies to.
+	Subjects []Subject `json:"subjects,omitempty" protobuf:"bytes,3,rep,name=subjects"`
+	// RoleRef can only reference a ClusterRole. It cannot be changed once
+	// the binding is created.
+	RoleRef RoleRef `json:"role_ref" protobuf:"bytes,4,opt,name=roleRef"`
+}
+
+// +k8s:deepcopy-gen:interfaces=github.com/opencarry/carry/pkg/runtime.Object
+type ClusterRoleBindingList struct {
+	metav1.TypeMeta `json:",inline" protobuf:"bytes,1,opt,name=typeMeta"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,2,opt,name=listMeta"`
+
+	Items []ClusterRoleBinding `json:"items" protobuf:"bytes,3,rep,name=items"`
+}
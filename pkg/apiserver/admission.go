@@ -0,0 +1,59 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/apiserver/request"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+// admissionAttributes returns what the admission plugins see of a request
+// with the given operation on info. obj is nil for deletes, old is nil for
+// creates.
+func admissionAttributes(req *http.Request, info *requestInfo, operation admission.Operation, obj, old runtime.Object) admission.Attributes {
+	name := info.name
+	if len(name) == 0 && obj != nil {
+		if metadata, err := v1.Accessor(obj); err == nil {
+			name = metadata.GetName()
+		}
+	}
+	userInfo, _ := request.UserFrom(req.Context())
+	return admission.NewAttributesRecord(obj, old,
+		info.resource.groupVersion.WithKind(info.resource.kind), info.namespace, name,
+		info.resource.groupVersion.WithResource(info.resource.name), info.subresource, operation, userInfo)
+}
+
+// admit runs the mutating admission plugins, which may change the object of
+// the request.
+func (s *Server) admit(req *http.Request, a admission.Attributes) *apierrors.StatusError {
+	mutator, ok := s.admissionControl.(admission.MutationInterface)
+	if !ok || !mutator.Handles(a.GetOperation()) {
+		return nil
+	}
+	return admissionError(mutator.Admit(req.Context(), a))
+}
+
+// validateAdmission runs the validating admission plugins.
+func (s *Server) validateAdmission(req *http.Request, a admission.Attributes) *apierrors.StatusError {
+	validator, ok := s.admissionControl.(admission.ValidationInterface)
+	if !ok || !validator.Handles(a.GetOperation()) {
+		return nil
+	}
+	return admissionError(validator.Validate(req.Context(), a))
+}
+
+// admissionError returns the status of an error of an admission plugin.
+// Plugins reject requests with a status, like admission.NewForbidden; other
+// errors are internal errors.
+func admissionError(err error) *apierrors.StatusError {
+	if err == nil {
+		return nil
+	}
+	if status, ok := err.(*apierrors.StatusError); ok {
+		return status
+	}
+	return apierrors.NewInternalError(err)
+}
@@ -0,0 +1,140 @@
+package apiserver
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/apiserver/request"
+	"github.com/opencarry/carry/pkg/audit"
+	"github.com/opencarry/carry/pkg/audit/policy"
+)
+
+// WithAudit records every request passed to handler as an audit event, at
+// the level checker picks for it, and hands the event to sink once the
+// request is answered. The user of the request is taken from its context,
+// see WithAuthentication; WithAudit goes before WithAuthorization so that
+// forbidden requests are audited too.
+func WithAudit(handler http.Handler, checker policy.Checker, sink audit.Sink) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ev := newAuditEvent(req)
+		ev.Level = checker.Level(ev)
+		if ev.Level == auditv1.LevelNone {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		rw := &auditResponseWriter{ResponseWriter: w}
+		defer func() {
+			// 处理请求时panic也要记录审计事件，返回码按500算
+			if r := recover(); r != nil {
+				if rw.code == 0 {
+					rw.code = http.StatusInternalServerError
+				}
+				finishAuditEvent(ev, rw.code)
+				sink.ProcessEvents(ev)
+				panic(r)
+			}
+			finishAuditEvent(ev, rw.code)
+			sink.ProcessEvents(ev)
+		}()
+		handler.ServeHTTP(rw, req.WithContext(request.WithAuditEvent(req.Context(), ev)))
+	})
+}
+
+// newAuditEvent returns the audit event of req, without a level.
+func newAuditEvent(req *http.Request) *auditv1.Event {
+	attributes := authorizationAttributes(req)
+	ev := &auditv1.Event{
+		AuditID:                  v1.UID(uuid.New().String()),
+		RequestURI:               req.URL.RequestURI(),
+		Verb:                     attributes.GetVerb(),
+		SourceIPs:                sourceIPs(req),
+		UserAgent:                req.UserAgent(),
+		RequestReceivedTimestamp: time.Now(),
+	}
+	if user := attributes.GetUser(); user != nil {
+		ev.User = v1.UserInfo{
+			Username: user.GetName(),
+			UID:      user.GetUID(),
+			Groups:   user.GetGroups(),
+			Extra:    user.GetExtra(),
+		}
+	}
+	if info := parseRequestInfo(req.URL.Path); info != nil {
+		ev.ObjectRef = &v1.ObjectReference{
+			Kind:       info.resource.kind,
+			APIVersion: info.resource.groupVersion.String(),
+			Namespace:  info.namespace,
+			Name:       info.name,
+		}
+		ev.Subresource = info.subresource
+	}
+	return ev
+}
+
+// finishAuditEvent completes ev once the request is answered with code.
+func finishAuditEvent(ev *auditv1.Event, code int) {
+	if code == 0 {
+		// 没有显式WriteHeader时net/http按200返回
+		code = http.StatusOK
+	}
+	ev.ResponseCode = int32(code)
+	ev.LatencyMicroseconds = time.Since(ev.RequestReceivedTimestamp).Microseconds()
+}
+
+// sourceIPs returns the addresses in the X-Forwarded-For header of req,
+// followed by the address req came from if it is not one of them.
+func sourceIPs(req *http.Request) []string {
+	var ips []string
+	for _, forwarded := range strings.Split(req.Header.Get("X-Forwarded-For"), ",") {
+		if ip := net.ParseIP(strings.TrimSpace(forwarded)); ip != nil {
+			ips = append(ips, ip.String())
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		for _, existing := range ips {
+			if existing == ip.String() {
+				return ips
+			}
+		}
+		ips = append(ips, ip.String())
+	}
+	return ips
+}
+
+// auditResponseWriter records the status code of the response.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *auditResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher, which watches need.
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,24 @@
+package apiserver
+
+import (
+	"net/http"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/apiserver/request"
+	"github.com/opencarry/carry/pkg/authentication/authenticator"
+)
+
+// WithAuthentication authenticates every request with auth before passing it
+// to handler, with the user in its context. Requests auth does not
+// authenticate are answered with 401 Unauthorized; to let them through as
+// the anonymous user, end auth with an anonymous authenticator.
+func WithAuthentication(handler http.Handler, auth authenticator.Request) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		resp, ok, err := auth.AuthenticateRequest(req)
+		if err != nil || !ok {
+			writeError(w, apierrors.NewUnauthorized(""))
+			return
+		}
+		handler.ServeHTTP(w, req.WithContext(request.WithUser(req.Context(), resp.User)))
+	})
+}
@@ -0,0 +1,125 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/apiserver/request"
+	"github.com/opencarry/carry/pkg/authorization/authorizer"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+// WithAuthorization passes to handler only the requests a allows, the others
+// are answered with 403 Forbidden. The user of the request is taken from its
+// context, see WithAuthentication.
+func WithAuthorization(handler http.Handler, a authorizer.Authorizer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attributes := authorizationAttributes(req)
+		if statusErr := authorize(req.Context(), a, attributes); statusErr != nil {
+			writeError(w, statusErr)
+			return
+		}
+		// 处理请求时可能需要再次授权，例如apply创建对象时
+		handler.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), authorizerKey, a)))
+	})
+}
+
+type authorizerContextKey struct{}
+
+// authorizerKey is the context key of the authorizer of the request.
+var authorizerKey = authorizerContextKey{}
+
+// authorize returns nil if a allows attributes, the error to answer the
+// request with otherwise.
+func authorize(ctx context.Context, a authorizer.Authorizer, attributes authorizer.Attributes) *apierrors.StatusError {
+	decision, reason, err := a.Authorize(ctx, attributes)
+	if decision == authorizer.DecisionAllow {
+		return nil
+	}
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	return forbidden(attributes, reason)
+}
+
+// authorizeCreate checks that the user of req may create the requested
+// object. Requests that create objects without the create verb, like an apply
+// of an object that does not exist, must pass it. Without authorization every
+// request is allowed.
+func authorizeCreate(req *http.Request) *apierrors.StatusError {
+	a, ok := req.Context().Value(authorizerKey).(authorizer.Authorizer)
+	if !ok {
+		return nil
+	}
+	attributes := authorizationAttributes(req)
+	attributes.Verb = "create"
+	return authorize(req.Context(), a, attributes)
+}
+
+// authorizationAttributes returns what the authorizer sees of req.
+func authorizationAttributes(req *http.Request) authorizer.AttributesRecord {
+	attributes := authorizer.AttributesRecord{
+		Verb: strings.ToLower(req.Method),
+		Path: req.URL.Path,
+	}
+	attributes.User, _ = request.UserFrom(req.Context())
+
+	info := parseRequestInfo(req.URL.Path)
+	if info == nil {
+		return attributes
+	}
+	attributes.ResourceRequest = true
+	attributes.APIGroup = info.resource.groupVersion.Group
+	attributes.APIVersion = info.resource.groupVersion.Version
+	attributes.Resource = info.resource.name
+	attributes.Subresource = info.subresource
+	attributes.Namespace = info.namespace
+	attributes.Name = info.name
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case len(info.name) != 0:
+			attributes.Verb = "get"
+		case req.URL.Query().Get("watch") == "true":
+			attributes.Verb = "watch"
+		default:
+			attributes.Verb = "list"
+		}
+	case http.MethodPost:
+		attributes.Verb = "create"
+	case http.MethodPut:
+		attributes.Verb = "update"
+	}
+	return attributes
+}
+
+// forbidden returns the error of a request the authorizer did not allow.
+func forbidden(attributes authorizer.Attributes, reason string) *apierrors.StatusError {
+	username := ""
+	if user := attributes.GetUser(); user != nil {
+		username = user.GetName()
+	}
+	var message string
+	if !attributes.IsResourceRequest() {
+		message = fmt.Sprintf("user %q cannot %s path %q", username, attributes.GetVerb(), attributes.GetPath())
+	} else {
+		resource := attributes.GetResource()
+		if len(attributes.GetSubresource()) != 0 {
+			resource += "/" + attributes.GetSubresource()
+		}
+		message = fmt.Sprintf("user %q cannot %s resource %q in API group %q", username, attributes.GetVerb(), resource, attributes.GetAPIGroup())
+		if len(attributes.GetNamespace()) != 0 {
+			message += fmt.Sprintf(" in the namespace %q", attributes.GetNamespace())
+		} else {
+			message += " at the cluster scope"
+		}
+	}
+	if len(reason) != 0 {
+		message += ": " + reason
+	}
+	groupResource := schema.GroupResource{Group: attributes.GetAPIGroup(), Resource: attributes.GetResource()}
+	return apierrors.NewForbidden(groupResource, attributes.GetName(), fmt.Errorf("%s", message))
+}
@@ -0,0 +1,27 @@
+// Package apiserver serves the API kinds over a RESTful HTTP API backed by a
+// storage.Interface.
+//
+// Namespaced kinds are served under
+//
+//	/apis/{group}/{version}/namespaces/{namespace}/{resource}[/{name}[/{subresource}]]
+//
+// and can be listed and watched across all namespaces under
+// /apis/{group}/{version}/{resource}. Cluster scoped kinds (like nodes,
+// namespaces and clusterroles) are served under
+// /apis/{group}/{version}/{resource}[/{name}[/{subresource}]]. The kinds of
+// the carry.i/v1 group are served under APIPrefix, the roles and bindings of
+// the rbac.carry.i/v1 group under /apis/rbac.carry.i/v1.
+//
+// Creates (pod bindings included), updates and deletes pass through the
+// admission plugins given to New: the mutating plugins run before the object
+// is validated, the validating plugins after.
+//
+// The Server does not check who sends a request. To do so, wrap it with
+// WithAuthorization, then with WithAuthentication. To record the requests in
+// an audit log, wrap it with WithAudit in between, so that forbidden requests
+// are recorded too:
+//
+//	handler := apiserver.WithAuthorization(server, authorizer)
+//	handler = apiserver.WithAudit(handler, policyChecker, auditBackend)
+//	handler = apiserver.WithAuthentication(handler, authenticator)
+package apiserver
@@ -0,0 +1,473 @@
+// Package fieldmanager tracks which manager owns which fields of an object and
+// implements server-side apply on top of it.
+//
+// The fields of an object a manager owns are recorded in its
+// metadata.managed_fields. Create, update and patch requests take ownership
+// of the fields they change. An apply request sends the fields its manager
+// wants to own with the values it wants them to have: the fields are merged
+// into the object, fields the manager applied before and no longer sends are
+// removed, and changing a field owned by another manager is a conflict unless
+// the request is forced.
+//
+// Only the labels, annotations and owner references of the metadata are
+// managed, the status of an object never is.
+package fieldmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/util/strategicpatch"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+// managedMetadataFields are the fields of the metadata owned by managers, the
+// others are set by the server.
+var managedMetadataFields = []string{"labels", "annotations", "owner_references"}
+
+// fieldValue is a managed field of an object in its decoded JSON form.
+type fieldValue struct {
+	value interface{}
+	// element is set for elements of lists merged by key. Only the presence
+	// of an element is owned, its content is made of fields of its own.
+	element bool
+	// remove removes the field from the object.
+	remove func()
+}
+
+// Update records the fields that differ between liveObj and newObj as owned
+// by manager, other managers lose them. liveObj is nil when newObj is being
+// created. The managed fields of newObj are replaced by the result, whatever
+// the client sent.
+func Update(liveObj, newObj runtime.Object, manager string) error {
+	newMeta, err := v1.Accessor(newObj)
+	if err != nil {
+		return err
+	}
+	newFields, err := managedFieldsOf(newObj)
+	if err != nil {
+		return err
+	}
+	liveFields := map[string]fieldValue{}
+	var managed []v1.ManagedFieldsEntry
+	if liveObj != nil {
+		liveMeta, err := v1.Accessor(liveObj)
+		if err != nil {
+			return err
+		}
+		if liveFields, err = managedFieldsOf(liveObj); err != nil {
+			return err
+		}
+		managed = liveMeta.GetManagedFields()
+	}
+
+	changed := map[string]bool{}
+	for path, f := range newFields {
+		live, ok := liveFields[path]
+		if !ok || (!f.element && !reflect.DeepEqual(live.value, f.value)) {
+			changed[path] = true
+		}
+	}
+	gone := map[string]bool{}
+	for path := range liveFields {
+		if _, ok := newFields[path]; !ok {
+			gone[path] = true
+		}
+	}
+
+	var result []v1.ManagedFieldsEntry
+	found := false
+	for _, entry := range managed {
+		entry = *entry.DeepCopy()
+		isOwn := entry.Manager == manager && entry.Operation == v1.ManagedFieldsOperationUpdate
+		var fields []string
+		for _, path := range entry.Fields {
+			if gone[path] || (changed[path] && !isOwn) {
+				continue
+			}
+			fields = append(fields, path)
+		}
+		if isOwn {
+			found = true
+			fields = union(fields, changed)
+			if len(changed) != 0 {
+				entry.Time = time.Now()
+			}
+		}
+		entry.Fields = fields
+		if len(entry.Fields) != 0 {
+			result = append(result, entry)
+		}
+	}
+	if !found && len(changed) != 0 {
+		result = append(result, v1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  v1.ManagedFieldsOperationUpdate,
+			APIVersion: apiVersion(newObj),
+			Time:       time.Now(),
+			Fields:     union(nil, changed),
+		})
+	}
+	newMeta.SetManagedFields(result)
+	return nil
+}
+
+// Apply merges the apply configuration, a partial object in JSON or YAML,
+// into liveObj on behalf of manager and returns the result. liveObj is an
+// empty object of the kind when the object does not exist yet. Changing
+// fields owned by other managers is a conflict, returned as a
+// *apierrors.StatusError, unless force is set; then manager takes them over.
+func Apply(liveObj runtime.Object, config []byte, manager string, force bool) (runtime.Object, error) {
+	t := reflect.TypeOf(liveObj).Elem()
+	configJSON, err := yaml.YAMLToJSON(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apply configuration: %v", err)
+	}
+	// the configuration must be a valid object, but fields it does not set
+	// must not be filled in with zero values
+	decoder := json.NewDecoder(bytes.NewReader(configJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(reflect.New(t).Interface()); err != nil {
+		return nil, fmt.Errorf("invalid apply configuration: %v", err)
+	}
+	configMap, err := decodeMap(configJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apply configuration: %v", err)
+	}
+	if metadata, ok := configMap["metadata"].(map[string]interface{}); ok {
+		if _, ok := metadata["managed_fields"]; ok {
+			return nil, fmt.Errorf("metadata.managed_fields must not be set in an apply configuration")
+		}
+	}
+
+	liveMeta, err := v1.Accessor(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	managed := liveMeta.GetManagedFields()
+	liveMap, err := toMap(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	liveFields := flatten(liveMap, t)
+	configFields := flatten(configMap, t)
+	isOwn := func(entry v1.ManagedFieldsEntry) bool {
+		return entry.Manager == manager && entry.Operation == v1.ManagedFieldsOperationApply
+	}
+
+	// a field owned by another manager may only be applied with its current value
+	conflicts := map[string]bool{}
+	var causes []v1.StatusCause
+	for _, path := range sortedPaths(configFields) {
+		f := configFields[path]
+		if live, ok := liveFields[path]; f.element || (ok && reflect.DeepEqual(live.value, f.value)) {
+			continue
+		}
+		for _, entry := range managed {
+			if isOwn(entry) || !contains(entry.Fields, path) {
+				continue
+			}
+			conflicts[path] = true
+			causes = append(causes, v1.StatusCause{
+				Type:    v1.CauseTypeFieldManagerConflict,
+				Message: fmt.Sprintf("conflict with %q", entry.Manager),
+				Field:   path,
+			})
+		}
+	}
+	if len(causes) != 0 && !force {
+		messages := make([]string, 0, len(causes))
+		for _, cause := range causes {
+			messages = append(messages, fmt.Sprintf("%s: %s", cause.Message, cause.Field))
+		}
+		return nil, apierrors.NewApplyConflict(causes, fmt.Sprintf("apply failed with %d conflicts: %s", len(causes), strings.Join(messages, ", ")))
+	}
+
+	// fields applied before and left out now are removed, unless another
+	// manager owns them or, for list elements, anything inside them
+	for _, entry := range managed {
+		if !isOwn(entry) {
+			continue
+		}
+		for _, path := range entry.Fields {
+			if _, ok := configFields[path]; ok {
+				continue
+			}
+			live, ok := liveFields[path]
+			if !ok || ownedByOthers(managed, path, isOwn) {
+				continue
+			}
+			live.remove()
+		}
+	}
+
+	merged, err := strategicpatch.StrategicMergeMapPatch(liveMap, configMap, liveObj)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	out := reflect.New(t).Interface().(runtime.Object)
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+
+	var result []v1.ManagedFieldsEntry
+	for _, entry := range managed {
+		if isOwn(entry) {
+			continue
+		}
+		entry = *entry.DeepCopy()
+		if force {
+			var fields []string
+			for _, path := range entry.Fields {
+				if !conflicts[path] {
+					fields = append(fields, path)
+				}
+			}
+			entry.Fields = fields
+		}
+		if len(entry.Fields) != 0 {
+			result = append(result, entry)
+		}
+	}
+	if len(configFields) != 0 {
+		result = append(result, v1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  v1.ManagedFieldsOperationApply,
+			APIVersion: apiVersion(liveObj),
+			Time:       time.Now(),
+			Fields:     sortedPaths(configFields),
+		})
+	}
+	outMeta, err := v1.Accessor(out)
+	if err != nil {
+		return nil, err
+	}
+	outMeta.SetManagedFields(result)
+	return out, nil
+}
+
+// ownedByOthers reports whether a manager other than the ones matched by
+// isOwn owns path or a field inside it.
+func ownedByOthers(managed []v1.ManagedFieldsEntry, path string, isOwn func(v1.ManagedFieldsEntry) bool) bool {
+	for _, entry := range managed {
+		if isOwn(entry) {
+			continue
+		}
+		for _, owned := range entry.Fields {
+			if owned == path || strings.HasPrefix(owned, path+".") || strings.HasPrefix(owned, path+"[") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apiVersion returns the group version the kind of obj is registered in.
+func apiVersion(obj runtime.Object) string {
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return v1.SchemeGroupVersion.String()
+	}
+	return kinds[0].GroupVersion().String()
+}
+
+func managedFieldsOf(obj runtime.Object) (map[string]fieldValue, error) {
+	m, err := toMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	return flatten(m, reflect.TypeOf(obj).Elem()), nil
+}
+
+func toMap(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMap(data)
+}
+
+func decodeMap(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	m := map[string]interface{}{}
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// flatten returns the managed fields of the decoded object obj of type t,
+// indexed by their path.
+func flatten(obj map[string]interface{}, t reflect.Type) map[string]fieldValue {
+	out := map[string]fieldValue{}
+	for k, v := range obj {
+		switch k {
+		case "kind", "api_version", "status":
+			continue
+		case "metadata":
+			metadata, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			metaType, _, _ := strategicpatch.LookupPatchMetadata(t, k)
+			for _, name := range managedMetadataFields {
+				if value, ok := metadata[name]; ok {
+					walk(metadata, name, value, metaType, field.NewPath(k), out)
+				}
+			}
+			continue
+		}
+		walk(obj, k, v, t, nil, out)
+	}
+	return out
+}
+
+// walk adds the field key of parent, of type parentType at parentPath, and
+// the fields inside it to out.
+func walk(parent map[string]interface{}, key string, value interface{}, parentType reflect.Type, parentPath *field.Path, out map[string]fieldValue) {
+	if value == nil {
+		return
+	}
+	t, strategy, mergeKey := strategicpatch.LookupPatchMetadata(parentType, key)
+	var path *field.Path
+	switch {
+	case parentPath == nil:
+		path = field.NewPath(key)
+	case isMap(parentType):
+		path = parentPath.Key(key)
+	default:
+		path = parentPath.Child(key)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) != 0 {
+			for k, child := range v {
+				walk(v, k, child, t, path, out)
+			}
+			return
+		}
+	case []interface{}:
+		if strategy == "merge" && len(mergeKey) != 0 {
+			for _, e := range v {
+				element, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				elementKey := element[mergeKey]
+				elementPath := path.Key(fmt.Sprint(elementKey))
+				out[elementPath.String()] = fieldValue{
+					value:   element,
+					element: true,
+					remove: func() {
+						list, _ := parent[key].([]interface{})
+						var kept []interface{}
+						for _, e := range list {
+							if m, ok := e.(map[string]interface{}); ok && reflect.DeepEqual(m[mergeKey], elementKey) {
+								continue
+							}
+							kept = append(kept, e)
+						}
+						parent[key] = kept
+					},
+				}
+				elementType := elem(t)
+				if elementType != nil {
+					elementType = elementType.Elem()
+				}
+				for k, child := range element {
+					walk(element, k, child, elementType, elementPath, out)
+				}
+			}
+			return
+		}
+	}
+	if isZero(value, t) {
+		// fields without omitempty are encoded even when they are not set
+		return
+	}
+	out[path.String()] = fieldValue{
+		value:  value,
+		remove: func() { delete(parent, key) },
+	}
+}
+
+// isZero reports whether value is the encoded zero value of t.
+func isZero(value interface{}, t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	data, err := json.Marshal(reflect.Zero(t).Interface())
+	if err != nil {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var zero interface{}
+	if err := decoder.Decode(&zero); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(value, zero)
+}
+
+func elem(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isMap(t reflect.Type) bool {
+	t = elem(t)
+	return t != nil && t.Kind() == reflect.Map
+}
+
+func sortedPaths(fields map[string]fieldValue) []string {
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// union returns the sorted union of list and the keys of set.
+func union(list []string, set map[string]bool) []string {
+	all := map[string]bool{}
+	for _, v := range list {
+		all[v] = true
+	}
+	for v := range set {
+		all[v] = true
+	}
+	result := make([]string, 0, len(all))
+	for v := range all {
+		result = append(result, v)
+	}
+	sort.Strings(result)
+	return result
+}
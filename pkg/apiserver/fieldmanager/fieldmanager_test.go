@@ -0,0 +1,229 @@
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+const deploymentConfig = `
+kind: deployment
+api_version: carry.i/v1
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:1
+      - name: sidecar
+        image: sidecar:1
+`
+
+func fieldsOf(obj *v1.Deployment, manager string, operation v1.ManagedFieldsOperationType) []string {
+	for _, entry := range obj.ManagedFields {
+		if entry.Manager == manager && entry.Operation == operation {
+			return entry.Fields
+		}
+	}
+	return nil
+}
+
+func apply(t *testing.T, live *v1.Deployment, config, manager string, force bool) (*v1.Deployment, error) {
+	t.Helper()
+	obj, err := Apply(live, []byte(config), manager, force)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*v1.Deployment), nil
+}
+
+func TestApply(t *testing.T) {
+	deployment, err := apply(t, &v1.Deployment{}, deploymentConfig, "ci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *deployment.Spec.Replicas != 3 || len(deployment.Spec.Template.Spec.Containers) != 2 {
+		t.Fatalf("unexpected applied deployment: %#v", deployment.Spec)
+	}
+	expected := []string{
+		"metadata.labels[app]",
+		"spec.replicas",
+		"spec.template.spec.containers[sidecar]",
+		"spec.template.spec.containers[sidecar].image",
+		"spec.template.spec.containers[sidecar].name",
+		"spec.template.spec.containers[web]",
+		"spec.template.spec.containers[web].image",
+		"spec.template.spec.containers[web].name",
+	}
+	if got := fieldsOf(deployment, "ci", v1.ManagedFieldsOperationApply); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected ci to own %v, got %v", expected, got)
+	}
+
+	// applying again with the same manager is not a conflict
+	if _, err := apply(t, deployment, deploymentConfig, "ci", false); err != nil {
+		t.Errorf("unexpected error re-applying: %v", err)
+	}
+
+	// sre changes the replicas with an update and takes them over
+	updated := deployment.DeepCopy()
+	replicas := int64(5)
+	updated.Spec.Replicas = &replicas
+	if err := Update(deployment, updated, "sre"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fieldsOf(updated, "sre", v1.ManagedFieldsOperationUpdate); !reflect.DeepEqual(got, []string{"spec.replicas"}) {
+		t.Errorf("expected sre to own spec.replicas, got %v", got)
+	}
+	if got := fieldsOf(updated, "ci", v1.ManagedFieldsOperationApply); contains(got, "spec.replicas") {
+		t.Errorf("expected ci to lose spec.replicas, got %v", got)
+	}
+
+	// ci applying a different number of replicas now conflicts
+	_, err = apply(t, updated, deploymentConfig, "ci", false)
+	if !apierrors.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	cause, ok := apierrors.StatusCause(err, v1.CauseTypeFieldManagerConflict)
+	if !ok || cause.Field != "spec.replicas" || cause.Message != `conflict with "sre"` {
+		t.Errorf("unexpected conflict cause %#v", cause)
+	}
+
+	// ci dropping the replicas from its configuration only bumps the image
+	bump := `
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:2
+      - name: sidecar
+        image: sidecar:1
+`
+	bumped, err := apply(t, updated, bump, "ci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *bumped.Spec.Replicas != 5 || bumped.Spec.Template.Spec.Containers[0].Image != "web:2" {
+		t.Errorf("unexpected deployment after the image bump: %#v", bumped.Spec)
+	}
+
+	// forcing takes the conflicting fields over
+	forced, err := apply(t, updated, deploymentConfig, "ci", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *forced.Spec.Replicas != 3 {
+		t.Errorf("expected the forced apply to set the replicas, got %d", *forced.Spec.Replicas)
+	}
+	if got := fieldsOf(forced, "sre", v1.ManagedFieldsOperationUpdate); got != nil {
+		t.Errorf("expected sre to lose all its fields, got %v", got)
+	}
+}
+
+func TestApplyRemovesFields(t *testing.T) {
+	deployment, err := apply(t, &v1.Deployment{}, deploymentConfig, "ci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// another manager adds a label, which ci must not remove
+	updated := deployment.DeepCopy()
+	updated.Labels["team"] = "sre"
+	if err := Update(deployment, updated, "sre"); err != nil {
+		t.Fatal(err)
+	}
+
+	config := `
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:1
+`
+	applied, err := apply(t, updated, config, "ci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied.Spec.Template.Spec.Containers) != 1 || applied.Spec.Template.Spec.Containers[0].Name != "web" {
+		t.Errorf("expected the sidecar to be removed, got %#v", applied.Spec.Template.Spec.Containers)
+	}
+	if applied.Spec.Replicas != nil {
+		t.Errorf("expected the replicas to be removed, got %d", *applied.Spec.Replicas)
+	}
+	if !reflect.DeepEqual(applied.Labels, map[string]string{"team": "sre"}) {
+		t.Errorf("expected only the label of sre to be left, got %v", applied.Labels)
+	}
+}
+
+func TestApplySharedOwnership(t *testing.T) {
+	deployment, err := apply(t, &v1.Deployment{}, deploymentConfig, "ci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same values applied by another manager are owned by both
+	shared, err := apply(t, deployment, deploymentConfig, "gitops", false)
+	if err != nil {
+		t.Fatalf("expected no conflict applying the same values, got %v", err)
+	}
+	if !contains(fieldsOf(shared, "ci", v1.ManagedFieldsOperationApply), "spec.replicas") ||
+		!contains(fieldsOf(shared, "gitops", v1.ManagedFieldsOperationApply), "spec.replicas") {
+		t.Errorf("expected spec.replicas to be shared, got %#v", shared.ManagedFields)
+	}
+}
+
+func TestApplyInvalidConfiguration(t *testing.T) {
+	for _, config := range []string{
+		`spec: {unknown_field: 1}`,
+		`spec: {replicas: "three"}`,
+		`metadata: {name: web, managed_fields: []}`,
+		`: -`,
+	} {
+		if _, err := Apply(&v1.Deployment{}, []byte(config), "ci", false); err == nil {
+			t.Errorf("expected an error for %q", config)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	deployment := &v1.Deployment{ObjectMeta: v1.ObjectMeta{Name: "web", Labels: map[string]string{"app": "web"}}}
+	if err := Update(nil, deployment, "kubectl"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fieldsOf(deployment, "kubectl", v1.ManagedFieldsOperationUpdate); !reflect.DeepEqual(got, []string{"metadata.labels[app]"}) {
+		t.Errorf("unexpected fields on create: %v", got)
+	}
+
+	// removed fields are not owned by anybody any more
+	updated := deployment.DeepCopy()
+	updated.Labels = nil
+	updated.ManagedFields = nil
+	if err := Update(deployment, updated, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.ManagedFields) != 0 {
+		t.Errorf("expected no managed fields, got %#v", updated.ManagedFields)
+	}
+
+	// the managed fields sent by a client are ignored
+	updated = deployment.DeepCopy()
+	updated.ManagedFields = []v1.ManagedFieldsEntry{{Manager: "forged", Fields: []string{"metadata.labels[app]"}}}
+	if err := Update(deployment, updated, "kubectl"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(updated.ManagedFields, deployment.ManagedFields) {
+		t.Errorf("expected the managed fields to be unchanged, got %#v", updated.ManagedFields)
+	}
+}
@@ -0,0 +1,530 @@
+package apiserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/apiserver/fieldmanager"
+	"github.com/opencarry/carry/pkg/audit"
+	"github.com/opencarry/carry/pkg/fields"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/names"
+	"github.com/opencarry/carry/pkg/types"
+	"github.com/opencarry/carry/pkg/util/jsonpatch"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// maxRequestBodyBytes is the limit on the size of a request body, 3MB like
+// the limit etcd puts on a single value.
+const maxRequestBodyBytes = 3 * 1024 * 1024
+
+func readBody(req *http.Request) ([]byte, *apierrors.StatusError) {
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxRequestBodyBytes+1))
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("failed to read the request body: %v", err))
+	}
+	if len(data) > maxRequestBodyBytes {
+		return nil, apierrors.NewRequestEntityTooLargeError(
+			fmt.Sprintf("the request body must not be larger than %d bytes", maxRequestBodyBytes))
+	}
+	return data, nil
+}
+
+// decodeBody decodes the request body into a defaulted object of the kind of
+// into.
+func decodeBody(req *http.Request, into runtime.Object) (runtime.Object, *apierrors.StatusError) {
+	if contentType := req.Header.Get("Content-Type"); len(contentType) != 0 {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, apierrors.NewUnsupportedMediaType(contentType)
+		}
+		if _, ok := runtime.SerializerInfoForMediaType(scheme.Codecs.SupportedMediaTypes(), mediaType); !ok {
+			return nil, apierrors.NewUnsupportedMediaType(contentType)
+		}
+	}
+	data, statusErr := readBody(req)
+	if statusErr != nil {
+		return nil, statusErr
+	}
+	obj, _, err := scheme.Codecs.UniversalDecoder().Decode(data, nil, into)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("failed to decode the request body: %v", err))
+	}
+	if reflect.TypeOf(obj) != reflect.TypeOf(into) {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an object of type %T in the request body, got %T", into, obj))
+	}
+	audit.LogRequestObject(req.Context(), obj)
+	return obj, nil
+}
+
+// checkObjectMeta makes sure the namespace and name of obj match the ones of
+// the request. An empty namespace is set to the one of the request.
+func checkObjectMeta(info *requestInfo, obj runtime.Object) (v1.Object, *apierrors.StatusError) {
+	metadata, err := v1.Accessor(obj)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if info.resource.namespaced {
+		if len(metadata.GetNamespace()) == 0 {
+			metadata.SetNamespace(info.namespace)
+		} else if metadata.GetNamespace() != info.namespace {
+			return nil, apierrors.NewBadRequest("the namespace of the provided object does not match the namespace sent on the request")
+		}
+	}
+	if len(info.name) != 0 && metadata.GetName() != info.name {
+		return nil, apierrors.NewBadRequest("the name of the object does not match the name on the url")
+	}
+	return metadata, nil
+}
+
+// maxFieldManagerLength is the limit on the length of the name of a field
+// manager.
+const maxFieldManagerLength = 128
+
+// fieldManager returns the name of the manager of the fields a request sets:
+// the field_manager parameter, or the program name of the user agent.
+func fieldManager(req *http.Request) (string, *apierrors.StatusError) {
+	manager := req.URL.Query().Get("field_manager")
+	if len(manager) == 0 {
+		manager = strings.Split(req.UserAgent(), "/")[0]
+	}
+	if len(manager) == 0 {
+		manager = "unknown"
+	}
+	if len(manager) > maxFieldManagerLength {
+		return "", apierrors.NewBadRequest(fmt.Sprintf("field_manager must not be longer than %d characters", maxFieldManagerLength))
+	}
+	return manager, nil
+}
+
+func (s *Server) get(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	obj := info.resource.newFunc()
+	if err := s.storage.Get(req.Context(), info.key(), storage.GetOptions{}, obj); err != nil {
+		writeError(w, storageError(err, info.resource.groupResource(), info.name))
+		return
+	}
+	writeObject(w, req, http.StatusOK, obj)
+}
+
+// listOptions parses the label and field selectors and the paging parameters
+// of a list or watch request.
+func listOptions(req *http.Request, info *requestInfo) (storage.ListOptions, *apierrors.StatusError) {
+	query := req.URL.Query()
+	opts := storage.ListOptions{
+		ResourceVersion: query.Get("resource_version"),
+		Continue:        query.Get("continue"),
+		Predicate: storage.SelectionPredicate{
+			Label:    labels.Everything(),
+			Field:    fields.Everything(),
+			GetAttrs: storage.AttrFuncForKind(info.resource.kind),
+		},
+		AllowWatchBookmarks: query.Get("allow_watch_bookmarks") == "true",
+	}
+	if s := query.Get("label_selector"); len(s) != 0 {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return opts, apierrors.NewBadRequest(fmt.Sprintf("invalid label_selector: %v", err))
+		}
+		opts.Predicate.Label = selector
+	}
+	if s := query.Get("field_selector"); len(s) != 0 {
+		selector, err := fields.ParseSelector(s)
+		if err != nil {
+			return opts, apierrors.NewBadRequest(fmt.Sprintf("invalid field_selector: %v", err))
+		}
+		if err := v1.ValidateFieldSelector(info.resource.kind, selector); err != nil {
+			return opts, apierrors.NewBadRequest(err.Error())
+		}
+		opts.Predicate.Field = selector
+	}
+	if s := query.Get("limit"); len(s) != 0 {
+		limit, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || limit < 0 {
+			return opts, apierrors.NewBadRequest(fmt.Sprintf("invalid limit %q", s))
+		}
+		opts.Limit = limit
+	}
+	return opts, nil
+}
+
+func (s *Server) list(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	opts, statusErr := listOptions(req, info)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	list := info.resource.newListFunc()
+	if err := s.storage.List(req.Context(), info.keyPrefix(), opts, list); err != nil {
+		if storage.IsInvalidObj(err) {
+			// a continue token that is not valid
+			writeError(w, apierrors.NewBadRequest(err.(*storage.StorageError).AdditionalErrorMsg))
+			return
+		}
+		writeError(w, storageError(err, info.resource.groupResource(), ""))
+		return
+	}
+	writeObject(w, req, http.StatusOK, list)
+}
+
+func (s *Server) create(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	if info.resource.namespaced && len(info.namespace) == 0 {
+		writeError(w, apierrors.NewMethodNotSupported(info.resource.groupResource(), strings.ToLower(req.Method)))
+		return
+	}
+	manager, statusErr := fieldManager(req)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	obj, statusErr := decodeBody(req, info.resource.newFunc())
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	if err := fieldmanager.Update(nil, obj, manager); err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	s.createObject(w, req, info, obj)
+}
+
+// createObject validates and stores the new object obj.
+func (s *Server) createObject(w http.ResponseWriter, req *http.Request, info *requestInfo, obj runtime.Object) {
+	metadata, statusErr := checkObjectMeta(info, obj)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	if len(metadata.GetResourceVersion()) != 0 {
+		writeError(w, apierrors.NewBadRequest("resource_version should not be set on objects to be created"))
+		return
+	}
+
+	attributes := admissionAttributes(req, info, admission.Create, obj, nil)
+	if statusErr := s.admit(req, attributes); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	// admission may not move the object
+	if _, statusErr := checkObjectMeta(info, obj); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+
+	name := metadata.GetName()
+	if len(name) == 0 {
+		name = metadata.GetGenerateName()
+	}
+	if errs := info.resource.validate(obj); len(errs) != 0 {
+		writeError(w, apierrors.NewInvalid(info.resource.groupKind(), name, errs))
+		return
+	}
+	if statusErr := s.validateAdmission(req, attributes); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+
+	out := info.resource.newFunc()
+	keyFunc := func(name string) string {
+		return storage.Key(info.resource.kind, info.namespace, name)
+	}
+	if err := names.Create(req.Context(), s.storage, keyFunc, info.resource.nameGenerator, obj, out); err != nil {
+		writeError(w, storageError(err, info.resource.groupResource(), metadata.GetName()))
+		return
+	}
+	writeObject(w, req, http.StatusCreated, out)
+}
+
+// updateFunc returns the new state of the object given its current state.
+type updateFunc func(existing runtime.Object) (runtime.Object, *apierrors.StatusError)
+
+// updateObject updates the requested object or its status to the state
+// returned by newObject, which may be called more than once. manager takes
+// ownership of the fields newObject changes; it is empty when newObject
+// records the owners itself.
+func (s *Server) updateObject(w http.ResponseWriter, req *http.Request, info *requestInfo, manager string, newObject updateFunc) {
+	out := info.resource.newFunc()
+	err := s.storage.GuaranteedUpdate(req.Context(), info.key(), out, false, nil, func(existing runtime.Object) (runtime.Object, error) {
+		obj, statusErr := newObject(existing)
+		if statusErr != nil {
+			return nil, statusErr
+		}
+		if _, statusErr := checkObjectMeta(info, obj); statusErr != nil {
+			return nil, statusErr
+		}
+		fillSystemFields(obj, existing)
+
+		var errs field.ErrorList
+		if info.subresource == "status" {
+			// only the status is updated
+			updated := existing.DeepCopyObject()
+			copyStatus(updated, obj)
+			objMeta, _ := v1.Accessor(obj)
+			updatedMeta, _ := v1.Accessor(updated)
+			updatedMeta.SetResourceVersion(objMeta.GetResourceVersion())
+			obj = updated
+		} else {
+			// the status is only updated through the status subresource
+			copyStatus(obj, existing)
+			if len(manager) != 0 {
+				if err := fieldmanager.Update(existing, obj, manager); err != nil {
+					return nil, apierrors.NewInternalError(err)
+				}
+			}
+		}
+
+		attributes := admissionAttributes(req, info, admission.Update, obj, existing)
+		if statusErr := s.admit(req, attributes); statusErr != nil {
+			return nil, statusErr
+		}
+		if _, statusErr := checkObjectMeta(info, obj); statusErr != nil {
+			return nil, statusErr
+		}
+		if info.subresource == "status" {
+			errs = info.resource.validateStatusUpdate(obj, existing)
+		} else {
+			errs = info.resource.validateUpdate(obj, existing)
+		}
+		if len(errs) != 0 {
+			return nil, apierrors.NewInvalid(info.resource.groupKind(), info.name, errs)
+		}
+		if statusErr := s.validateAdmission(req, attributes); statusErr != nil {
+			return nil, statusErr
+		}
+		return obj, nil
+	})
+	if err != nil {
+		writeError(w, storageError(err, info.resource.groupResource(), info.name))
+		return
+	}
+	writeObject(w, req, http.StatusOK, out)
+}
+
+// fillSystemFields sets the fields of obj owned by the server that the client
+// left empty, or sent back unchanged, to their values in existing.
+func fillSystemFields(obj, existing runtime.Object) {
+	objMeta, _ := v1.Accessor(obj)
+	existingMeta, _ := v1.Accessor(existing)
+	if len(objMeta.GetUID()) == 0 {
+		objMeta.SetUID(existingMeta.GetUID())
+	}
+	// times lose their monotonic clock reading and location on the wire
+	if objMeta.GetCreationTime().IsZero() || objMeta.GetCreationTime().Equal(existingMeta.GetCreationTime()) {
+		objMeta.SetCreationTime(existingMeta.GetCreationTime())
+	}
+	if objMeta.GetDeletionTime().Equal(existingMeta.GetDeletionTime()) {
+		objMeta.SetDeletionTime(existingMeta.GetDeletionTime())
+	}
+	objMeta.SetGeneration(existingMeta.GetGeneration())
+}
+
+func (s *Server) update(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	manager, statusErr := fieldManager(req)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	obj, statusErr := decodeBody(req, info.resource.newFunc())
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	if _, statusErr := checkObjectMeta(info, obj); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	s.updateObject(w, req, info, manager, func(existing runtime.Object) (runtime.Object, *apierrors.StatusError) {
+		return obj.DeepCopyObject(), nil
+	})
+}
+
+func (s *Server) patch(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	contentType := req.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	patchType := types.PatchType(mediaType)
+	if err != nil || !supportedPatchTypes[patchType] {
+		writeError(w, apierrors.NewUnsupportedMediaType(contentType))
+		return
+	}
+	patch, statusErr := readBody(req)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	audit.LogRequestPatch(req.Context(), patch)
+	if patchType == types.ApplyPatchType {
+		s.apply(w, req, info, patch)
+		return
+	}
+	manager, statusErr := fieldManager(req)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	s.updateObject(w, req, info, manager, func(existing runtime.Object) (runtime.Object, *apierrors.StatusError) {
+		obj := info.resource.newFunc()
+		if err := applyPatch(patchType, existing, patch, obj); err != nil {
+			if errors.Is(err, jsonpatch.ErrTestFailed) {
+				return nil, apierrors.NewConflict(info.resource.groupResource(), info.name, err)
+			}
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("failed to apply the patch: %v", err))
+		}
+		return obj, nil
+	})
+}
+
+// apply applies the configuration in the request body on behalf of the
+// field manager of the request, creating the object if it does not exist.
+func (s *Server) apply(w http.ResponseWriter, req *http.Request, info *requestInfo, config []byte) {
+	if len(info.subresource) != 0 {
+		writeError(w, apierrors.NewMethodNotSupported(info.resource.groupResource(), "apply of "+info.subresource))
+		return
+	}
+	query := req.URL.Query()
+	manager := query.Get("field_manager")
+	if len(manager) == 0 {
+		writeError(w, apierrors.NewBadRequest("field_manager is required for apply requests"))
+		return
+	}
+	if _, statusErr := fieldManager(req); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	force := query.Get("force") == "true"
+	applyTo := func(existing runtime.Object) (runtime.Object, *apierrors.StatusError) {
+		obj, err := fieldmanager.Apply(existing, config, manager, force)
+		if err != nil {
+			if statusErr, ok := err.(*apierrors.StatusError); ok {
+				return nil, statusErr
+			}
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		scheme.Scheme.Default(obj)
+		return obj, nil
+	}
+
+	err := s.storage.Get(req.Context(), info.key(), storage.GetOptions{}, info.resource.newFunc())
+	if storage.IsNotFound(err) {
+		// the request was authorized as a patch, creating needs the create verb
+		if statusErr := authorizeCreate(req); statusErr != nil {
+			writeError(w, statusErr)
+			return
+		}
+		obj, statusErr := applyTo(info.resource.newFunc())
+		if statusErr != nil {
+			writeError(w, statusErr)
+			return
+		}
+		s.createObject(w, req, info, obj)
+		return
+	}
+	if err != nil {
+		writeError(w, storageError(err, info.resource.groupResource(), info.name))
+		return
+	}
+	s.updateObject(w, req, info, "", applyTo)
+}
+
+func (s *Server) delete(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	var preconditions *storage.Preconditions
+	if s.admissionControl != nil && s.admissionControl.Handles(admission.Delete) {
+		existing := info.resource.newFunc()
+		if err := s.storage.Get(req.Context(), info.key(), storage.GetOptions{}, existing); err != nil {
+			writeError(w, storageError(err, info.resource.groupResource(), info.name))
+			return
+		}
+		if statusErr := s.validateAdmission(req, admissionAttributes(req, info, admission.Delete, nil, existing)); statusErr != nil {
+			writeError(w, statusErr)
+			return
+		}
+		// the object admitted is the object deleted
+		metadata, _ := v1.Accessor(existing)
+		resourceVersion := metadata.GetResourceVersion()
+		preconditions = &storage.Preconditions{ResourceVersion: &resourceVersion}
+	}
+
+	out := info.resource.newFunc()
+	if err := s.storage.Delete(req.Context(), info.key(), out, preconditions); err != nil {
+		writeError(w, storageError(err, info.resource.groupResource(), info.name))
+		return
+	}
+	writeObject(w, req, http.StatusOK, out)
+}
+
+// bind assigns a pod to the host of the binding in the request body.
+func (s *Server) bind(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	obj, statusErr := decodeBody(req, &v1.Binding{})
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	binding := obj.(*v1.Binding)
+	if len(binding.Name) == 0 {
+		binding.Name = info.name
+	}
+	if _, statusErr := checkObjectMeta(info, binding); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+
+	attributes := admissionAttributes(req, info, admission.Create, binding, nil)
+	if statusErr := s.admit(req, attributes); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	// admission may not move the binding
+	if _, statusErr := checkObjectMeta(info, binding); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	if len(binding.PodID) != 0 && binding.PodID != info.name {
+		writeError(w, apierrors.NewBadRequest("the pod_id of the binding does not match the name on the url"))
+		return
+	}
+	if len(binding.Host) == 0 {
+		writeError(w, apierrors.NewInvalid(v1.Kind("binding"), info.name, field.ErrorList{field.Required(field.NewPath("host"), "")}))
+		return
+	}
+	if statusErr := s.validateAdmission(req, attributes); statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+
+	err := s.storage.GuaranteedUpdate(req.Context(), info.key(), &v1.Pod{}, false, nil, func(existing runtime.Object) (runtime.Object, error) {
+		pod := existing.(*v1.Pod)
+		if !pod.DeletionTime.IsZero() {
+			return nil, apierrors.NewConflict(v1.Resource("pods"), info.name, fmt.Errorf("pod is being deleted, cannot be assigned to a host"))
+		}
+		if len(pod.Spec.NodeName) != 0 {
+			return nil, apierrors.NewConflict(v1.Resource("pods"), info.name, fmt.Errorf("pod is already assigned to node %q", pod.Spec.NodeName))
+		}
+		pod.Spec.NodeName = binding.Host
+		return pod, nil
+	})
+	if err != nil {
+		writeError(w, storageError(err, v1.Resource("pods"), info.name))
+		return
+	}
+	writeSuccess(w, http.StatusCreated)
+}
+
+// writeSuccess writes a status telling the request succeeded, for requests
+// that do not return an object.
+func writeSuccess(w http.ResponseWriter, code int) {
+	writeStatus(w, &v1.Status{
+		Status: v1.StatusSuccess,
+		Code:   int32(code),
+	})
+}
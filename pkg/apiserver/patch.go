@@ -0,0 +1,50 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/types"
+	"github.com/opencarry/carry/pkg/util/jsonpatch"
+	"github.com/opencarry/carry/pkg/util/strategicpatch"
+)
+
+// supportedPatchTypes are the content types accepted by the PATCH verb.
+var supportedPatchTypes = map[types.PatchType]bool{
+	types.JSONPatchType:           true,
+	types.MergePatchType:          true,
+	types.StrategicMergePatchType: true,
+	types.ApplyPatchType:          true,
+}
+
+// applyPatch applies the patch of patchType to obj and decodes the result
+// into out, an empty object of the kind of obj.
+func applyPatch(patchType types.PatchType, obj runtime.Object, patch []byte, out runtime.Object) error {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var patched []byte
+	switch patchType {
+	case types.JSONPatchType:
+		p, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return err
+		}
+		if patched, err = p.Apply(original); err != nil {
+			return err
+		}
+	case types.MergePatchType:
+		if patched, err = jsonpatch.MergePatch(original, patch); err != nil {
+			return err
+		}
+	case types.StrategicMergePatchType:
+		if patched, err = strategicpatch.StrategicMergePatch(original, patch, out); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported patch type %q", patchType)
+	}
+	return json.Unmarshal(patched, out)
+}
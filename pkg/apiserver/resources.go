@@ -0,0 +1,292 @@
+package apiserver
+
+import (
+	"reflect"
+
+	"github.com/opencarry/carry/pkg/api/validation"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/storage/names"
+	"github.com/opencarry/carry/pkg/util/validation/field"
+)
+
+// resource describes how a kind is served.
+type resource struct {
+	// groupVersion is the api group and version the resource is served in,
+	// carry.i/v1 if empty.
+	groupVersion schema.GroupVersion
+	// name is the plural used in the url, like "pods".
+	name string
+	// kind is the kind of the objects, like "pod". It is the first segment of
+	// their storage key.
+	kind       string
+	namespaced bool
+
+	newFunc     func() runtime.Object
+	newListFunc func() runtime.Object
+
+	validate       func(obj runtime.Object) field.ErrorList
+	validateUpdate func(obj, old runtime.Object) field.ErrorList
+	// validateStatusUpdate is nil for kinds without a status subresource.
+	validateStatusUpdate func(obj, old runtime.Object) field.ErrorList
+
+	nameGenerator names.NameGenerator
+}
+
+// hasStatus reports whether the kind has a status subresource.
+func (r *resource) hasStatus() bool {
+	return r.validateStatusUpdate != nil
+}
+
+// groupResource returns the group qualified name of the resource, used in
+// the status of failed requests.
+func (r *resource) groupResource() schema.GroupResource {
+	return r.groupVersion.WithResource(r.name).GroupResource()
+}
+
+// groupKind returns the group qualified kind of the objects.
+func (r *resource) groupKind() schema.GroupKind {
+	return r.groupVersion.WithKind(r.kind).GroupKind()
+}
+
+// resources 所有通过api server提供服务的kind，先以group version、再以url中的复数名索引
+var resources = map[schema.GroupVersion]map[string]*resource{}
+
+func addResource(r *resource) {
+	if r.groupVersion.Empty() {
+		r.groupVersion = v1.SchemeGroupVersion
+	}
+	if resources[r.groupVersion] == nil {
+		resources[r.groupVersion] = map[string]*resource{}
+	}
+	resources[r.groupVersion][r.name] = r
+}
+
+func init() {
+	addResource(&resource{
+		name: "pods", kind: "pod", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.Pod{} },
+		newListFunc: func() runtime.Object { return &v1.PodList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidatePod(obj.(*v1.Pod)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidatePodUpdate(obj.(*v1.Pod), old.(*v1.Pod))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidatePodStatusUpdate(obj.(*v1.Pod), old.(*v1.Pod))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "deployments", kind: "deployment", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.Deployment{} },
+		newListFunc: func() runtime.Object { return &v1.DeploymentList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateDeployment(obj.(*v1.Deployment)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateDeploymentUpdate(obj.(*v1.Deployment), old.(*v1.Deployment))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateDeploymentStatusUpdate(obj.(*v1.Deployment), old.(*v1.Deployment))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "replicasets", kind: "replicaset", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.ReplicaSet{} },
+		newListFunc: func() runtime.Object { return &v1.ReplicaSetList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateReplicaSet(obj.(*v1.ReplicaSet)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateReplicaSetUpdate(obj.(*v1.ReplicaSet), old.(*v1.ReplicaSet))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateReplicaSetStatusUpdate(obj.(*v1.ReplicaSet), old.(*v1.ReplicaSet))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "statefulsets", kind: "statefulset", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.StatefulSet{} },
+		newListFunc: func() runtime.Object { return &v1.StatefulSetList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateStatefulSet(obj.(*v1.StatefulSet)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateStatefulSetUpdate(obj.(*v1.StatefulSet), old.(*v1.StatefulSet))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateStatefulSetStatusUpdate(obj.(*v1.StatefulSet), old.(*v1.StatefulSet))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "daemonsets", kind: "daemonset", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.DaemonSet{} },
+		newListFunc: func() runtime.Object { return &v1.DaemonSetList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateDaemonSet(obj.(*v1.DaemonSet)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateDaemonSetUpdate(obj.(*v1.DaemonSet), old.(*v1.DaemonSet))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateDaemonSetStatusUpdate(obj.(*v1.DaemonSet), old.(*v1.DaemonSet))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "jobs", kind: "job", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.Job{} },
+		newListFunc: func() runtime.Object { return &v1.JobList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateJob(obj.(*v1.Job)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateJobUpdate(obj.(*v1.Job), old.(*v1.Job))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateJobStatusUpdate(obj.(*v1.Job), old.(*v1.Job))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "services", kind: "service", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.Service{} },
+		newListFunc: func() runtime.Object { return &v1.ServiceList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateService(obj.(*v1.Service)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateServiceUpdate(obj.(*v1.Service), old.(*v1.Service))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateServiceStatusUpdate(obj.(*v1.Service), old.(*v1.Service))
+		},
+		nameGenerator: names.SimpleNameGenerator,
+	})
+	addResource(&resource{
+		name: "configmaps", kind: "configmap", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.ConfigMap{} },
+		newListFunc: func() runtime.Object { return &v1.ConfigMapList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateConfigMap(obj.(*v1.ConfigMap)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateConfigMapUpdate(obj.(*v1.ConfigMap), old.(*v1.ConfigMap))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "events", kind: "event", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.Event{} },
+		newListFunc: func() runtime.Object { return &v1.EventList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateEvent(obj.(*v1.Event)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateEventUpdate(obj.(*v1.Event), old.(*v1.Event))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "nodes", kind: "node",
+		newFunc:     func() runtime.Object { return &v1.Node{} },
+		newListFunc: func() runtime.Object { return &v1.NodeList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateNode(obj.(*v1.Node)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateNodeUpdate(obj.(*v1.Node), old.(*v1.Node))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateNodeStatusUpdate(obj.(*v1.Node), old.(*v1.Node))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "namespaces", kind: "namespace",
+		newFunc:     func() runtime.Object { return &v1.Namespace{} },
+		newListFunc: func() runtime.Object { return &v1.NamespaceList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateNamespace(obj.(*v1.Namespace)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateNamespaceUpdate(obj.(*v1.Namespace), old.(*v1.Namespace))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateNamespaceStatusUpdate(obj.(*v1.Namespace), old.(*v1.Namespace))
+		},
+		nameGenerator: names.SimpleNameGenerator,
+	})
+	addResource(&resource{
+		name: "resourcequotas", kind: "resourcequota", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.ResourceQuota{} },
+		newListFunc: func() runtime.Object { return &v1.ResourceQuotaList{} },
+		validate: func(obj runtime.Object) field.ErrorList {
+			return validation.ValidateResourceQuota(obj.(*v1.ResourceQuota))
+		},
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateResourceQuotaUpdate(obj.(*v1.ResourceQuota), old.(*v1.ResourceQuota))
+		},
+		validateStatusUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateResourceQuotaStatusUpdate(obj.(*v1.ResourceQuota), old.(*v1.ResourceQuota))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		name: "limitranges", kind: "limitrange", namespaced: true,
+		newFunc:     func() runtime.Object { return &v1.LimitRange{} },
+		newListFunc: func() runtime.Object { return &v1.LimitRangeList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateLimitRange(obj.(*v1.LimitRange)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateLimitRangeUpdate(obj.(*v1.LimitRange), old.(*v1.LimitRange))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+
+	addResource(&resource{
+		groupVersion: rbacv1.SchemeGroupVersion,
+		name:         "roles", kind: "role", namespaced: true,
+		newFunc:     func() runtime.Object { return &rbacv1.Role{} },
+		newListFunc: func() runtime.Object { return &rbacv1.RoleList{} },
+		validate:    func(obj runtime.Object) field.ErrorList { return validation.ValidateRole(obj.(*rbacv1.Role)) },
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateRoleUpdate(obj.(*rbacv1.Role), old.(*rbacv1.Role))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		groupVersion: rbacv1.SchemeGroupVersion,
+		name:         "rolebindings", kind: "rolebinding", namespaced: true,
+		newFunc:     func() runtime.Object { return &rbacv1.RoleBinding{} },
+		newListFunc: func() runtime.Object { return &rbacv1.RoleBindingList{} },
+		validate: func(obj runtime.Object) field.ErrorList {
+			return validation.ValidateRoleBinding(obj.(*rbacv1.RoleBinding))
+		},
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateRoleBindingUpdate(obj.(*rbacv1.RoleBinding), old.(*rbacv1.RoleBinding))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		groupVersion: rbacv1.SchemeGroupVersion,
+		name:         "clusterroles", kind: "clusterrole",
+		newFunc:     func() runtime.Object { return &rbacv1.ClusterRole{} },
+		newListFunc: func() runtime.Object { return &rbacv1.ClusterRoleList{} },
+		validate: func(obj runtime.Object) field.ErrorList {
+			return validation.ValidateClusterRole(obj.(*rbacv1.ClusterRole))
+		},
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateClusterRoleUpdate(obj.(*rbacv1.ClusterRole), old.(*rbacv1.ClusterRole))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+	addResource(&resource{
+		groupVersion: rbacv1.SchemeGroupVersion,
+		name:         "clusterrolebindings", kind: "clusterrolebinding",
+		newFunc:     func() runtime.Object { return &rbacv1.ClusterRoleBinding{} },
+		newListFunc: func() runtime.Object { return &rbacv1.ClusterRoleBindingList{} },
+		validate: func(obj runtime.Object) field.ErrorList {
+			return validation.ValidateClusterRoleBinding(obj.(*rbacv1.ClusterRoleBinding))
+		},
+		validateUpdate: func(obj, old runtime.Object) field.ErrorList {
+			return validation.ValidateClusterRoleBindingUpdate(obj.(*rbacv1.ClusterRoleBinding), old.(*rbacv1.ClusterRoleBinding))
+		},
+		nameGenerator: names.SubdomainNameGenerator,
+	})
+}
+
+// copyStatus sets the status of dst to the status of src. Updates of an
+// object keep its status, updates of the status subresource keep everything
+// else.
+func copyStatus(dst, src runtime.Object) {
+	dstStatus := reflect.ValueOf(dst).Elem().FieldByName("Status")
+	srcStatus := reflect.ValueOf(src).Elem().FieldByName("Status")
+	if dstStatus.IsValid() && srcStatus.IsValid() {
+		dstStatus.Set(srcStatus)
+	}
+}
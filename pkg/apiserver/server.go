@@ -0,0 +1,215 @@
+package apiserver
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/opencarry/carry/pkg/admission"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/audit"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// APIPrefix is the path prefix of the urls of the carry.i/v1 kinds. The kinds
+// of other groups are served under "/apis/{group}/{version}".
+var APIPrefix = "/apis/" + v1.SchemeGroupVersion.String()
+
+// Server serves the kinds stored in a storage.Interface.
+type Server struct {
+	storage storage.Interface
+	// admissionControl 在对象写入storage前执行的admission插件，为nil时不执行
+	admissionControl admission.Interface
+}
+
+var _ http.Handler = &Server{}
+
+// New returns a Server that serves the objects in s. Creates, updates and
+// deletes go through admissionControl, which may be nil.
+func New(s storage.Interface, admissionControl admission.Interface) *Server {
+	return &Server{storage: s, admissionControl: admissionControl}
+}
+
+// requestInfo is the resource a request is about, as found in its url.
+type requestInfo struct {
+	resource *resource
+	// namespace is empty for cluster scoped kinds and for requests across all
+	// namespaces.
+	namespace   string
+	name        string
+	subresource string
+}
+
+// key returns the storage key of the requested object.
+func (ri *requestInfo) key() string {
+	return storage.Key(ri.resource.kind, ri.namespace, ri.name)
+}
+
+// keyPrefix returns the storage key prefix of the requested collection.
+func (ri *requestInfo) keyPrefix() string {
+	return storage.KeyPrefix(ri.resource.kind, ri.namespace)
+}
+
+// parseRequestInfo returns the resource addressed by path, or nil if path
+// does not address one.
+func parseRequestInfo(path string) *requestInfo {
+	if !strings.HasPrefix(path, "/apis/") {
+		return nil
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(path, "/apis/"), "/"), "/")
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			return nil
+		}
+	}
+	// {group}/{version}/{resource}...
+	if len(parts) < 3 {
+		return nil
+	}
+	groupResources := resources[schema.GroupVersion{Group: parts[0], Version: parts[1]}]
+	parts = parts[2:]
+
+	info := &requestInfo{}
+	// namespaces/{namespace}/{resource}... , but namespaces/{name}/status is
+	// the status of a namespace
+	if len(parts) >= 3 && parts[0] == "namespaces" && !(len(parts) == 3 && parts[2] == "status") {
+		info.namespace = parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) > 3 {
+		return nil
+	}
+
+	info.resource = groupResources[parts[0]]
+	if info.resource == nil {
+		return nil
+	}
+	if len(info.namespace) != 0 && !info.resource.namespaced {
+		return nil
+	}
+	if len(parts) > 1 {
+		info.name = parts[1]
+		// namespaced objects are only addressable within their namespace
+		if info.resource.namespaced && len(info.namespace) == 0 {
+			return nil
+		}
+	}
+	if len(parts) > 2 {
+		info.subresource = parts[2]
+		switch {
+		case info.subresource == "status" && info.resource.hasStatus():
+		case info.subresource == "binding" && info.resource.kind == "pod":
+		default:
+			return nil
+		}
+	}
+	return info
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	info := parseRequestInfo(req.URL.Path)
+	if info == nil {
+		writeError(w, apierrors.NewGenericServerResponse(http.StatusNotFound, req.Method, schema.GroupResource{}, "", ""))
+		return
+	}
+
+	switch {
+	case len(info.name) == 0:
+		switch req.Method {
+		case http.MethodGet:
+			if req.URL.Query().Get("watch") == "true" {
+				s.watch(w, req, info)
+			} else {
+				s.list(w, req, info)
+			}
+			return
+		case http.MethodPost:
+			s.create(w, req, info)
+			return
+		}
+	case info.subresource == "binding":
+		if req.Method == http.MethodPost {
+			s.bind(w, req, info)
+			return
+		}
+	default:
+		switch req.Method {
+		case http.MethodGet:
+			s.get(w, req, info)
+			return
+		case http.MethodPut:
+			s.update(w, req, info)
+			return
+		case http.MethodPatch:
+			s.patch(w, req, info)
+			return
+		case http.MethodDelete:
+			if len(info.subresource) == 0 {
+				s.delete(w, req, info)
+				return
+			}
+		}
+	}
+	writeError(w, apierrors.NewMethodNotSupported(info.resource.groupResource(), strings.ToLower(req.Method)))
+}
+
+// negotiate returns the serializer for the media type the client accepts,
+// JSON if it accepts any.
+func negotiate(req *http.Request) (runtime.SerializerInfo, bool) {
+	supported := scheme.Codecs.SupportedMediaTypes()
+	accept := req.Header.Get("Accept")
+	if len(accept) == 0 {
+		return supported[0], true
+	}
+	for _, clause := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(clause))
+		if err != nil {
+			continue
+		}
+		if mediaType == "*/*" || mediaType == "application/*" {
+			return supported[0], true
+		}
+		if info, ok := runtime.SerializerInfoForMediaType(supported, mediaType); ok {
+			return info, true
+		}
+	}
+	return runtime.SerializerInfo{}, false
+}
+
+// writeObject encodes obj, in the group version it is registered in, in the
+// format the client accepts.
+func writeObject(w http.ResponseWriter, req *http.Request, code int, obj runtime.Object) {
+	info, ok := negotiate(req)
+	if !ok {
+		writeError(w, apierrors.NewNotAcceptable(supportedMediaTypes()))
+		return
+	}
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	encoder := scheme.Codecs.EncoderForVersion(info.Serializer, kinds[0].GroupVersion())
+	data, err := runtime.Encode(encoder, obj)
+	if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	audit.LogResponseObject(req.Context(), obj)
+	w.Header().Set("Content-Type", info.MediaType)
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func supportedMediaTypes() []string {
+	var types []string
+	for _, info := range scheme.Codecs.SupportedMediaTypes() {
+		types = append(types, info.MediaType)
+	}
+	return types
+}
@@ -0,0 +1,828 @@
+package apiserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencarry/carry/pkg/admission"
+	"github.com/opencarry/carry/pkg/admission/plugin/limitranger"
+	"github.com/opencarry/carry/pkg/admission/plugin/namespace/lifecycle"
+	"github.com/opencarry/carry/pkg/admission/plugin/resourcequota"
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+	"github.com/opencarry/carry/pkg/audit"
+	"github.com/opencarry/carry/pkg/audit/policy"
+	"github.com/opencarry/carry/pkg/authentication/group"
+	"github.com/opencarry/carry/pkg/authentication/request/bearertoken"
+	"github.com/opencarry/carry/pkg/authentication/token/tokenfile"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/authorization/authorizerfactory"
+	"github.com/opencarry/carry/pkg/authorization/rbac"
+	authorizerunion "github.com/opencarry/carry/pkg/authorization/union"
+	carryresource "github.com/opencarry/carry/pkg/resource"
+	"github.com/opencarry/carry/pkg/storage/memory"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(New(memory.New(), nil))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func validPod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:               "web",
+				Image:              "nginx:1.21",
+				ImageDeploymentDir: "/opt/web",
+			}},
+		},
+	}
+}
+
+// do sends body encoded as JSON and decodes the response into out, unless out
+// is nil. It returns the status code of the response.
+func do(t *testing.T, method, url, contentType string, body, out interface{}) int {
+	t.Helper()
+	return doAs(t, "", method, url, contentType, body, out)
+}
+
+// doAs is do with token as the bearer token of the request, if not empty.
+func doAs(t *testing.T, token, method, url, contentType string, body, out interface{}) int {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if len(token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: failed to decode response: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestPodCRUD(t *testing.T) {
+	server := newTestServer(t)
+	pods := server.URL + APIPrefix + "/namespaces/default/pods"
+
+	created := &v1.Pod{}
+	if code := do(t, http.MethodPost, pods, "application/json", validPod("web-1"), created); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	if created.Kind != "pod" || created.Namespace != "default" || created.ResourceVersion == "" || created.UID == "" {
+		t.Errorf("unexpected created pod: %#v", created)
+	}
+	if created.Spec.RestartPolicy == "" {
+		t.Errorf("expected the created pod to be defaulted")
+	}
+	if code := do(t, http.MethodPost, pods, "application/json", validPod("web-1"), nil); code != http.StatusConflict {
+		t.Errorf("expected 409 creating an existing pod, got %d", code)
+	}
+
+	generated := validPod("")
+	generated.GenerateName = "web-"
+	if code := do(t, http.MethodPost, pods, "application/json", generated, generated); code != http.StatusCreated || len(generated.Name) != len("web-")+5 {
+		t.Errorf("expected a generated name, got %d %q", code, generated.Name)
+	}
+
+	got := &v1.Pod{}
+	if code := do(t, http.MethodGet, pods+"/web-1", "", nil, got); code != http.StatusOK || got.UID != created.UID {
+		t.Errorf("unexpected get: %d %#v", code, got.ObjectMeta)
+	}
+
+	// update, the status is not updated through the object itself
+	got.Labels["tier"] = "frontend"
+	got.Status.Phase = v1.PodRunning
+	updated := &v1.Pod{}
+	if code := do(t, http.MethodPut, pods+"/web-1", "application/json", got, updated); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if updated.Labels["tier"] != "frontend" || updated.Status.Phase == v1.PodRunning || updated.ResourceVersion == got.ResourceVersion {
+		t.Errorf("unexpected update: %#v", updated)
+	}
+	// a stale update is rejected
+	if code := do(t, http.MethodPut, pods+"/web-1", "application/json", got, nil); code != http.StatusConflict {
+		t.Errorf("expected 409 for a stale update, got %d", code)
+	}
+
+	// status update, everything but the status is kept
+	updated.Status.Phase = v1.PodRunning
+	updated.Labels = nil
+	if code := do(t, http.MethodPut, pods+"/web-1/status", "application/json", updated, updated); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if updated.Status.Phase != v1.PodRunning || updated.Labels["tier"] != "frontend" {
+		t.Errorf("unexpected status update: %#v", updated)
+	}
+
+	// merge patch
+	patch := map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"tier": nil}}}
+	updated = &v1.Pod{}
+	if code := do(t, http.MethodPatch, pods+"/web-1", "application/merge-patch+json", patch, updated); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if _, ok := updated.Labels["tier"]; ok || updated.Labels["app"] != "web" {
+		t.Errorf("unexpected labels after patch: %v", updated.Labels)
+	}
+
+	// binding
+	binding := &v1.Binding{Host: "node-1"}
+	if code := do(t, http.MethodPost, pods+"/web-1/binding", "application/json", binding, nil); code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", code)
+	}
+	if code := do(t, http.MethodPost, pods+"/web-1/binding", "application/json", &v1.Binding{Host: "node-2"}, nil); code != http.StatusConflict {
+		t.Errorf("expected 409 binding an assigned pod, got %d", code)
+	}
+	do(t, http.MethodGet, pods+"/web-1", "", nil, got)
+	if got.Spec.NodeName != "node-1" {
+		t.Errorf("expected pod to be bound to node-1, got %q", got.Spec.NodeName)
+	}
+
+	if code := do(t, http.MethodDelete, pods+"/web-1", "", nil, nil); code != http.StatusOK {
+		t.Errorf("expected 200, got %d", code)
+	}
+	st := &v1.Status{}
+	if code := do(t, http.MethodGet, pods+"/web-1", "", nil, st); code != http.StatusNotFound || !apierrors.IsNotFound(apierrors.FromObject(st)) {
+		t.Errorf("expected not found status, got %d %#v", code, st)
+	}
+}
+
+func TestInvalidObject(t *testing.T) {
+	server := newTestServer(t)
+	pod := validPod("web")
+	pod.Spec.Containers[0].Name = ""
+
+	st := &v1.Status{}
+	code := do(t, http.MethodPost, server.URL+APIPrefix+"/namespaces/default/pods", "application/json", pod, st)
+	if code != http.StatusUnprocessableEntity || !apierrors.IsInvalid(apierrors.FromObject(st)) || st.Details == nil {
+		t.Fatalf("expected invalid status, got %d %#v", code, st)
+	}
+	found := false
+	for _, cause := range st.Details.Causes {
+		if cause.Field == "spec.containers[0].name" && cause.Type == v1.CauseTypeFieldValueRequired {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a cause for spec.containers[0].name, got %#v", st.Details.Causes)
+	}
+
+	if code := do(t, http.MethodPost, server.URL+APIPrefix+"/namespaces/other/pods", "application/json", &v1.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a namespace mismatch, got %d", code)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	server := newTestServer(t)
+	pods := server.URL + APIPrefix + "/namespaces/default/pods"
+	pod := validPod("web")
+	pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+		Name: "sidecar", Image: "sidecar:1", ImageDeploymentDir: "/opt/sidecar", Command: []string{"sidecar"},
+	})
+	if code := do(t, http.MethodPost, pods, "application/json", pod, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+
+	// strategic merge patch, only the named container changes
+	patched := &v1.Pod{}
+	patch := json.RawMessage(`{"spec": {"suspended": true, "containers": [{"name": "sidecar", "image": "sidecar:2"}]}}`)
+	if code := do(t, http.MethodPatch, pods+"/web", "application/strategic-merge-patch+json", patch, patched); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if len(patched.Spec.Containers) != 2 || patched.Spec.Containers[0].Image != pod.Spec.Containers[0].Image ||
+		patched.Spec.Containers[1].Image != "sidecar:2" || patched.Spec.Suspended == nil || !*patched.Spec.Suspended {
+		t.Errorf("unexpected pod after strategic merge patch: %#v", patched.Spec)
+	}
+
+	// the same patch as a merge patch replaces the list, leaving an invalid container
+	st := &v1.Status{}
+	if code := do(t, http.MethodPatch, pods+"/web", "application/merge-patch+json", patch, st); code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d %#v", code, st)
+	}
+
+	// json patch of the status subresource
+	patch = json.RawMessage(`[{"op": "test", "path": "/spec/suspended", "value": true}, {"op": "add", "path": "/status/taint_restarts", "value": 1}]`)
+	if code := do(t, http.MethodPatch, pods+"/web/status", "application/json-patch+json", patch, patched); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if patched.Status.TaintRestarts == nil || *patched.Status.TaintRestarts != 1 {
+		t.Errorf("unexpected status after json patch: %#v", patched.Status)
+	}
+	patch = json.RawMessage(`[{"op": "test", "path": "/spec/suspended", "value": false}]`)
+	if code := do(t, http.MethodPatch, pods+"/web", "application/json-patch+json", patch, nil); code != http.StatusConflict {
+		t.Errorf("expected 409 for a failed test, got %d", code)
+	}
+
+	if code := do(t, http.MethodPatch, pods+"/web", "application/json", json.RawMessage(`{}`), nil); code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415, got %d", code)
+	}
+	if code := do(t, http.MethodPatch, pods+"/web", "application/json-patch+json", json.RawMessage(`{}`), nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a malformed patch, got %d", code)
+	}
+}
+
+func TestApply(t *testing.T) {
+	server := newTestServer(t)
+	deployments := server.URL + APIPrefix + "/namespaces/default/deployments"
+	config := json.RawMessage(`{
+		"kind": "deployment",
+		"api_version": "carry.i/v1",
+		"metadata": {"name": "web"},
+		"spec": {
+			"replicas": 2,
+			"selector": {"match_labels": {"app": "web"}},
+			"template": {
+				"metadata": {"labels": {"app": "web"}},
+				"spec": {"containers": [{"name": "web", "image": "web:1", "image_deployment_dir": "/opt/web"}]}
+			}
+		}
+	}`)
+	applyType := "application/apply-patch+yaml"
+
+	if code := do(t, http.MethodPatch, deployments+"/web", applyType, config, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a field manager, got %d", code)
+	}
+	created := &v1.Deployment{}
+	if code := do(t, http.MethodPatch, deployments+"/web?field_manager=ci", applyType, config, created); code != http.StatusCreated {
+		t.Fatalf("expected apply to create the deployment, got %d", code)
+	}
+	if len(created.ManagedFields) != 1 || created.ManagedFields[0].Manager != "ci" || created.UID == "" {
+		t.Errorf("unexpected created deployment: %#v", created.ObjectMeta)
+	}
+
+	// sre scales with a merge patch and takes over the replicas
+	scaled := &v1.Deployment{}
+	scale := json.RawMessage(`{"spec": {"replicas": 5}}`)
+	if code := do(t, http.MethodPatch, deployments+"/web?field_manager=sre", "application/merge-patch+json", scale, scaled); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if len(scaled.ManagedFields) != 2 {
+		t.Errorf("expected two managers, got %#v", scaled.ManagedFields)
+	}
+
+	st := &v1.Status{}
+	if code := do(t, http.MethodPatch, deployments+"/web?field_manager=ci", applyType, config, st); code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", code)
+	}
+	if cause, ok := apierrors.StatusCause(apierrors.FromObject(st), v1.CauseTypeFieldManagerConflict); !ok || cause.Field != "spec.replicas" {
+		t.Errorf("expected a conflict on spec.replicas, got %#v", st)
+	}
+
+	forced := &v1.Deployment{}
+	if code := do(t, http.MethodPatch, deployments+"/web?field_manager=ci&force=true", applyType, config, forced); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if *forced.Spec.Replicas != 2 || len(forced.ManagedFields) != 1 {
+		t.Errorf("unexpected deployment after a forced apply: %v %#v", *forced.Spec.Replicas, forced.ManagedFields)
+	}
+}
+
+func TestAdmission(t *testing.T) {
+	store := memory.New()
+	server := httptest.NewServer(New(store, admission.NewChainHandler(
+		lifecycle.NewLifecycle(store),
+		limitranger.NewLimitRanger(store),
+		resourcequota.NewResourceQuota(store),
+	)))
+	t.Cleanup(server.Close)
+	namespace := server.URL + APIPrefix + "/namespaces/team-a"
+
+	limitRange := &v1.LimitRange{
+		ObjectMeta: v1.ObjectMeta{Name: "limits"},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+			Type:    v1.LimitTypeContainer,
+			Max:     v1.ResourceList{v1.ResourceCPU: carryresource.MustParse("1")},
+			Default: v1.ResourceList{v1.ResourceCPU: carryresource.MustParse("500m")},
+		}}},
+	}
+	if code := do(t, http.MethodPost, namespace+"/limitranges", "application/json", limitRange, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	quota := &v1.ResourceQuota{
+		ObjectMeta: v1.ObjectMeta{Name: "compute"},
+		Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{
+			v1.ResourcePods:        carryresource.MustParse("5"),
+			v1.ResourceRequestsCPU: carryresource.MustParse("1"),
+		}},
+	}
+	if code := do(t, http.MethodPost, namespace+"/resourcequotas", "application/json", quota, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+
+	// the limit range defaults the resources of the containers
+	created := &v1.Pod{}
+	if code := do(t, http.MethodPost, namespace+"/pods", "application/json", validPod("web-1"), created); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	resources := created.Spec.Containers[0].Resources
+	if cpu := resources.Limits[v1.ResourceCPU]; cpu.String() != "500m" {
+		t.Errorf("expected the default cpu limit, got %v", resources.Limits)
+	}
+	if cpu := resources.Requests[v1.ResourceCPU]; cpu.String() != "500m" {
+		t.Errorf("expected the cpu request to default to the limit, got %v", resources.Requests)
+	}
+
+	// and rejects containers above its max
+	greedy := validPod("greedy")
+	greedy.Spec.Containers[0].Resources.Limits = v1.ResourceList{v1.ResourceCPU: carryresource.MustParse("2")}
+	st := &v1.Status{}
+	if code := do(t, http.MethodPost, namespace+"/pods", "application/json", greedy, st); code != http.StatusForbidden {
+		t.Errorf("expected 403 above the max of the limit range, got %d", code)
+	}
+
+	// the quota of one cpu fits two pods
+	if code := do(t, http.MethodPost, namespace+"/pods", "application/json", validPod("web-2"), nil); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	st = &v1.Status{}
+	if code := do(t, http.MethodPost, namespace+"/pods", "application/json", validPod("web-3"), st); code != http.StatusForbidden {
+		t.Fatalf("expected 403 over the quota, got %d", code)
+	}
+	if expected := `pods.carry.i "web-3" is forbidden: exceeded quota: compute, requested: requests.cpu=500m, used: requests.cpu=1, limited: requests.cpu=1`; st.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, st.Message)
+	}
+
+	// nothing is created in a terminating namespace
+	terminating := &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "old"}}
+	namespaces := server.URL + APIPrefix + "/namespaces"
+	if code := do(t, http.MethodPost, namespaces, "application/json", terminating, terminating); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	terminating.Status.Phase = v1.NamespaceTerminating
+	if code := do(t, http.MethodPut, namespaces+"/old/status", "application/json", terminating, nil); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code := do(t, http.MethodPost, namespaces+"/old/pods", "application/json", validPod("web"), nil); code != http.StatusForbidden {
+		t.Errorf("expected 403 in a terminating namespace, got %d", code)
+	}
+}
+
+// teamNodes rejects binding the pods of a namespace to the nodes of other
+// teams.
+type teamNodes struct {
+	*admission.Handler
+}
+
+func (teamNodes) Validate(ctx context.Context, a admission.Attributes) error {
+	binding, ok := a.GetObject().(*v1.Binding)
+	if !ok || a.GetSubresource() != "binding" {
+		return nil
+	}
+	if !strings.HasPrefix(binding.Host, a.GetNamespace()+"-") {
+		return admission.NewForbidden(a, fmt.Errorf("node %s does not belong to %s", binding.Host, a.GetNamespace()))
+	}
+	return nil
+}
+
+func TestBindingAdmission(t *testing.T) {
+	store := memory.New()
+	server := httptest.NewServer(New(store, teamNodes{admission.NewHandler(admission.Create)}))
+	t.Cleanup(server.Close)
+	pods := server.URL + APIPrefix + "/namespaces/team-a/pods"
+
+	if code := do(t, http.MethodPost, pods, "application/json", validPod("web"), nil); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	st := &v1.Status{}
+	if code := do(t, http.MethodPost, pods+"/web/binding", "application/json", &v1.Binding{Host: "team-b-1"}, st); code != http.StatusForbidden {
+		t.Fatalf("expected 403 binding to a node of another team, got %d", code)
+	}
+	if expected := `pods.carry.i "web" is forbidden: node team-b-1 does not belong to team-a`; st.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, st.Message)
+	}
+	pod := &v1.Pod{}
+	if code := do(t, http.MethodGet, pods+"/web", "", nil, pod); code != http.StatusOK || len(pod.Spec.NodeName) != 0 {
+		t.Fatalf("expected the pod to stay unassigned, got %d %q", code, pod.Spec.NodeName)
+	}
+
+	if code := do(t, http.MethodPost, pods+"/web/binding", "application/json", &v1.Binding{Host: "team-a-1"}, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201 binding to a node of the team, got %d", code)
+	}
+	if code := do(t, http.MethodGet, pods+"/web", "", nil, pod); code != http.StatusOK || pod.Spec.NodeName != "team-a-1" {
+		t.Errorf("expected the pod to be assigned to team-a-1, got %d %q", code, pod.Spec.NodeName)
+	}
+}
+
+func TestRouting(t *testing.T) {
+	server := newTestServer(t)
+	tests := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default/pods", http.StatusOK},
+		{http.MethodGet, "/apis/carry.i/v1/pods", http.StatusOK},
+		{http.MethodGet, "/apis/carry.i/v1/nodes", http.StatusOK},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces", http.StatusOK},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default", http.StatusNotFound},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default/status", http.StatusNotFound},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default/nodes", http.StatusNotFound},
+		{http.MethodGet, "/apis/carry.i/v1/pods/foo", http.StatusNotFound},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default/configmaps/foo/status", http.StatusNotFound},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default/unknown", http.StatusNotFound},
+		{http.MethodGet, "/apis/carry.i/v2/pods", http.StatusNotFound},
+		{http.MethodPost, "/apis/carry.i/v1/pods", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/apis/carry.i/v1/namespaces/default/pods", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/apis/carry.i/v1/namespaces/default/pods/foo/binding", http.StatusMethodNotAllowed},
+	}
+	for _, tc := range tests {
+		if code := do(t, tc.method, server.URL+tc.path, "", nil, nil); code != tc.code {
+			t.Errorf("%s %s: expected %d, got %d", tc.method, tc.path, tc.code, code)
+		}
+	}
+}
+
+func TestClusterScoped(t *testing.T) {
+	server := newTestServer(t)
+	namespaces := server.URL + APIPrefix + "/namespaces"
+
+	ns := &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "team-a"}}
+	if code := do(t, http.MethodPost, namespaces, "application/json", ns, ns); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	ns.Status.Phase = v1.NamespaceTerminating
+	if code := do(t, http.MethodPut, namespaces+"/team-a/status", "application/json", ns, ns); code != http.StatusOK || ns.Status.Phase != v1.NamespaceTerminating {
+		t.Errorf("unexpected status update: %d %#v", code, ns.Status)
+	}
+	if code := do(t, http.MethodPost, namespaces, "application/json", &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "x", Namespace: "default"}}, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a namespaced namespace, got %d", code)
+	}
+}
+
+func TestList(t *testing.T) {
+	server := newTestServer(t)
+	for _, ns := range []string{"a", "b"} {
+		for _, name := range []string{"web-1", "web-2", "db-1"} {
+			pod := validPod(name)
+			if name == "db-1" {
+				pod.Labels = map[string]string{"app": "db"}
+			}
+			if code := do(t, http.MethodPost, server.URL+APIPrefix+"/namespaces/"+ns+"/pods", "application/json", pod, nil); code != http.StatusCreated {
+				t.Fatalf("expected 201, got %d", code)
+			}
+		}
+	}
+
+	list := &v1.PodList{}
+	do(t, http.MethodGet, server.URL+APIPrefix+"/pods?label_selector=app%3Dweb", "", nil, list)
+	if len(list.Items) != 4 {
+		t.Errorf("expected 4 web pods, got %d", len(list.Items))
+	}
+	do(t, http.MethodGet, server.URL+APIPrefix+"/namespaces/a/pods?field_selector=metadata.name%3Ddb-1", "", nil, list)
+	if len(list.Items) != 1 || list.Items[0].Name != "db-1" {
+		t.Errorf("expected db-1, got %v", list.Items)
+	}
+	if code := do(t, http.MethodGet, server.URL+APIPrefix+"/pods?field_selector=spec.unknown%3Dx", "", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unsupported field selector, got %d", code)
+	}
+
+	var seen []string
+	url := server.URL + APIPrefix + "/pods?limit=4"
+	for {
+		list = &v1.PodList{}
+		if code := do(t, http.MethodGet, url, "", nil, list); code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+		for _, pod := range list.Items {
+			seen = append(seen, pod.Namespace+"/"+pod.Name)
+		}
+		if list.Continue == "" {
+			break
+		}
+		url = server.URL + APIPrefix + "/pods?limit=4&continue=" + list.Continue
+	}
+	if len(seen) != 6 {
+		t.Errorf("expected 6 pods over all chunks, got %v", seen)
+	}
+	if code := do(t, http.MethodGet, server.URL+APIPrefix+"/pods?limit=4&continue=garbage", "", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid continue token, got %d", code)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	server := newTestServer(t)
+	pods := server.URL + APIPrefix + "/namespaces/default/pods"
+
+	created := &v1.Pod{}
+	do(t, http.MethodPost, pods, "application/json", validPod("web-1"), created)
+
+	resp, err := http.Get(pods + "?watch=true&resource_version=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	do(t, http.MethodPost, pods, "application/json", validPod("web-2"), nil)
+	do(t, http.MethodDelete, pods+"/web-1", "", nil, nil)
+
+	events := make(chan v1.WatchEvent)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var event v1.WatchEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				t.Errorf("failed to decode event %q: %v", scanner.Text(), err)
+				return
+			}
+			events <- event
+		}
+	}()
+
+	for _, expected := range []struct{ eventType, name string }{{"added", "web-2"}, {"deleted", "web-1"}} {
+		select {
+		case event := <-events:
+			pod := &v1.Pod{}
+			if err := json.Unmarshal(event.Object, pod); err != nil {
+				t.Fatal(err)
+			}
+			if event.Type != expected.eventType || pod.Name != expected.name || pod.Kind != "pod" {
+				t.Errorf("expected %s %s, got %s %s", expected.eventType, expected.name, event.Type, pod.Name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s event", expected.eventType)
+		}
+	}
+
+	// resuming from the first revision replays the changes after it
+	resp2, err := http.Get(pods + "?watch=true&timeout_seconds=1&resource_version=" + created.ResourceVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp2.Body.Close()
+	var replayed []string
+	scanner := bufio.NewScanner(resp2.Body)
+	for scanner.Scan() {
+		var event v1.WatchEvent
+		json.Unmarshal(scanner.Bytes(), &event)
+		replayed = append(replayed, event.Type)
+	}
+	if len(replayed) != 2 || replayed[0] != "added" || replayed[1] != "deleted" {
+		t.Errorf("expected added and deleted to be replayed, got %v", replayed)
+	}
+
+	if resp, err := http.Get(pods + "?watch=true&resource_version=1000"); err != nil || resp.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("expected 504 for a too large resource version, got %v %v", err, resp.StatusCode)
+	}
+}
+
+func TestAuthenticationAndAuthorization(t *testing.T) {
+	tokens := tokenfile.New(map[string]*user.DefaultInfo{
+		"admin-token": {Name: "admin", Groups: []string{user.SystemPrivilegedGroup}},
+		"alice-token": {Name: "alice", Groups: []string{"team-a"}},
+	})
+	store := memory.New()
+	authn := group.NewAuthenticatedGroupAdder(bearertoken.New(tokens))
+	authz := authorizerunion.New(authorizerfactory.NewPrivilegedGroups(user.SystemPrivilegedGroup), rbac.New(store))
+	server := httptest.NewServer(WithAuthentication(WithAuthorization(New(store, nil), authz), authn))
+	t.Cleanup(server.Close)
+	rbacPrefix := server.URL + "/apis/" + rbacv1.SchemeGroupVersion.String()
+
+	for _, token := range []string{"", "wrong-token"} {
+		status := &v1.Status{}
+		if code := doAs(t, token, http.MethodGet, server.URL+APIPrefix+"/pods", "", nil, status); code != http.StatusUnauthorized || status.Reason != v1.StatusReasonUnauthorized {
+			t.Errorf("%q: expected 401, got %d %s", token, code, status.Reason)
+		}
+	}
+
+	// the administrator lets team-a manage the pods of its namespace
+	role := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{Name: "pod-admin"},
+		Rules:      []rbacv1.PolicyRule{{Verbs: []string{"*"}, APIGroups: []string{v1.GroupName}, Resources: []string{"pods"}}},
+	}
+	created := &rbacv1.Role{}
+	if code := doAs(t, "admin-token", http.MethodPost, rbacPrefix+"/namespaces/team-a/roles", "application/json", role, created); code != http.StatusCreated {
+		t.Fatalf("expected 201 creating the role, got %d", code)
+	}
+	if created.APIVersion != "rbac.carry.i/v1" || created.Kind != "role" || created.Namespace != "team-a" {
+		t.Errorf("unexpected role %#v", created)
+	}
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "team-a-pod-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "team-a"}},
+		RoleRef:    rbacv1.RoleRef{Kind: rbacv1.RoleKind, Name: "pod-admin"},
+	}
+	if code := doAs(t, "admin-token", http.MethodPost, rbacPrefix+"/namespaces/team-a/rolebindings", "application/json", binding, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201 creating the binding, got %d", code)
+	}
+	invalid := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "invalid"},
+		RoleRef:    rbacv1.RoleRef{Kind: rbacv1.RoleKind, Name: "pod-admin"},
+	}
+	if code := doAs(t, "admin-token", http.MethodPost, rbacPrefix+"/clusterrolebindings", "application/json", invalid, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a cluster role binding to a role, got %d", code)
+	}
+
+	if code := doAs(t, "alice-token", http.MethodPost, server.URL+APIPrefix+"/namespaces/team-a/pods", "application/json", validPod("web"), nil); code != http.StatusCreated {
+		t.Errorf("expected 201 creating a pod in team-a, got %d", code)
+	}
+	status := &v1.Status{}
+	if code := doAs(t, "alice-token", http.MethodPost, server.URL+APIPrefix+"/namespaces/team-b/pods", "application/json", validPod("web"), status); code != http.StatusForbidden {
+		t.Errorf("expected 403 creating a pod in team-b, got %d", code)
+	}
+	if expected := `pods.carry.i is forbidden: user "alice" cannot create resource "pods" in API group "carry.i" in the namespace "team-b"`; status.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, status.Message)
+	}
+	if code := doAs(t, "alice-token", http.MethodGet, server.URL+APIPrefix+"/pods", "", nil, nil); code != http.StatusForbidden {
+		t.Errorf("expected 403 listing pods across namespaces, got %d", code)
+	}
+	if code := doAs(t, "alice-token", http.MethodPost, server.URL+APIPrefix+"/namespaces/team-a/pods/web/binding", "application/json", &v1.Binding{Host: "n1"}, nil); code != http.StatusForbidden {
+		t.Errorf("expected 403 binding a pod, got %d", code)
+	}
+	if code := doAs(t, "alice-token", http.MethodPost, rbacPrefix+"/namespaces/team-a/roles", "application/json", role, nil); code != http.StatusForbidden {
+		t.Errorf("expected 403 creating a role, got %d", code)
+	}
+	if code := doAs(t, "alice-token", http.MethodDelete, server.URL+APIPrefix+"/namespaces/team-a/pods/web", "", nil, nil); code != http.StatusOK {
+		t.Errorf("expected 200 deleting a pod in team-a, got %d", code)
+	}
+
+	list := &rbacv1.RoleBindingList{}
+	if code := doAs(t, "admin-token", http.MethodGet, rbacPrefix+"/rolebindings", "", nil, list); code != http.StatusOK || len(list.Items) != 1 || list.Kind != "rolebindinglist" {
+		t.Errorf("expected the binding to be listed, got %d %#v", code, list)
+	}
+}
+
+func TestApplyCreateAuthorization(t *testing.T) {
+	tokens := tokenfile.New(map[string]*user.DefaultInfo{
+		"admin-token": {Name: "admin", Groups: []string{user.SystemPrivilegedGroup}},
+		"alice-token": {Name: "alice", Groups: []string{"team-a"}},
+	})
+	store := memory.New()
+	authz := authorizerunion.New(authorizerfactory.NewPrivilegedGroups(user.SystemPrivilegedGroup), rbac.New(store))
+	server := httptest.NewServer(WithAuthentication(WithAuthorization(New(store, nil), authz), bearertoken.New(tokens)))
+	t.Cleanup(server.Close)
+	rbacPrefix := server.URL + "/apis/" + rbacv1.SchemeGroupVersion.String()
+	pods := server.URL + APIPrefix + "/namespaces/team-a/pods"
+
+	// team-a may only patch the pods of its namespace
+	role := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{Name: "pod-patcher"},
+		Rules:      []rbacv1.PolicyRule{{Verbs: []string{"patch"}, APIGroups: []string{v1.GroupName}, Resources: []string{"pods"}}},
+	}
+	if code := doAs(t, "admin-token", http.MethodPost, rbacPrefix+"/namespaces/team-a/roles", "application/json", role, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201 creating the role, got %d", code)
+	}
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "team-a-pod-patcher"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "team-a"}},
+		RoleRef:    rbacv1.RoleRef{Kind: rbacv1.RoleKind, Name: "pod-patcher"},
+	}
+	if code := doAs(t, "admin-token", http.MethodPost, rbacPrefix+"/namespaces/team-a/rolebindings", "application/json", binding, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201 creating the binding, got %d", code)
+	}
+	if code := doAs(t, "admin-token", http.MethodPost, pods, "application/json", validPod("web"), nil); code != http.StatusCreated {
+		t.Fatalf("expected 201 creating the pod, got %d", code)
+	}
+
+	applyType := "application/apply-patch+yaml"
+	config := validPod("db")
+	config.Kind = "pod"
+	config.APIVersion = v1.SchemeGroupVersion.String()
+	status := &v1.Status{}
+	if code := doAs(t, "alice-token", http.MethodPatch, pods+"/db?field_manager=alice", applyType, config, status); code != http.StatusForbidden {
+		t.Fatalf("expected 403 applying a new pod, got %d", code)
+	}
+	if expected := `pods.carry.i "db" is forbidden: user "alice" cannot create resource "pods" in API group "carry.i" in the namespace "team-a"`; status.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, status.Message)
+	}
+	if code := doAs(t, "admin-token", http.MethodGet, pods+"/db", "", nil, nil); code != http.StatusNotFound {
+		t.Errorf("expected the pod not to be created, got %d", code)
+	}
+
+	config = validPod("web")
+	config.Kind = "pod"
+	config.APIVersion = v1.SchemeGroupVersion.String()
+	config.Labels["tier"] = "frontend"
+	applied := &v1.Pod{}
+	if code := doAs(t, "alice-token", http.MethodPatch, pods+"/web?field_manager=alice", applyType, config, applied); code != http.StatusOK {
+		t.Fatalf("expected 200 applying an existing pod, got %d", code)
+	}
+	if applied.Labels["tier"] != "frontend" {
+		t.Errorf("expected the label to be applied, got %v", applied.Labels)
+	}
+}
+
+// channelSink passes the audit events it receives on.
+type channelSink chan *auditv1.Event
+
+func (s channelSink) ProcessEvents(events ...*auditv1.Event) bool {
+	for _, ev := range events {
+		s <- ev
+	}
+	return true
+}
+
+func (s channelSink) next(t *testing.T) *auditv1.Event {
+	t.Helper()
+	select {
+	case ev := <-s:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an audit event")
+		return nil
+	}
+}
+
+func TestAudit(t *testing.T) {
+	tokens := tokenfile.New(map[string]*user.DefaultInfo{
+		"alice-token": {Name: "alice", UID: "1", Groups: []string{"team-a"}},
+	})
+	checker := policy.NewChecker(&auditv1.Policy{Rules: []auditv1.PolicyRule{
+		{Level: auditv1.LevelNone, Verbs: []string{"get"}},
+		{Level: auditv1.LevelRequestResponse, Kinds: []string{"configmap"}},
+		{Level: auditv1.LevelMetadata},
+	}})
+	sink := make(channelSink, 10)
+	handler := WithAuthorization(New(memory.New(), nil), authorizerfactory.NewAlwaysAllowAuthorizer())
+	handler = WithAudit(handler, checker, sink)
+	server := httptest.NewServer(WithAuthentication(handler, bearertoken.New(tokens)))
+	t.Cleanup(server.Close)
+	configMaps := server.URL + APIPrefix + "/namespaces/default/configmaps"
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "keys"},
+		Data:       map[string]string{"user": "alice"},
+		BinaryData: map[string][]byte{"key": []byte("secret")},
+	}
+	if code := doAs(t, "alice-token", http.MethodPost, configMaps, "application/json", configMap, nil); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	ev := sink.next(t)
+	if ev.Level != auditv1.LevelRequestResponse || ev.Verb != "create" || ev.ResponseCode != http.StatusCreated {
+		t.Errorf("unexpected event %#v", ev)
+	}
+	if ev.ObjectRef == nil || ev.ObjectRef.Name != "keys" || len(ev.ObjectRef.UID) == 0 || ev.ObjectRef.APIVersion != "carry.i/v1" {
+		t.Errorf("unexpected object reference %#v", ev.ObjectRef)
+	}
+	for name, object := range map[string][]byte{"request": ev.RequestObject, "response": ev.ResponseObject} {
+		recorded := struct {
+			Data       map[string]string `json:"data"`
+			BinaryData map[string]string `json:"binary_data"`
+		}{}
+		if err := json.Unmarshal(object, &recorded); err != nil {
+			t.Fatalf("%s object: %v", name, err)
+		}
+		if recorded.Data["user"] != "alice" || recorded.BinaryData["key"] != audit.Redacted {
+			t.Errorf("expected the binary data of the %s object to be redacted, got %s", name, object)
+		}
+	}
+
+	// gets are not audited
+	if code := doAs(t, "alice-token", http.MethodGet, configMaps+"/keys", "", nil, nil); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code := doAs(t, "alice-token", http.MethodDelete, server.URL+APIPrefix+"/namespaces/default/pods/web", "", nil, nil); code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", code)
+	}
+	ev = sink.next(t)
+	if ev.Level != auditv1.LevelMetadata || ev.Verb != "delete" || ev.ResponseCode != http.StatusNotFound ||
+		ev.User.Username != "alice" || ev.User.UID != "1" || len(ev.AuditID) == 0 ||
+		ev.RequestObject != nil || ev.ResponseObject != nil || ev.LatencyMicroseconds < 0 {
+		t.Errorf("unexpected event %#v", ev)
+	}
+	expectedRef := v1.ObjectReference{Kind: "pod", APIVersion: "carry.i/v1", Namespace: "default", Name: "web"}
+	if ev.ObjectRef == nil || *ev.ObjectRef != expectedRef {
+		t.Errorf("expected object reference %#v, got %#v", expectedRef, ev.ObjectRef)
+	}
+	select {
+	case ev := <-sink:
+		t.Errorf("unexpected event %#v", ev)
+	default:
+	}
+}
@@ -0,0 +1,72 @@
+package apiserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// storageError translates an error of the storage into the status returned to
+// the client.
+func storageError(err error, qualifiedResource schema.GroupResource, name string) *apierrors.StatusError {
+	switch {
+	case err == nil:
+		return nil
+	case storage.IsNotFound(err):
+		return apierrors.NewNotFound(qualifiedResource, name)
+	case storage.IsExist(err):
+		return apierrors.NewAlreadyExists(qualifiedResource, name)
+	case storage.IsConflict(err):
+		return apierrors.NewConflict(qualifiedResource, name, fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+	case storage.IsInvalidObj(err):
+		// preconditions that do not hold
+		return apierrors.NewConflict(qualifiedResource, name, fmt.Errorf("%s", err.(*storage.StorageError).AdditionalErrorMsg))
+	case storage.IsResourceVersionTooOld(err), storage.IsResourceExpired(err):
+		return apierrors.NewResourceExpired(err.(*storage.StorageError).AdditionalErrorMsg)
+	case storage.IsResourceVersionTooLarge(err):
+		status := apierrors.NewTimeoutError(fmt.Sprintf("too large resource version: %s", err.(*storage.StorageError).AdditionalErrorMsg), 1)
+		status.ErrStatus.Details.Causes = []v1.StatusCause{{
+			Type:    v1.CauseTypeResourceVersionTooLarge,
+			Message: "too large resource version",
+		}}
+		return status
+	}
+	if status, ok := err.(*apierrors.StatusError); ok {
+		return status
+	}
+	return apierrors.NewInternalError(err)
+}
+
+// writeError writes err as a status. Errors that do not carry a status are
+// reported as internal errors.
+func writeError(w http.ResponseWriter, err error) {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		status = apierrors.NewInternalError(err)
+	}
+	s := status.Status()
+	writeStatus(w, &s)
+}
+
+// writeStatus writes s, always encoded as JSON.
+func writeStatus(w http.ResponseWriter, s *v1.Status) {
+	code := int(s.Code)
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+	data, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), s)
+	if err != nil {
+		http.Error(w, s.Message, code)
+		return
+	}
+	w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+	w.WriteHeader(code)
+	w.Write(data)
+}
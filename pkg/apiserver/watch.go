@@ -0,0 +1,86 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+// watch streams the changes of the requested collection as newline delimited
+// v1.WatchEvents in a chunked response, until the client goes away, the
+// timeout_seconds of the request expire or the watch falls behind. In the
+// latter case the client is expected to watch again from the last resource
+// version it received.
+func (s *Server) watch(w http.ResponseWriter, req *http.Request, info *requestInfo) {
+	opts, statusErr := listOptions(req, info)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, apierrors.NewInternalError(fmt.Errorf("unable to start watch - can't get http.Flusher: %#v", w)))
+		return
+	}
+
+	ctx := req.Context()
+	if s := req.URL.Query().Get("timeout_seconds"); len(s) != 0 {
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || seconds <= 0 {
+			writeError(w, apierrors.NewBadRequest(fmt.Sprintf("invalid timeout_seconds %q", s)))
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(seconds)*time.Second)
+		defer cancel()
+	}
+
+	watcher, err := s.storage.Watch(ctx, info.keyPrefix(), opts)
+	if err != nil {
+		if storage.IsInvalidObj(err) {
+			writeError(w, apierrors.NewBadRequest(err.(*storage.StorageError).AdditionalErrorMsg))
+			return
+		}
+		writeError(w, storageError(err, info.resource.groupResource(), ""))
+		return
+	}
+	defer watcher.Stop()
+
+	w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := scheme.Codecs.LegacyCodec(info.resource.groupVersion)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+			object, err := runtime.Encode(encoder, event.Object)
+			if err != nil {
+				return
+			}
+			data, err := json.Marshal(&v1.WatchEvent{Type: string(event.Type), Object: object})
+			if err != nil {
+				return
+			}
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
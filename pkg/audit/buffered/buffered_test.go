@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffered
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+)
+
+// fakeBackend records the batches it receives.
+type fakeBackend struct {
+	lock     sync.Mutex
+	batches  [][]*auditv1.Event
+	shutdown bool
+}
+
+func (b *fakeBackend) ProcessEvents(events ...*auditv1.Event) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.batches = append(b.batches, events)
+	return true
+}
+
+func (b *fakeBackend) Run(stopCh <-chan struct{}) error { return nil }
+
+func (b *fakeBackend) Shutdown() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.shutdown = true
+}
+
+func (b *fakeBackend) String() string { return "fake" }
+
+func (b *fakeBackend) events() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	n := 0
+	for _, batch := range b.batches {
+		n += len(batch)
+	}
+	return n
+}
+
+func newEvents(n int) []*auditv1.Event {
+	events := make([]*auditv1.Event, n)
+	for i := range events {
+		events[i] = &auditv1.Event{}
+	}
+	return events
+}
+
+func TestBufferedBackendBatches(t *testing.T) {
+	delegate := &fakeBackend{}
+	backend := NewBackend(delegate, BatchConfig{BufferSize: 100, MaxBatchSize: 3, MaxBatchWait: time.Hour})
+	stopCh := make(chan struct{})
+	if err := backend.Run(stopCh); err != nil {
+		t.Fatal(err)
+	}
+	if !backend.ProcessEvents(newEvents(7)...) {
+		t.Fatal("expected the events to be queued")
+	}
+	close(stopCh)
+	backend.Shutdown()
+
+	if delegate.events() != 7 || !delegate.shutdown {
+		t.Errorf("expected 7 events and the delegate shut down, got %d %v", delegate.events(), delegate.shutdown)
+	}
+	for _, batch := range delegate.batches {
+		if len(batch) > 3 {
+			t.Errorf("expected batches of at most 3 events, got %d", len(batch))
+		}
+	}
+	if backend.ProcessEvents(newEvents(1)...) {
+		t.Errorf("expected events after shutdown to be dropped")
+	}
+}
+
+func TestBufferedBackendMaxWait(t *testing.T) {
+	delegate := &fakeBackend{}
+	backend := NewBackend(delegate, BatchConfig{BufferSize: 100, MaxBatchSize: 10, MaxBatchWait: 10 * time.Millisecond})
+	stopCh := make(chan struct{})
+	defer func() {
+		close(stopCh)
+		backend.Shutdown()
+	}()
+	backend.Run(stopCh)
+	backend.ProcessEvents(newEvents(2)...)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for delegate.events() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the batch")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBufferedBackendFull(t *testing.T) {
+	delegate := &fakeBackend{}
+	// not running, so nothing drains the buffer
+	backend := NewBackend(delegate, BatchConfig{BufferSize: 2, MaxBatchSize: 1})
+	if !backend.ProcessEvents(newEvents(2)...) {
+		t.Errorf("expected the events to fit")
+	}
+	if backend.ProcessEvents(newEvents(1)...) {
+		t.Errorf("expected the event to be dropped")
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+)
+
+// EventString creates a 1-line text representation of an audit event, using a subset of the
+// information in the event struct.
+func EventString(ev *auditv1.Event) string {
+	username := "<none>"
+	groups := "<none>"
+	if len(ev.User.Username) > 0 {
+		username = ev.User.Username
+		if len(ev.User.Groups) > 0 {
+			groups = strings.Join(ev.User.Groups, ",")
+		}
+	}
+
+	namespace := "<none>"
+	name := "<none>"
+	kind := "<none>"
+	if ev.ObjectRef != nil {
+		if len(ev.ObjectRef.Namespace) != 0 {
+			namespace = ev.ObjectRef.Namespace
+		}
+		if len(ev.ObjectRef.Name) != 0 {
+			name = ev.ObjectRef.Name
+		}
+		kind = ev.ObjectRef.Kind
+	}
+
+	return fmt.Sprintf("%s AUDIT: id=%q user=%q groups=%q verb=%q kind=%q namespace=%q name=%q uri=%q response=\"%d\"",
+		ev.RequestReceivedTimestamp.Format("2006-01-02T15:04:05.000000000Z07:00"), ev.AuditID,
+		username, groups, ev.Verb, kind, namespace, name, ev.RequestURI, ev.ResponseCode)
+}
+
+// HandlePluginError is called when a backend fails to deliver events. The
+// events are written to the standard logger, so they are not lost.
+func HandlePluginError(plugin string, err error, impacted ...*auditv1.Event) {
+	log.Printf("Error in audit plugin '%s' affecting %d audit events: %v", plugin, len(impacted), err)
+	for _, ev := range impacted {
+		log.Print(EventString(ev))
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package log writes audit events as JSON lines, one event per line.
+package log
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	"github.com/opencarry/carry/pkg/audit"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+// PluginName is the name of this plugin, to be used in help and logs.
+const PluginName = "log"
+
+type backend struct {
+	// lock serializes the writes of the events, so lines do not interleave.
+	lock    sync.Mutex
+	out     io.Writer
+	encoder runtime.Encoder
+}
+
+var _ audit.Backend = &backend{}
+
+// NewBackend returns a backend writing the events to out in JSON, one per
+// line. out is closed on Shutdown if it is an io.Closer.
+func NewBackend(out io.Writer) audit.Backend {
+	return &backend{
+		out:     out,
+		encoder: scheme.Codecs.LegacyCodec(auditv1.SchemeGroupVersion),
+	}
+}
+
+func (b *backend) ProcessEvents(events ...*auditv1.Event) bool {
+	success := true
+	for _, ev := range events {
+		success = b.logEvent(ev) && success
+	}
+	return success
+}
+
+func (b *backend) logEvent(ev *auditv1.Event) bool {
+	line, err := runtime.Encode(b.encoder, ev)
+	if err != nil {
+		audit.HandlePluginError(PluginName, fmt.Errorf("unable to encode audit event: %v", err), ev)
+		return false
+	}
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		line = append(line, '\n')
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, err := b.out.Write(line); err != nil {
+		audit.HandlePluginError(PluginName, err, ev)
+		return false
+	}
+	return true
+}
+
+func (b *backend) Run(stopCh <-chan struct{}) error {
+	return nil
+}
+
+func (b *backend) Shutdown() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if closer, ok := b.out.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			audit.HandlePluginError(PluginName, err)
+		}
+	}
+}
+
+func (b *backend) String() string {
+	return fmt.Sprintf("%s<%T>", PluginName, b.out)
+}
@@ -0,0 +1,99 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	carryv1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func TestBackend(t *testing.T) {
+	out := &bytes.Buffer{}
+	backend := NewBackend(out)
+	events := []*auditv1.Event{
+		{AuditID: "1", Level: auditv1.LevelMetadata, Verb: "create", User: carryv1.UserInfo{Username: "alice"},
+			ObjectRef: &carryv1.ObjectReference{Kind: "pod", Namespace: "default", Name: "web"}, ResponseCode: 201},
+		{AuditID: "2", Level: auditv1.LevelRequest, Verb: "patch", RequestObject: json.RawMessage(`{"a":"b"}`)},
+	}
+	if !backend.ProcessEvents(events...) {
+		t.Fatal("expected the events to be written")
+	}
+
+	scanner := bufio.NewScanner(out)
+	for i := 0; scanner.Scan(); i++ {
+		ev := &auditv1.Event{}
+		if err := json.Unmarshal(scanner.Bytes(), ev); err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		if ev.APIVersion != "audit.carry.i/v1" || ev.Kind != "event" || ev.AuditID != events[i].AuditID || ev.Verb != events[i].Verb {
+			t.Errorf("line %d: unexpected event %s", i, scanner.Text())
+		}
+		if i == 1 && string(ev.RequestObject) != `{"a":"b"}` {
+			t.Errorf("unexpected request object %s", ev.RequestObject)
+		}
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 0 {
+		t.Errorf("expected the buffer to be read, %d lines left", lines)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	file, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := fmt.Fprintf(file, "line %d\n", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a write larger than the maximum size is not split
+	if _, err := file.Write([]byte("a long line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("closed\n")); err == nil {
+		t.Errorf("expected writing to a closed file to fail")
+	}
+
+	expected := map[string]string{
+		path:        "a long line\n",
+		path + ".1": "line 4\n",
+		path + ".2": "line 3\n",
+	}
+	for name, content := range expected {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("%s: expected %q, got %q", filepath.Base(name), content, data)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 backups, got %v", err)
+	}
+
+	// the size of an existing file counts
+	file, err = NewRotatingFile(path, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte("0123456789\n"))
+	file.Close()
+	data, _ := ioutil.ReadFile(path)
+	if string(data) != "0123456789\n" {
+		t.Errorf("expected the file to be truncated, got %q", data)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy picks the audit level of requests from an audit policy.
+package policy
+
+import (
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+)
+
+// Checker exposes methods for checking the policy rules.
+type Checker interface {
+	// Level returns the audit level of the request described by ev, from
+	// its user, verb and object reference.
+	Level(ev *auditv1.Event) auditv1.Level
+}
+
+// NewChecker creates a new policy checker.
+func NewChecker(policy *auditv1.Policy) Checker {
+	return &policyChecker{*policy}
+}
+
+// FakeChecker creates a checker that returns a constant level for all requests (for testing).
+func FakeChecker(level auditv1.Level) Checker {
+	return &fakeChecker{level}
+}
+
+type policyChecker struct {
+	auditv1.Policy
+}
+
+func (p *policyChecker) Level(ev *auditv1.Event) auditv1.Level {
+	for _, rule := range p.Rules {
+		if ruleMatches(&rule, ev) {
+			return rule.Level
+		}
+	}
+	return auditv1.LevelNone
+}
+
+// Check whether the rule matches the request described by ev.
+func ruleMatches(r *auditv1.PolicyRule, ev *auditv1.Event) bool {
+	if len(r.Users) > 0 && !hasString(r.Users, ev.User.Username) {
+		return false
+	}
+	if len(r.UserGroups) > 0 {
+		matched := false
+		for _, group := range ev.User.Groups {
+			if hasString(r.UserGroups, group) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.Verbs) > 0 && !hasString(r.Verbs, ev.Verb) {
+		return false
+	}
+
+	if len(r.Kinds) == 0 && len(r.Namespaces) == 0 {
+		return true
+	}
+	// 按kind或namespace过滤的规则不匹配非资源请求
+	if ev.ObjectRef == nil {
+		return false
+	}
+	if len(r.Kinds) > 0 && !hasString(r.Kinds, ev.ObjectRef.Kind) {
+		return false
+	}
+	if len(r.Namespaces) > 0 && !hasString(r.Namespaces, ev.ObjectRef.Namespace) {
+		return false
+	}
+	return true
+}
+
+// Utility function to check whether a string slice contains a string.
+func hasString(slice []string, value string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeChecker struct {
+	level auditv1.Level
+}
+
+func (f *fakeChecker) Level(*auditv1.Event) auditv1.Level {
+	return f.level
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	carryv1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func TestChecker(t *testing.T) {
+	events := map[string]*auditv1.Event{
+		"alice get pod": {
+			Verb:      "get",
+			User:      carryv1.UserInfo{Username: "alice", Groups: []string{"team-a"}},
+			ObjectRef: &carryv1.ObjectReference{Kind: "pod", Namespace: "team-a", Name: "web"},
+		},
+		"bob create configmap": {
+			Verb:      "create",
+			User:      carryv1.UserInfo{Username: "bob", Groups: []string{"team-b"}},
+			ObjectRef: &carryv1.ObjectReference{Kind: "configmap", Namespace: "team-b"},
+		},
+		"alice list nodes": {
+			Verb:      "list",
+			User:      carryv1.UserInfo{Username: "alice", Groups: []string{"team-a"}},
+			ObjectRef: &carryv1.ObjectReference{Kind: "node"},
+		},
+		"bob get healthz": {
+			Verb: "get",
+			User: carryv1.UserInfo{Username: "bob"},
+		},
+	}
+	rules := map[string]auditv1.PolicyRule{
+		"default":          {Level: auditv1.LevelMetadata},
+		"alice":            {Level: auditv1.LevelRequest, Users: []string{"alice"}},
+		"team-b":           {Level: auditv1.LevelRequest, UserGroups: []string{"team-b"}},
+		"writes":           {Level: auditv1.LevelRequestResponse, Verbs: []string{"create", "update", "patch", "delete"}},
+		"configmaps":       {Level: auditv1.LevelRequestResponse, Kinds: []string{"configmap"}},
+		"team-a namespace": {Level: auditv1.LevelRequest, Namespaces: []string{"team-a"}},
+		"cluster scope":    {Level: auditv1.LevelRequest, Namespaces: []string{""}},
+		"pod gets":         {Level: auditv1.LevelNone, Verbs: []string{"get"}, Kinds: []string{"pod"}},
+	}
+	tests := []struct {
+		rules    []string
+		event    string
+		expected auditv1.Level
+	}{
+		{[]string{"default"}, "bob get healthz", auditv1.LevelMetadata},
+		{[]string{}, "bob get healthz", auditv1.LevelNone},
+		{[]string{"alice", "default"}, "alice get pod", auditv1.LevelRequest},
+		{[]string{"alice", "default"}, "bob create configmap", auditv1.LevelMetadata},
+		{[]string{"team-b"}, "bob create configmap", auditv1.LevelRequest},
+		{[]string{"team-b"}, "bob get healthz", auditv1.LevelNone},
+		{[]string{"writes"}, "bob create configmap", auditv1.LevelRequestResponse},
+		{[]string{"writes"}, "alice list nodes", auditv1.LevelNone},
+		{[]string{"configmaps"}, "bob create configmap", auditv1.LevelRequestResponse},
+		{[]string{"configmaps"}, "bob get healthz", auditv1.LevelNone},
+		{[]string{"team-a namespace"}, "alice get pod", auditv1.LevelRequest},
+		{[]string{"team-a namespace"}, "alice list nodes", auditv1.LevelNone},
+		{[]string{"cluster scope"}, "alice list nodes", auditv1.LevelRequest},
+		{[]string{"cluster scope"}, "bob get healthz", auditv1.LevelNone},
+		// the first rule matching wins
+		{[]string{"pod gets", "alice", "default"}, "alice get pod", auditv1.LevelNone},
+		{[]string{"pod gets", "alice", "default"}, "alice list nodes", auditv1.LevelRequest},
+	}
+	for _, test := range tests {
+		policy := &auditv1.Policy{}
+		for _, rule := range test.rules {
+			policy.Rules = append(policy.Rules, rules[rule])
+		}
+		if level := NewChecker(policy).Level(events[test.event]); level != test.expected {
+			t.Errorf("rules %v, %s: expected level %q, got %q", test.rules, test.event, test.expected, level)
+		}
+	}
+}
+
+func TestLoadPolicyFromFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(valid, []byte(`api_version: audit.carry.i/v1
+kind: policy
+rules:
+- level: none
+  verbs: ["get", "list", "watch"]
+- level: request_response
+  kinds: ["configmap"]
+  namespaces: ["kube-system"]
+- level: metadata
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicyFromFile(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.Rules) != 3 || policy.Rules[1].Level != auditv1.LevelRequestResponse || policy.Rules[1].Namespaces[0] != "kube-system" {
+		t.Errorf("unexpected policy %#v", policy)
+	}
+
+	for name, content := range map[string]string{
+		"invalid level": "api_version: audit.carry.i/v1\nkind: policy\nrules:\n- level: everything\n",
+		"no rules":      "api_version: audit.carry.i/v1\nkind: policy\nrules: []\n",
+		"other kind":    "api_version: carry.i/v1\nkind: configmap\n",
+		"unknown field": "api_version: audit.carry.i/v1\nkind: policy\nrules:\n- level: none\n  resources: [pods]\n",
+	} {
+		path := filepath.Join(dir, "invalid.yaml")
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPolicyFromFile(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := LoadPolicyFromFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+)
+
+// LoadPolicyFromFile loads the audit policy in filePath, in YAML or JSON.
+func LoadPolicyFromFile(filePath string) (*auditv1.Policy, error) {
+	if filePath == "" {
+		return nil, fmt.Errorf("file path not specified")
+	}
+	policyDef, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file path %q: %+v", filePath, err)
+	}
+
+	policy, err := LoadPolicyFromBytes(policyDef)
+	if err != nil {
+		return nil, fmt.Errorf("%v: from file %v", err.Error(), filePath)
+	}
+	return policy, nil
+}
+
+// LoadPolicyFromBytes decodes and validates the audit policy in policyDef.
+func LoadPolicyFromBytes(policyDef []byte) (*auditv1.Policy, error) {
+	policy := &auditv1.Policy{}
+	obj, gvk, err := scheme.Codecs.UniversalStrictDeserializer().Decode(policyDef, nil, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding: %v", err)
+	}
+	if obj != policy || gvk.GroupVersion() != auditv1.SchemeGroupVersion {
+		return nil, fmt.Errorf("failed decoding: expected a %v policy, got %v", auditv1.SchemeGroupVersion, gvk)
+	}
+
+	for i, rule := range policy.Rules {
+		if !rule.Level.IsValid() {
+			return nil, fmt.Errorf("invalid audit level %q in rule %d", rule.Level, i)
+		}
+	}
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("loaded illegal policy with 0 rules")
+	}
+	return policy, nil
+}
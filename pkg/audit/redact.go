@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+
+	carryv1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+// Redacted replaces the values of redacted fields in the objects of the
+// audit events.
+const Redacted = "<redacted>"
+
+// redactedFields 各kind中需要在审计日志里隐藏值的顶层字段，字段本身是map，只保留key
+var redactedFields = map[schema.GroupKind][]string{
+	carryv1.Kind("configmap"): {"binary_data"},
+}
+
+// redact returns data, an object of the kind ref refers to, a list of such
+// objects or a patch of one, in JSON, with the values of the redacted fields
+// of the kind replaced by Redacted.
+func redact(ref *carryv1.ObjectReference, data []byte) json.RawMessage {
+	if ref == nil {
+		return data
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return data
+	}
+	fields := redactedFields[gv.WithKind(ref.Kind).GroupKind()]
+	if len(fields) == 0 {
+		return data
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		// an object, an apply configuration, a merge patch or a list
+		redactObject(value, fields)
+		if items, ok := value["items"].([]interface{}); ok {
+			for _, item := range items {
+				if item, ok := item.(map[string]interface{}); ok {
+					redactObject(item, fields)
+				}
+			}
+		}
+	case []interface{}:
+		// a JSON patch
+		for _, operation := range value {
+			if operation, ok := operation.(map[string]interface{}); ok {
+				redactOperation(operation, fields)
+			}
+		}
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactObject(obj map[string]interface{}, fields []string) {
+	for _, field := range fields {
+		redactValue(obj, field)
+	}
+}
+
+// redactValue replaces the values of the map under key in obj.
+func redactValue(obj map[string]interface{}, key string) {
+	switch m := obj[key].(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			if v != nil {
+				m[k] = Redacted
+			}
+		}
+	case nil:
+	default:
+		obj[key] = Redacted
+	}
+}
+
+// redactOperation redacts the value of a JSON patch operation on one of
+// fields or on one of their keys.
+func redactOperation(operation map[string]interface{}, fields []string) {
+	path, _ := operation["path"].(string)
+	for _, field := range fields {
+		switch {
+		case path == "/"+field:
+			redactValue(operation, "value")
+		case strings.HasPrefix(path, "/"+field+"/"):
+			if _, ok := operation["value"]; ok {
+				operation["value"] = Redacted
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	carryv1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+func TestRedact(t *testing.T) {
+	configMap := &carryv1.ObjectReference{Kind: "configmap", APIVersion: "carry.i/v1"}
+	pod := &carryv1.ObjectReference{Kind: "pod", APIVersion: "carry.i/v1"}
+	tests := []struct {
+		name     string
+		ref      *carryv1.ObjectReference
+		data     string
+		expected string
+	}{{
+		name:     "object",
+		ref:      configMap,
+		data:     `{"data":{"a":"b"},"binary_data":{"k1":"c2VjcmV0","k2":"c2VjcmV0"}}`,
+		expected: `{"data":{"a":"b"},"binary_data":{"k1":"<redacted>","k2":"<redacted>"}}`,
+	}, {
+		name:     "list",
+		ref:      configMap,
+		data:     `{"items":[{"binary_data":{"k":"c2VjcmV0"}},{"data":{"a":"b"}}]}`,
+		expected: `{"items":[{"binary_data":{"k":"<redacted>"}},{"data":{"a":"b"}}]}`,
+	}, {
+		name:     "merge patch removing a key",
+		ref:      configMap,
+		data:     `{"binary_data":{"k1":null,"k2":"c2VjcmV0"}}`,
+		expected: `{"binary_data":{"k1":null,"k2":"<redacted>"}}`,
+	}, {
+		name: "json patch",
+		ref:  configMap,
+		data: `[{"op":"add","path":"/binary_data/k","value":"c2VjcmV0"},{"op":"replace","path":"/binary_data","value":{"k":"c2VjcmV0"}},` +
+			`{"op":"remove","path":"/binary_data/old"},{"op":"add","path":"/data/k","value":"v"}]`,
+		expected: `[{"op":"add","path":"/binary_data/k","value":"<redacted>"},{"op":"replace","path":"/binary_data","value":{"k":"<redacted>"}},` +
+			`{"op":"remove","path":"/binary_data/old"},{"op":"add","path":"/data/k","value":"v"}]`,
+	}, {
+		name:     "other kind",
+		ref:      pod,
+		data:     `{"binary_data":{"k":"v"}}`,
+		expected: `{"binary_data":{"k":"v"}}`,
+	}, {
+		name:     "non-resource request",
+		data:     `{"binary_data":{"k":"v"}}`,
+		expected: `{"binary_data":{"k":"v"}}`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var actual, expected interface{}
+			if err := json.Unmarshal(redact(test.ref, []byte(test.data)), &actual); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(test.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected %s, got %v", test.expected, actual)
+			}
+		})
+	}
+}
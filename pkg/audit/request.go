@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	carryv1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/apiserver/request"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+// LogRequestObject adds obj, decoded from the body of the request of ctx, to
+// its audit event if the event is at the request level or higher.
+func LogRequestObject(ctx context.Context, obj runtime.Object) {
+	ev := request.AuditEventFrom(ctx)
+	if ev == nil || ev.Level.Less(auditv1.LevelRequest) || ev.RequestObject != nil {
+		return
+	}
+	ev.RequestObject = encodeObject(ev, obj)
+}
+
+// LogRequestPatch adds patch, the body of the patch or apply request of ctx,
+// to its audit event if the event is at the request level or higher.
+// Apply configurations in YAML are converted to JSON.
+func LogRequestPatch(ctx context.Context, patch []byte) {
+	ev := request.AuditEventFrom(ctx)
+	if ev == nil || ev.Level.Less(auditv1.LevelRequest) || ev.RequestObject != nil {
+		return
+	}
+	data, err := yaml.YAMLToJSON(patch)
+	if err != nil || !json.Valid(data) {
+		return
+	}
+	ev.RequestObject = redact(ev.ObjectRef, data)
+}
+
+// LogResponseObject completes the object reference of the audit event of
+// the request of ctx with the uid and resource version of obj, sent back to
+// the client, if obj is the object referred to. obj itself is added to the
+// event if the event is at the request_response level.
+func LogResponseObject(ctx context.Context, obj runtime.Object) {
+	ev := request.AuditEventFrom(ctx)
+	if ev == nil {
+		return
+	}
+	if ref := ev.ObjectRef; ref != nil {
+		kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+		metadata, accessorErr := carryv1.Accessor(obj)
+		// 创建请求的url里没有name，以返回的对象为准
+		if err == nil && accessorErr == nil && kinds[0].Kind == ref.Kind &&
+			(len(ref.Name) == 0 || ref.Name == metadata.GetName()) {
+			ref.Name = metadata.GetName()
+			ref.UID = metadata.GetUID()
+			ref.ResourceVersion = metadata.GetResourceVersion()
+		}
+	}
+	if ev.Level.Less(auditv1.LevelRequestResponse) {
+		return
+	}
+	ev.ResponseObject = encodeObject(ev, obj)
+}
+
+// encodeObject returns obj in JSON, with the secret data the kind of ev
+// holds redacted. It returns nil if obj cannot be encoded, the event is
+// recorded without it.
+func encodeObject(ev *auditv1.Event, obj runtime.Object) json.RawMessage {
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil
+	}
+	data, err := runtime.Encode(scheme.Codecs.LegacyCodec(kinds[0].GroupVersion()), obj)
+	if err != nil {
+		return nil
+	}
+	return redact(ev.ObjectRef, data)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records who sent which request to the API, and what became
+// of it, as audit events handed to a backend.
+//
+// The api server builds an event for every request whose audit level,
+// picked by the policy, is not none. At the request level the object sent
+// by the client is added to the event, at the request_response level the
+// object returned too. Fields holding secret data, like the binary_data of
+// configmaps, are redacted from the objects.
+package audit
+
+import (
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+)
+
+// Sink receives the audit events.
+type Sink interface {
+	// ProcessEvents handles events. It must not block the request it is
+	// called for for long, nor keep or modify the events. It returns false
+	// if an event was dropped.
+	ProcessEvents(events ...*auditv1.Event) bool
+}
+
+// Backend is a Sink that may run in the background.
+type Backend interface {
+	Sink
+
+	// Run will initialize the backend. It must not block, but may run go routines in the background. If
+	// stopCh is closed, it is supposed to stop them. Run will be called before the first call to ProcessEvents.
+	Run(stopCh <-chan struct{}) error
+
+	// Shutdown will synchronously shut down the backend while making sure that all pending
+	// events are delivered. It can be assumed that this method is called after
+	// the stopCh channel passed to the Run method has been closed.
+	Shutdown()
+
+	// Returns the backend PluginName.
+	String() string
+}
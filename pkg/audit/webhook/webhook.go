@@ -0,0 +1,144 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements the audit.Backend interface using HTTP webhooks.
+//
+// The events are batched in the background and POSTed to the webhook as an
+// audit.carry.i/v1 EventList in JSON. Failed batches are retried with a
+// backoff before they are dropped.
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	"github.com/opencarry/carry/pkg/audit"
+	"github.com/opencarry/carry/pkg/audit/buffered"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+const (
+	// PluginName is the name of this plugin, to be used in help and logs.
+	PluginName = "webhook"
+
+	// DefaultInitialBackoff is the default amount of time to wait before
+	// retrying sending audit events through a webhook.
+	DefaultInitialBackoff = 10 * time.Second
+
+	// maxRetries is how many times a batch is resent before it is dropped.
+	maxRetries = 3
+	// defaultTimeout is how long a single POST to the webhook may take.
+	defaultTimeout = 30 * time.Second
+)
+
+type backend struct {
+	url            string
+	client         *http.Client
+	initialBackoff time.Duration
+}
+
+// NewBackend returns an audit backend that sends events over HTTP to the
+// webhook at webhookURL, in batches configured by config. client is an
+// http.Client with a timeout if nil.
+func NewBackend(webhookURL string, client *http.Client, config buffered.BatchConfig) (audit.Backend, error) {
+	b, err := newBackend(webhookURL, client, DefaultInitialBackoff)
+	if err != nil {
+		return nil, err
+	}
+	return buffered.NewBackend(b, config), nil
+}
+
+func newBackend(webhookURL string, client *http.Client, initialBackoff time.Duration) (*backend, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit webhook url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid audit webhook url %q: the scheme must be http or https", webhookURL)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &backend{url: u.String(), client: client, initialBackoff: initialBackoff}, nil
+}
+
+func (b *backend) Run(stopCh <-chan struct{}) error {
+	return nil
+}
+
+func (b *backend) Shutdown() {
+	// nothing to do here
+}
+
+func (b *backend) ProcessEvents(ev ...*auditv1.Event) bool {
+	if err := b.processEvents(ev...); err != nil {
+		audit.HandlePluginError(PluginName, err, ev...)
+		return false
+	}
+	return true
+}
+
+func (b *backend) processEvents(ev ...*auditv1.Event) error {
+	list := &auditv1.EventList{}
+	for _, e := range ev {
+		list.Items = append(list.Items, *e)
+	}
+	body, err := runtime.Encode(scheme.Codecs.LegacyCodec(auditv1.SchemeGroupVersion), list)
+	if err != nil {
+		return err
+	}
+
+	backoff := b.initialBackoff
+	for retry := 0; ; retry++ {
+		err = b.send(body)
+		if err == nil || retry == maxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send POSTs body to the webhook once.
+func (b *backend) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", runtime.ContentTypeJSON)
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed calling the audit webhook: %v", err)
+	}
+	defer resp.Body.Close()
+	// 读完响应体以便复用连接
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (b *backend) String() string {
+	return PluginName
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	auditv1 "github.com/opencarry/carry/pkg/apis/audit.carry.i/v1"
+	"github.com/opencarry/carry/pkg/audit/buffered"
+)
+
+func TestWebhook(t *testing.T) {
+	var lock sync.Mutex
+	var received []auditv1.Event
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		list := &auditv1.EventList{}
+		if err := json.NewDecoder(req.Body).Decode(list); err != nil {
+			t.Errorf("failed to decode the events: %v", err)
+		}
+		if list.APIVersion != "audit.carry.i/v1" || list.Kind != "eventlist" {
+			t.Errorf("unexpected list %s/%s", list.APIVersion, list.Kind)
+		}
+		received = append(received, list.Items...)
+	}))
+	defer server.Close()
+
+	b, err := newBackend(server.URL, nil, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := buffered.NewBackend(b, buffered.BatchConfig{BufferSize: 10, MaxBatchSize: 10, MaxBatchWait: time.Hour})
+	stopCh := make(chan struct{})
+	backend.Run(stopCh)
+	backend.ProcessEvents(&auditv1.Event{AuditID: "1", Verb: "create"}, &auditv1.Event{AuditID: "2", Verb: "delete"})
+	close(stopCh)
+	backend.Shutdown()
+
+	lock.Lock()
+	defer lock.Unlock()
+	// batches are sent concurrently
+	sort.Slice(received, func(i, j int) bool { return received[i].AuditID < received[j].AuditID })
+	if len(received) != 2 || received[0].AuditID != "1" || received[1].Verb != "delete" {
+		t.Errorf("unexpected events %#v", received)
+	}
+}
+
+func TestWebhookDropsAfterRetries(t *testing.T) {
+	var lock sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	b, err := newBackend(server.URL, nil, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ProcessEvents(&auditv1.Event{AuditID: "1"}) {
+		t.Errorf("expected the event to be dropped")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if calls != maxRetries+1 {
+		t.Errorf("expected %d calls, got %d", maxRetries+1, calls)
+	}
+}
+
+func TestNewBackendInvalidURL(t *testing.T) {
+	for _, url := range []string{"ftp://example.com", "://"} {
+		if _, err := NewBackend(url, nil, buffered.NewDefaultBatchConfig()); err == nil {
+			t.Errorf("%q: expected an error", url)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package x509
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opencarry/carry/pkg/authentication/user"
+)
+
+// newCert returns a certificate for subject signed by parent, self-signed if
+// parent is nil.
+func newCert(t *testing.T, subject pkix.Name, isCA bool, usages []x509.ExtKeyUsage, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		ExtKeyUsage:           usages,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestX509(t *testing.T) {
+	clientAuth := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	ca, caKey := newCert(t, pkix.Name{CommonName: "ca"}, true, nil, nil, nil)
+	alice, _ := newCert(t, pkix.Name{CommonName: "alice", Organization: []string{"team-a", "team-b"}}, false, clientAuth, ca, caKey)
+	noName, _ := newCert(t, pkix.Name{Organization: []string{"team-a"}}, false, clientAuth, ca, caKey)
+	server, _ := newCert(t, pkix.Name{CommonName: "server"}, false, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, ca, caKey)
+	otherCA, otherKey := newCert(t, pkix.Name{CommonName: "other"}, true, nil, nil, nil)
+	mallory, _ := newCert(t, pkix.Name{CommonName: "alice"}, false, clientAuth, otherCA, otherKey)
+
+	opts := DefaultVerifyOptions()
+	opts.Roots = x509.NewCertPool()
+	opts.Roots.AddCert(ca)
+	auth := New(opts, CommonNameUserConversion)
+
+	tests := []struct {
+		name     string
+		tls      *tls.ConnectionState
+		expected *user.DefaultInfo
+		err      bool
+	}{
+		{name: "no tls"},
+		{name: "no certificate", tls: &tls.ConnectionState{}},
+		{
+			name:     "valid",
+			tls:      &tls.ConnectionState{PeerCertificates: []*x509.Certificate{alice}},
+			expected: &user.DefaultInfo{Name: "alice", Groups: []string{"team-a", "team-b"}},
+		},
+		{name: "no common name", tls: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{noName}}},
+		{name: "server certificate", tls: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{server}}, err: true},
+		{name: "untrusted", tls: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{mallory}}, err: true},
+		{name: "untrusted intermediate", tls: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{mallory, otherCA}}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &http.Request{TLS: test.tls}
+			resp, ok, err := auth.AuthenticateRequest(req)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if ok != (test.expected != nil) {
+				t.Fatalf("expected authenticated %v, got %v", test.expected != nil, ok)
+			}
+			if ok && !reflect.DeepEqual(resp.User, test.expected) {
+				t.Errorf("expected %#v, got %#v", test.expected, resp.User)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package tokenfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opencarry/carry/pkg/authentication/user"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTokenFile(t *testing.T) {
+	auth, err := NewCSV(writeFile(t, `
+# token,user,uid,groups
+token1,alice,uid1
+token2,bob,uid2,"team-a, team-b"
+token3,carol,uid3,,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]*user.DefaultInfo{
+		"token1": {Name: "alice", UID: "uid1"},
+		"token2": {Name: "bob", UID: "uid2", Groups: []string{"team-a", "team-b"}},
+		"token3": {Name: "carol", UID: "uid3"},
+		"token4": nil,
+		"":       nil,
+	}
+	for token, expected := range tests {
+		resp, ok, err := auth.AuthenticateToken(context.Background(), token)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", token, err)
+			continue
+		}
+		if expected == nil {
+			if ok {
+				t.Errorf("%q: expected the token not to be authenticated, got %#v", token, resp.User)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("%q: expected the token to be authenticated", token)
+			continue
+		}
+		if !reflect.DeepEqual(resp.User, expected) {
+			t.Errorf("%q: expected %#v, got %#v", token, expected, resp.User)
+		}
+	}
+}
+
+func TestBadTokenFile(t *testing.T) {
+	for _, content := range []string{
+		"token1,alice\n",
+		"token1,alice,uid1\ntoken1,bob,uid2\n",
+		",alice,uid1\n",
+	} {
+		if _, err := NewCSV(writeFile(t, content)); err == nil {
+			t.Errorf("%q: expected an error", content)
+		}
+	}
+	if _, err := NewCSV(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
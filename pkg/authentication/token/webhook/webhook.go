@@ -0,0 +1,125 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook authenticates bearer tokens by asking a token review
+// service, a local stand-in for the TokenReview API of an identity provider.
+//
+// The authenticator POSTs a TokenReview carrying the token to the service,
+// which answers with the TokenReview and its status filled in: whether the
+// token is authenticated and, if so, the user it belongs to.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/authenticator"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+const (
+	// defaultTimeout is how long the authenticator waits for the service.
+	defaultTimeout = 10 * time.Second
+	// maxResponseBytes is the limit on the size of the response of the service.
+	maxResponseBytes = 1024 * 1024
+)
+
+var _ authenticator.Token = &WebhookTokenAuthenticator{}
+
+type WebhookTokenAuthenticator struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// New creates a token authenticator that reviews tokens with the service at
+// serviceURL. client is http.DefaultClient if nil.
+func New(serviceURL string, client *http.Client) (*WebhookTokenAuthenticator, error) {
+	u, err := url.Parse(serviceURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token review url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid token review url %q: the scheme must be http or https", serviceURL)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookTokenAuthenticator{url: u.String(), client: client, timeout: defaultTimeout}, nil
+}
+
+// AuthenticateToken implements the authenticator.Token interface.
+func (w *WebhookTokenAuthenticator) AuthenticateToken(ctx context.Context, token string) (*authenticator.Response, bool, error) {
+	review := &v1.TokenReview{Spec: v1.TokenReviewSpec{Token: token}}
+	body, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), review)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", runtime.ContentTypeJSON)
+	req.Header.Set("Accept", runtime.ContentTypeJSON)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed calling the token review service: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed calling the token review service: %v", err)
+	}
+	if len(data) > maxResponseBytes {
+		return nil, false, fmt.Errorf("token review response larger than %d bytes", maxResponseBytes)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("token review service returned unexpected response code %d", resp.StatusCode)
+	}
+
+	result := &v1.TokenReview{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, false, fmt.Errorf("failed to decode the token review response: %v", err)
+	}
+	status := result.Status
+	if len(status.Error) != 0 {
+		return nil, false, errors.New(status.Error)
+	}
+	if !status.Authenticated {
+		return nil, false, nil
+	}
+	return &authenticator.Response{
+		User: &user.DefaultInfo{
+			Name:   status.User.Username,
+			UID:    status.User.UID,
+			Groups: status.User.Groups,
+			Extra:  status.User.Extra,
+		},
+	}, true, nil
+}
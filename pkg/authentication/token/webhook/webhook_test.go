@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+)
+
+// newReviewServer serves a token review service that knows a single token.
+func newReviewServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		review := &v1.TokenReview{}
+		if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+			http.Error(w, "bad review", http.StatusBadRequest)
+			return
+		}
+		switch review.Spec.Token {
+		case "good":
+			review.Status = v1.TokenReviewStatus{Authenticated: true, User: v1.UserInfo{
+				Username: "alice",
+				UID:      "1",
+				Groups:   []string{"team-a"},
+				Extra:    map[string][]string{"scopes": {"read"}},
+			}}
+		case "broken":
+			review.Status = v1.TokenReviewStatus{Error: "identity provider unavailable"}
+		case "fail":
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(review)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	auth, err := New(newReviewServer(t).URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, ok, err := auth.AuthenticateToken(context.Background(), "good")
+	if err != nil || !ok {
+		t.Fatalf("expected the token to be authenticated, got %v %v", ok, err)
+	}
+	expected := &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"team-a"}, Extra: map[string][]string{"scopes": {"read"}}}
+	if !reflect.DeepEqual(resp.User, expected) {
+		t.Errorf("expected %#v, got %#v", expected, resp.User)
+	}
+
+	if _, ok, err := auth.AuthenticateToken(context.Background(), "unknown"); ok || err != nil {
+		t.Errorf("expected an unknown token not to be authenticated, got %v %v", ok, err)
+	}
+	for _, token := range []string{"broken", "fail"} {
+		if _, ok, err := auth.AuthenticateToken(context.Background(), token); ok || err == nil {
+			t.Errorf("%s: expected an error, got %v %v", token, ok, err)
+		}
+	}
+}
+
+func TestNewInvalidURL(t *testing.T) {
+	for _, url := range []string{"", "ftp://example.com", "://"} {
+		if _, err := New(url, nil); err == nil {
+			t.Errorf("%q: expected an error", url)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbac implements the authorizer.Authorizer interface using roles base access control.
+//
+// The roles and bindings of the rbac.carry.i/v1 group are read from storage
+// on every request. A ClusterRoleBinding grants the rules of its ClusterRole
+// in every namespace and on cluster scoped resources; a RoleBinding grants
+// the rules of its Role, or of a ClusterRole, only within its own namespace.
+// A request is allowed if any granted rule matches its verb, api group,
+// resource, subresource and object name, otherwise the authorizer has no
+// opinion on it.
+//
+// Anyone allowed to create or update roles and bindings can grant themselves
+// any permission: access to the rbac.carry.i resources is to be given to
+// administrators only.
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/authorization/authorizer"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+)
+
+type RBACAuthorizer struct {
+	storage storage.Interface
+}
+
+var _ authorizer.Authorizer = &RBACAuthorizer{}
+
+// New returns an authorizer that grants the permissions of the roles bound
+// in s.
+func New(s storage.Interface) *RBACAuthorizer {
+	return &RBACAuthorizer{storage: s}
+}
+
+// Authorize implements authorizer.Authorizer.
+func (r *RBACAuthorizer) Authorize(ctx context.Context, requestAttributes authorizer.Attributes) (authorizer.Decision, string, error) {
+	user := requestAttributes.GetUser()
+	if user == nil || !requestAttributes.IsResourceRequest() {
+		return authorizer.DecisionNoOpinion, "", nil
+	}
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.list(ctx, storage.KeyPrefix("clusterrolebinding", ""), clusterRoleBindings); err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		subject, ok := appliesTo(user, binding.Subjects)
+		if !ok {
+			continue
+		}
+		rules, err := r.rules(ctx, binding.RoleRef, "")
+		if err != nil {
+			return authorizer.DecisionNoOpinion, "", err
+		}
+		if RulesAllow(requestAttributes, rules...) {
+			return authorizer.DecisionAllow, fmt.Sprintf("RBAC: allowed by ClusterRoleBinding %q of ClusterRole %q to %s %q",
+				binding.Name, binding.RoleRef.Name, subject.Kind, subject.Name), nil
+		}
+	}
+
+	namespace := requestAttributes.GetNamespace()
+	if len(namespace) == 0 {
+		return authorizer.DecisionNoOpinion, "", nil
+	}
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := r.list(ctx, storage.KeyPrefix("rolebinding", namespace), roleBindings); err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	for _, binding := range roleBindings.Items {
+		subject, ok := appliesTo(user, binding.Subjects)
+		if !ok {
+			continue
+		}
+		rules, err := r.rules(ctx, binding.RoleRef, namespace)
+		if err != nil {
+			return authorizer.DecisionNoOpinion, "", err
+		}
+		if RulesAllow(requestAttributes, rules...) {
+			return authorizer.DecisionAllow, fmt.Sprintf("RBAC: allowed by RoleBinding %q of %s %q to %s %q in namespace %q",
+				binding.Name, binding.RoleRef.Kind, binding.RoleRef.Name, subject.Kind, subject.Name, namespace), nil
+		}
+	}
+	return authorizer.DecisionNoOpinion, "", nil
+}
+
+func (r *RBACAuthorizer) list(ctx context.Context, keyPrefix string, listObj runtime.Object) error {
+	return r.storage.List(ctx, keyPrefix, storage.ListOptions{Predicate: storage.Everything}, listObj)
+}
+
+// rules returns the rules of the role roleRef refers to, Roles are looked up
+// in namespace. A role that does not exist grants nothing.
+func (r *RBACAuthorizer) rules(ctx context.Context, roleRef rbacv1.RoleRef, namespace string) ([]rbacv1.PolicyRule, error) {
+	switch roleRef.Kind {
+	case rbacv1.ClusterRoleKind:
+		role := &rbacv1.ClusterRole{}
+		if err := r.storage.Get(ctx, storage.Key("clusterrole", "", roleRef.Name), storage.GetOptions{IgnoreNotFound: true}, role); err != nil {
+			return nil, err
+		}
+		return role.Rules, nil
+	case rbacv1.RoleKind:
+		if len(namespace) == 0 {
+			return nil, nil
+		}
+		role := &rbacv1.Role{}
+		if err := r.storage.Get(ctx, storage.Key("role", namespace, roleRef.Name), storage.GetOptions{IgnoreNotFound: true}, role); err != nil {
+			return nil, err
+		}
+		return role.Rules, nil
+	}
+	return nil, nil
+}
+
+// appliesTo returns the first of subjects that is user or one of its groups.
+func appliesTo(user user.Info, subjects []rbacv1.Subject) (*rbacv1.Subject, bool) {
+	for i := range subjects {
+		subject := &subjects[i]
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			if user.GetName() == subject.Name {
+				return subject, true
+			}
+		case rbacv1.GroupKind:
+			if has(user.GetGroups(), subject.Name) {
+				return subject, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// RulesAllow reports whether any of rules allows the request.
+func RulesAllow(requestAttributes authorizer.Attributes, rules ...rbacv1.PolicyRule) bool {
+	for i := range rules {
+		if RuleAllows(requestAttributes, &rules[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleAllows reports whether rule matches the verb, api group, resource,
+// subresource and name of the request.
+func RuleAllows(requestAttributes authorizer.Attributes, rule *rbacv1.PolicyRule) bool {
+	combinedResource := requestAttributes.GetResource()
+	if len(requestAttributes.GetSubresource()) > 0 {
+		combinedResource = requestAttributes.GetResource() + "/" + requestAttributes.GetSubresource()
+	}
+
+	return VerbMatches(rule, requestAttributes.GetVerb()) &&
+		APIGroupMatches(rule, requestAttributes.GetAPIGroup()) &&
+		ResourceMatches(rule, combinedResource, requestAttributes.GetSubresource()) &&
+		ResourceNameMatches(rule, requestAttributes.GetName())
+}
+
+func VerbMatches(rule *rbacv1.PolicyRule, requestedVerb string) bool {
+	for _, ruleVerb := range rule.Verbs {
+		if ruleVerb == rbacv1.VerbAll {
+			return true
+		}
+		if ruleVerb == requestedVerb {
+			return true
+		}
+	}
+
+	return false
+}
+
+func APIGroupMatches(rule *rbacv1.PolicyRule, requestedGroup string) bool {
+	for _, ruleGroup := range rule.APIGroups {
+		if ruleGroup == rbacv1.APIGroupAll {
+			return true
+		}
+		if ruleGroup == requestedGroup {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ResourceMatches(rule *rbacv1.PolicyRule, combinedRequestedResource, requestedSubresource string) bool {
+	for _, ruleResource := range rule.Resources {
+		// if everything is allowed, we match
+		if ruleResource == rbacv1.ResourceAll {
+			return true
+		}
+		// if we have an exact match, we match
+		if ruleResource == combinedRequestedResource {
+			return true
+		}
+
+		// We can also match a */subresource.
+		// if there isn't a subresource, then continue
+		if len(requestedSubresource) == 0 {
+			continue
+		}
+		// if the rule isn't in the format */subresource, then we don't match, continue
+		if len(ruleResource) == len(requestedSubresource)+2 &&
+			ruleResource[0:2] == "*/" &&
+			ruleResource[2:] == requestedSubresource {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResourceNameMatches returns the result of the rule.ResourceNames matching.
+func ResourceNameMatches(rule *rbacv1.PolicyRule, requestedName string) bool {
+	if len(rule.ResourceNames) == 0 {
+		return true
+	}
+
+	for _, ruleName := range rule.ResourceNames {
+		if ruleName == requestedName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func has(set []string, ele string) bool {
+	for _, s := range set {
+		if s == ele {
+			return true
+		}
+	}
+	return false
+}
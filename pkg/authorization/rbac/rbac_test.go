@@ -0,0 +1,124 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	rbacv1 "github.com/opencarry/carry/pkg/apis/rbac.carry.i/v1"
+	"github.com/opencarry/carry/pkg/authentication/user"
+	"github.com/opencarry/carry/pkg/authorization/authorizer"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/memory"
+)
+
+func newStorage(t *testing.T) storage.Interface {
+	s := memory.New()
+	objects := map[string]runtime.Object{
+		// team-a的成员可以管理team-a中的pod
+		storage.Key("role", "team-a", "pod-admin"): &rbacv1.Role{
+			ObjectMeta: v1.ObjectMeta{Name: "pod-admin", Namespace: "team-a"},
+			Rules: []rbacv1.PolicyRule{{
+				Verbs:     []string{rbacv1.VerbAll},
+				APIGroups: []string{v1.GroupName},
+				Resources: []string{"pods"},
+			}},
+		},
+		storage.Key("rolebinding", "team-a", "team-a-pod-admin"): &rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "team-a-pod-admin", Namespace: "team-a"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "team-a"}},
+			RoleRef:    rbacv1.RoleRef{Kind: rbacv1.RoleKind, Name: "pod-admin"},
+		},
+		// 所有人都可以读取任意namespace中的configmap，以及更新pod的status
+		storage.Key("clusterrole", "", "viewer"): &rbacv1.ClusterRole{
+			ObjectMeta: v1.ObjectMeta{Name: "viewer"},
+			Rules: []rbacv1.PolicyRule{{
+				Verbs:     []string{"get", "list", "watch"},
+				APIGroups: []string{v1.GroupName},
+				Resources: []string{"configmaps"},
+			}, {
+				Verbs:     []string{"update"},
+				APIGroups: []string{rbacv1.APIGroupAll},
+				Resources: []string{"*/status"},
+			}},
+		},
+		storage.Key("clusterrolebinding", "", "everyone-viewer"): &rbacv1.ClusterRoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "everyone-viewer"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: user.AllAuthenticated}},
+			RoleRef:    rbacv1.RoleRef{Kind: rbacv1.ClusterRoleKind, Name: "viewer"},
+		},
+		// bob只能在team-b中修改名为settings的configmap
+		storage.Key("clusterrole", "", "settings-editor"): &rbacv1.ClusterRole{
+			ObjectMeta: v1.ObjectMeta{Name: "settings-editor"},
+			Rules: []rbacv1.PolicyRule{{
+				Verbs:         []string{"update", "patch"},
+				APIGroups:     []string{v1.GroupName},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{"settings"},
+			}},
+		},
+		storage.Key("rolebinding", "team-b", "bob-settings-editor"): &rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "bob-settings-editor", Namespace: "team-b"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+			RoleRef:    rbacv1.RoleRef{Kind: rbacv1.ClusterRoleKind, Name: "settings-editor"},
+		},
+		// 引用不存在的role的binding不授予任何权限
+		storage.Key("rolebinding", "team-b", "dangling"): &rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "dangling", Namespace: "team-b"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+			RoleRef:    rbacv1.RoleRef{Kind: rbacv1.RoleKind, Name: "missing"},
+		},
+	}
+	for key, obj := range objects {
+		if err := s.Create(context.Background(), key, obj, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestAuthorize(t *testing.T) {
+	alice := &user.DefaultInfo{Name: "alice", Groups: []string{"team-a", user.AllAuthenticated}}
+	bob := &user.DefaultInfo{Name: "bob", Groups: []string{user.AllAuthenticated}}
+	anonymous := &user.DefaultInfo{Name: user.Anonymous, Groups: []string{user.AllUnauthenticated}}
+
+	tests := []struct {
+		name  string
+		attrs authorizer.AttributesRecord
+		allow bool
+	}{
+		{"create pod in own namespace", authorizer.AttributesRecord{User: alice, Verb: "create", APIGroup: "carry.i", Resource: "pods", Namespace: "team-a"}, true},
+		{"delete pod in own namespace", authorizer.AttributesRecord{User: alice, Verb: "delete", APIGroup: "carry.i", Resource: "pods", Namespace: "team-a", Name: "web"}, true},
+		{"delete pod in other namespace", authorizer.AttributesRecord{User: alice, Verb: "delete", APIGroup: "carry.i", Resource: "pods", Namespace: "team-b", Name: "web"}, false},
+		{"list pods across namespaces", authorizer.AttributesRecord{User: alice, Verb: "list", APIGroup: "carry.i", Resource: "pods"}, false},
+		{"pods of another group", authorizer.AttributesRecord{User: alice, Verb: "get", APIGroup: "other.i", Resource: "pods", Namespace: "team-a", Name: "web"}, false},
+		{"pod subresource", authorizer.AttributesRecord{User: alice, Verb: "create", APIGroup: "carry.i", Resource: "pods", Subresource: "binding", Namespace: "team-a", Name: "web"}, false},
+		{"services in own namespace", authorizer.AttributesRecord{User: alice, Verb: "create", APIGroup: "carry.i", Resource: "services", Namespace: "team-a"}, false},
+		{"cluster wide read", authorizer.AttributesRecord{User: bob, Verb: "watch", APIGroup: "carry.i", Resource: "configmaps"}, true},
+		{"cluster wide read in a namespace", authorizer.AttributesRecord{User: alice, Verb: "get", APIGroup: "carry.i", Resource: "configmaps", Namespace: "team-b", Name: "x"}, true},
+		{"cluster wide write", authorizer.AttributesRecord{User: bob, Verb: "delete", APIGroup: "carry.i", Resource: "configmaps", Namespace: "team-b", Name: "settings"}, false},
+		{"status of any resource", authorizer.AttributesRecord{User: bob, Verb: "update", APIGroup: "carry.i", Resource: "nodes", Subresource: "status", Name: "n1"}, true},
+		{"status rule does not match the object", authorizer.AttributesRecord{User: bob, Verb: "update", APIGroup: "carry.i", Resource: "nodes", Name: "n1"}, false},
+		{"named resource", authorizer.AttributesRecord{User: bob, Verb: "patch", APIGroup: "carry.i", Resource: "configmaps", Namespace: "team-b", Name: "settings"}, true},
+		{"other name", authorizer.AttributesRecord{User: bob, Verb: "patch", APIGroup: "carry.i", Resource: "configmaps", Namespace: "team-b", Name: "other"}, false},
+		{"named resource in another namespace", authorizer.AttributesRecord{User: bob, Verb: "patch", APIGroup: "carry.i", Resource: "configmaps", Namespace: "team-a", Name: "settings"}, false},
+		{"anonymous", authorizer.AttributesRecord{User: anonymous, Verb: "get", APIGroup: "carry.i", Resource: "configmaps", Namespace: "team-a", Name: "x"}, false},
+	}
+	a := New(newStorage(t))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.attrs.ResourceRequest = true
+			decision, reason, err := a.Authorize(context.Background(), test.attrs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allowed := decision == authorizer.DecisionAllow; allowed != test.allow {
+				t.Errorf("expected allowed %v, got decision %v (%s)", test.allow, decision, reason)
+			}
+			if decision == authorizer.DecisionDeny {
+				t.Errorf("rbac never denies, got %v", decision)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+// Pop is helper function for popping from Queue.
+// WARNING: Do NOT use this function in non-test code to avoid races
+// unless you really really really really know what you are doing.
+func Pop(queue Queue) interface{} {
+	var result interface{}
+	queue.Pop(func(obj interface{}) error {
+		result = obj
+		return nil
+	})
+	return result
+}
+
+func deltaTypes(deltas Deltas) []DeltaType {
+	types := []DeltaType{}
+	for _, d := range deltas {
+		types = append(types, d.Type)
+	}
+	return types
+}
+
+func TestDeltaFIFO_basic(t *testing.T) {
+	f := NewDeltaFIFOWithOptions(DeltaFIFOOptions{})
+	f.Add(testPod("ns", "a", "", nil))
+	f.Add(testPod("ns", "b", "", nil))
+	f.Update(testPod("ns", "a", "node-1", nil))
+	f.Delete(testPod("ns", "a", "node-1", nil))
+
+	deltas := Pop(f).(Deltas)
+	if e, a := []DeltaType{Added, Updated, Deleted}, deltaTypes(deltas); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if deltas.Newest().Object.(*v1.Pod).Spec.NodeName != "node-1" {
+		t.Errorf("unexpected newest object %v", deltas.Newest().Object)
+	}
+	if deltas := Pop(f).(Deltas); deltas.Oldest().Object.(*v1.Pod).Name != "b" {
+		t.Errorf("expected b, got %v", deltas.Oldest().Object)
+	}
+
+	// 删除不存在的对象会被忽略
+	f.Delete(testPod("ns", "c", "", nil))
+	if keys := f.ListKeys(); len(keys) != 0 {
+		t.Errorf("expected an empty queue, got %v", keys)
+	}
+}
+
+func TestDeltaFIFO_dedupDeletions(t *testing.T) {
+	f := NewDeltaFIFOWithOptions(DeltaFIFOOptions{})
+	f.Add(testPod("ns", "a", "", nil))
+	f.Delete(testPod("ns", "a", "", nil))
+	f.Delete(testPod("ns", "a", "", nil))
+
+	if e, a := []DeltaType{Added, Deleted}, deltaTypes(Pop(f).(Deltas)); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
+func TestDeltaFIFO_ReplaceMakesDeletions(t *testing.T) {
+	known := NewStore(MetaNamespaceKeyFunc)
+	known.Add(testPod("ns", "a", "", nil))
+	known.Add(testPod("ns", "b", "", nil))
+	f := NewDeltaFIFOWithOptions(DeltaFIFOOptions{KnownObjects: known})
+
+	f.Replace([]interface{}{testPod("ns", "a", "node-1", nil)}, "10")
+	if f.HasSynced() {
+		t.Error("expected not synced before the replaced items are popped")
+	}
+
+	deltas := map[string]Deltas{}
+	for i := 0; i < 2; i++ {
+		d := Pop(f).(Deltas)
+		key, _ := f.KeyOf(d)
+		deltas[key] = d
+	}
+	if !f.HasSynced() {
+		t.Error("expected synced after the replaced items are popped")
+	}
+	if e, a := []DeltaType{Replaced}, deltaTypes(deltas["ns/a"]); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v for ns/a, got %v", e, a)
+	}
+	if e, a := []DeltaType{Deleted}, deltaTypes(deltas["ns/b"]); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v for ns/b, got %v", e, a)
+	}
+	deleted, ok := deltas["ns/b"].Newest().Object.(DeletedFinalStateUnknown)
+	if !ok || deleted.Key != "ns/b" || deleted.Obj.(*v1.Pod).Name != "b" {
+		t.Errorf("unexpected deleted object %#v", deltas["ns/b"].Newest().Object)
+	}
+}
+
+func TestDeltaFIFO_Resync(t *testing.T) {
+	known := NewStore(MetaNamespaceKeyFunc)
+	known.Add(testPod("ns", "a", "", nil))
+	known.Add(testPod("ns", "b", "", nil))
+	f := NewDeltaFIFOWithOptions(DeltaFIFOOptions{KnownObjects: known})
+
+	// 已在队列中的对象不再重复同步
+	f.Update(testPod("ns", "a", "node-1", nil))
+	f.Resync()
+
+	if e, a := []DeltaType{Updated}, deltaTypes(Pop(f).(Deltas)); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := []DeltaType{Sync}, deltaTypes(Pop(f).(Deltas)); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
+func TestDeltaFIFO_requeueOnPop(t *testing.T) {
+	f := NewDeltaFIFOWithOptions(DeltaFIFOOptions{})
+	f.Add(testPod("ns", "a", "", nil))
+	_, err := f.Pop(func(obj interface{}) error {
+		return ErrRequeue{Err: nil}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok, _ := f.GetByKey("ns/a"); !ok {
+		t.Error("expected the item to be requeued")
+	}
+}
+
+func TestDeltaFIFO_Close(t *testing.T) {
+	f := NewDeltaFIFOWithOptions(DeltaFIFOOptions{})
+	done := make(chan error)
+	go func() {
+		_, err := f.Pop(func(obj interface{}) error { return nil })
+		done <- err
+	}()
+	f.Close()
+	select {
+	case err := <-done:
+		if err != ErrFIFOClosed {
+			t.Errorf("expected %v, got %v", ErrFIFOClosed, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Pop did not return after Close")
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+func TestReflectorListAndWatch(t *testing.T) {
+	created := make(chan *watch.FakeWatcher, 1)
+	var watchOptions []v1.ListOptions
+	lw := &ListWatch{
+		ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+			return &v1.PodList{
+				ListMeta: v1.ListMeta{ResourceVersion: "1"},
+				Items:    []v1.Pod{*testPod("ns", "a", "", nil)},
+			}, nil
+		},
+		WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+			watchOptions = append(watchOptions, options)
+			fw := watch.NewFake()
+			created <- fw
+			return fw, nil
+		},
+	}
+	store := NewStore(MetaNamespaceKeyFunc)
+	r := NewReflector(lw, &v1.Pod{}, store, 0)
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- r.ListAndWatch(stopCh) }()
+
+	fw := <-created
+	added := testPod("ns", "b", "", nil)
+	added.ResourceVersion = "2"
+	fw.Add(added)
+	deleted := testPod("ns", "a", "", nil)
+	deleted.ResourceVersion = "3"
+	fw.Delete(deleted)
+	// 类型不对的对象被忽略
+	fw.Add(&v1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1", ResourceVersion: "4"}})
+	fw.Stop()
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if len(watchOptions) != 1 || watchOptions[0].ResourceVersion != "1" {
+		t.Errorf("expected a watch from the list resource version, got %+v", watchOptions)
+	}
+	if keys := store.ListKeys(); len(keys) != 1 || keys[0] != "ns/b" {
+		t.Errorf("expected ns/b only in the store, got %v", keys)
+	}
+	if rv := r.LastSyncResourceVersion(); rv != "3" {
+		t.Errorf("expected last sync resource version 3, got %q", rv)
+	}
+}
+
+func TestReflectorRelistsOnExpiredWatch(t *testing.T) {
+	var lock sync.Mutex
+	var listOptions []v1.ListOptions
+	lists := 0
+	lw := &ListWatch{
+		ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			listOptions = append(listOptions, options)
+			lists++
+			list := &v1.PodList{ListMeta: v1.ListMeta{ResourceVersion: "10"}}
+			if lists > 1 {
+				// 第二次list时a已被删除，b被创建
+				list.ResourceVersion = "20"
+				list.Items = []v1.Pod{*testPod("ns", "b", "", nil)}
+			} else {
+				list.Items = []v1.Pod{*testPod("ns", "a", "", nil)}
+			}
+			return list, nil
+		},
+		WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+			if options.ResourceVersion == "10" {
+				return nil, apierrors.NewResourceExpired("too old resource version: 10 (15)")
+			}
+			return watch.NewFake(), nil
+		},
+	}
+	store := NewStore(MetaNamespaceKeyFunc)
+	r := NewReflector(lw, &v1.Pod{}, store, 0)
+	r.period = time.Millisecond
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go r.Run(stopCh)
+
+	deadline := time.After(5 * time.Second)
+	for {
+		if keys := store.ListKeys(); len(keys) == 1 && keys[0] == "ns/b" {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for the relist, store has %v", store.ListKeys())
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(listOptions) < 2 || listOptions[1].ResourceVersion != "" {
+		t.Errorf("expected a relist from the most recent resource version, got %+v", listOptions)
+	}
+	if rv := r.LastSyncResourceVersion(); rv != "20" {
+		t.Errorf("expected last sync resource version 20, got %q", rv)
+	}
+}
+
+func TestReflectorWatchErrorEvent(t *testing.T) {
+	fw := watch.NewFake()
+	lw := &ListWatch{
+		ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+			return &v1.PodList{ListMeta: v1.ListMeta{ResourceVersion: "1"}}, nil
+		},
+		WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+			return fw, nil
+		},
+	}
+	r := NewReflector(lw, &v1.Pod{}, NewStore(MetaNamespaceKeyFunc), 0)
+	go func() {
+		status := apierrors.NewResourceExpired("too old resource version").Status()
+		fw.Error(&status)
+	}()
+	// 错误事件结束watch，ListAndWatch返回后由Run重新list
+	if err := r.ListAndWatch(make(chan struct{})); err != nil {
+		t.Fatal(err)
+	}
+	if !fw.IsStopped() {
+		t.Error("expected the watch to be stopped")
+	}
+}
+
+func TestReflectorWatchHandlerClosedWatch(t *testing.T) {
+	r := NewReflector(&ListWatch{}, &v1.Pod{}, NewStore(MetaNamespaceKeyFunc), 0)
+	tests := []struct {
+		name    string
+		started time.Duration
+		events  int
+		short   bool
+	}{
+		{name: "closed at once", started: 0, short: true},
+		{name: "closed after events", started: 0, events: 1},
+		{name: "closed after a while", started: 2 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fw := watch.NewFake()
+			go func() {
+				for i := 0; i < test.events; i++ {
+					fw.Add(&v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web", ResourceVersion: "2"}})
+				}
+				fw.Stop()
+			}()
+			err := r.watchHandler(time.Now().Add(-test.started), fw, make(chan error), make(chan struct{}))
+			if test.short {
+				if err == nil || !strings.Contains(err.Error(), "very short watch") {
+					t.Errorf("expected a very short watch error, got %v", err)
+				}
+			} else if err != io.EOF {
+				t.Errorf("expected io.EOF, got %v", err)
+			}
+		})
+	}
+}
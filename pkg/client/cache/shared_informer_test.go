@@ -0,0 +1,171 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+type testListener struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (l *testListener) record(event string, obj interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	key, _ := DeletionHandlingMetaNamespaceKeyFunc(obj)
+	l.events = append(l.events, event+" "+key)
+}
+
+func (l *testListener) OnAdd(obj interface{})               { l.record("add", obj) }
+func (l *testListener) OnUpdate(oldObj, newObj interface{}) { l.record("update", newObj) }
+func (l *testListener) OnDelete(obj interface{})            { l.record("delete", obj) }
+
+// waitFor waits until the listener has seen at least n events and returns them.
+func (l *testListener) waitFor(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		l.lock.Lock()
+		if len(l.events) >= n {
+			events := append([]string(nil), l.events...)
+			l.lock.Unlock()
+			return events
+		}
+		l.lock.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	t.Fatalf("timed out waiting for %d events, got %v", n, l.events)
+	return nil
+}
+
+func newFakeListWatch(fw *watch.FakeWatcher, items ...v1.Pod) *ListWatch {
+	return &ListWatch{
+		ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+			return &v1.PodList{ListMeta: v1.ListMeta{ResourceVersion: "1"}, Items: items}, nil
+		},
+		WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+			return fw, nil
+		},
+	}
+}
+
+func TestSharedInformerEventHandlers(t *testing.T) {
+	fw := watch.NewFake()
+	informer := NewSharedIndexInformer(newFakeListWatch(fw, *testPod("ns", "a", "", nil)), &v1.Pod{}, 0,
+		Indexers{NamespaceIndex: MetaNamespaceIndexFunc})
+	first := &testListener{}
+	informer.AddEventHandler(first)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+	if !WaitForCacheSync(stopCh, informer.HasSynced) {
+		t.Fatal("cache did not sync")
+	}
+	if err := informer.AddIndexers(Indexers{"node_name": podNodeNameIndexFunc}); err == nil {
+		t.Error("expected an error adding indexers to a started informer")
+	}
+
+	fw.Add(testPod("ns", "b", "", nil))
+	updated := testPod("ns", "a", "node-1", nil)
+	updated.ResourceVersion = "3"
+	fw.Modify(updated)
+	fw.Delete(testPod("ns", "b", "", nil))
+
+	// 不同key的事件之间没有顺序保证
+	expected := []string{"add ns/a", "add ns/b", "delete ns/b", "update ns/a"}
+	events := first.waitFor(t, len(expected))
+	sort.Strings(expected)
+	sort.Strings(events)
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("expected events %v, got %v", expected, events)
+		}
+	}
+
+	// 启动后加入的handler先收到缓存中对象的add事件
+	late := &testListener{}
+	informer.AddEventHandler(late)
+	if events := late.waitFor(t, 1); events[0] != "add ns/a" {
+		t.Errorf("expected a synthetic add of ns/a, got %v", events)
+	}
+
+	items, err := informer.GetIndexer().ByIndex(NamespaceIndex, "ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].(*v1.Pod).Spec.NodeName != "node-1" {
+		t.Errorf("unexpected indexed pods %v", items)
+	}
+}
+
+func TestSharedInformerResync(t *testing.T) {
+	fw := watch.NewFake()
+	informer := NewSharedInformer(newFakeListWatch(fw, *testPod("ns", "a", "", nil)), &v1.Pod{}, time.Second)
+	resyncing := &testListener{}
+	informer.AddEventHandlerWithResyncPeriod(resyncing, time.Second)
+	quiet := &testListener{}
+	informer.AddEventHandlerWithResyncPeriod(quiet, 0)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+
+	events := resyncing.waitFor(t, 2)
+	if events[0] != "add ns/a" || events[1] != "update ns/a" {
+		t.Errorf("expected an add and a resync update, got %v", events)
+	}
+	if events := quiet.waitFor(t, 1); len(events) != 1 {
+		t.Errorf("expected the handler without resync to get the add only, got %v", events)
+	}
+}
+
+func TestFilteringResourceEventHandler(t *testing.T) {
+	listener := &testListener{}
+	handler := FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			return obj.(*v1.Pod).Spec.NodeName == "node-1"
+		},
+		Handler: listener,
+	}
+	handler.OnAdd(testPod("ns", "a", "node-2", nil))
+	handler.OnUpdate(testPod("ns", "a", "node-2", nil), testPod("ns", "a", "node-1", nil))
+	handler.OnUpdate(testPod("ns", "a", "node-1", nil), testPod("ns", "a", "node-1", nil))
+	handler.OnUpdate(testPod("ns", "a", "node-1", nil), testPod("ns", "a", "node-2", nil))
+	handler.OnDelete(testPod("ns", "a", "node-2", nil))
+
+	expected := []string{"add ns/a", "update ns/a", "delete ns/a"}
+	if len(listener.events) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, listener.events)
+	}
+	for i := range expected {
+		if listener.events[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, listener.events)
+		}
+	}
+}
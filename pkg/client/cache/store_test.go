@@ -0,0 +1,184 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/util/sets"
+)
+
+func testPod(namespace, name, nodeName string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       v1.PodSpec{NodeName: nodeName},
+	}
+}
+
+// podNodeNameIndexFunc 按spec.node_name索引pod
+func podNodeNameIndexFunc(obj interface{}) ([]string, error) {
+	pod := obj.(*v1.Pod)
+	if len(pod.Spec.NodeName) == 0 {
+		return nil, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
+func keysOf(items []interface{}) []string {
+	keys := []string{}
+	for _, item := range items {
+		key, _ := MetaNamespaceKeyFunc(item)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(MetaNamespaceKeyFunc)
+	store.Add(testPod("ns", "a", "", nil))
+	store.Add(testPod("ns", "b", "", nil))
+	store.Update(testPod("ns", "a", "node-1", nil))
+
+	if item, ok, _ := store.GetByKey("ns/a"); !ok || item.(*v1.Pod).Spec.NodeName != "node-1" {
+		t.Errorf("expected the updated ns/a, got %v, %v", item, ok)
+	}
+	if keys := sets.NewString(store.ListKeys()...); !keys.Equal(sets.NewString("ns/a", "ns/b")) {
+		t.Errorf("unexpected keys %v", keys.List())
+	}
+
+	store.Delete(testPod("ns", "a", "", nil))
+	if _, ok, _ := store.Get(testPod("ns", "a", "", nil)); ok {
+		t.Error("expected ns/a to be deleted")
+	}
+
+	store.Replace([]interface{}{testPod("ns", "c", "", nil), testPod("", "d", "", nil)}, "10")
+	if keys := sets.NewString(store.ListKeys()...); !keys.Equal(sets.NewString("ns/c", "d")) {
+		t.Errorf("unexpected keys after replace %v", keys.List())
+	}
+}
+
+func TestSplitMetaNamespaceKey(t *testing.T) {
+	for key, expected := range map[string][2]string{
+		"ns/name": {"ns", "name"},
+		"name":    {"", "name"},
+	} {
+		namespace, name, err := SplitMetaNamespaceKey(key)
+		if err != nil || namespace != expected[0] || name != expected[1] {
+			t.Errorf("%q: expected %v, got %q, %q, %v", key, expected, namespace, name, err)
+		}
+	}
+	if _, _, err := SplitMetaNamespaceKey("a/b/c"); err == nil {
+		t.Error("expected an error for a key with too many parts")
+	}
+}
+
+func TestIndexer(t *testing.T) {
+	indexer := NewIndexer(MetaNamespaceKeyFunc, Indexers{
+		NamespaceIndex: MetaNamespaceIndexFunc,
+		LabelIndex:     MetaLabelIndexFunc,
+	})
+	indexer.Add(testPod("ns-1", "web-1", "node-1", map[string]string{"app": "web"}))
+	indexer.Add(testPod("ns-1", "web-2", "node-2", map[string]string{"app": "web"}))
+	indexer.Add(testPod("ns-2", "db-1", "node-1", map[string]string{"app": "db"}))
+
+	items, err := indexer.ByIndex(NamespaceIndex, "ns-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := keysOf(items); len(keys) != 2 || keys[0] != "ns-1/web-1" || keys[1] != "ns-1/web-2" {
+		t.Errorf("unexpected pods in ns-1: %v", keys)
+	}
+
+	items, err = indexer.ByIndex(LabelIndex, LabelIndexValue("app", "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := keysOf(items); len(keys) != 1 || keys[0] != "ns-2/db-1" {
+		t.Errorf("unexpected pods with app=db: %v", keys)
+	}
+
+	// 在已有数据后加入的索引也包含已有的对象
+	if err := indexer.AddIndexers(Indexers{"node_name": podNodeNameIndexFunc}); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexer.AddIndexers(Indexers{NamespaceIndex: MetaNamespaceIndexFunc}); err == nil {
+		t.Error("expected an error adding a conflicting indexer")
+	}
+	keys, err := indexer.IndexKeys("node_name", "node-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != "ns-1/web-1" || keys[1] != "ns-2/db-1" {
+		t.Errorf("unexpected pods on node-1: %v", keys)
+	}
+
+	// 更新和删除会维护索引
+	indexer.Update(testPod("ns-1", "web-1", "node-2", map[string]string{"app": "web"}))
+	indexer.Delete(testPod("ns-2", "db-1", "", nil))
+	if keys, _ := indexer.IndexKeys("node_name", "node-1"); len(keys) != 0 {
+		t.Errorf("expected no pods on node-1, got %v", keys)
+	}
+	if values := sets.NewString(indexer.ListIndexFuncValues("node_name")...); !values.Equal(sets.NewString("node-2")) {
+		t.Errorf("unexpected node names %v", values.List())
+	}
+	items, err = indexer.Index("node_name", testPod("", "", "node-2", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := keysOf(items); len(keys) != 2 {
+		t.Errorf("expected 2 pods on node-2, got %v", keys)
+	}
+
+	if _, err := indexer.ByIndex("missing", "value"); err == nil {
+		t.Error("expected an error for a missing index")
+	}
+}
+
+func TestListAllByNamespace(t *testing.T) {
+	indexer := NewIndexer(MetaNamespaceKeyFunc, Indexers{NamespaceIndex: MetaNamespaceIndexFunc})
+	indexer.Add(testPod("ns-1", "web-1", "", map[string]string{"app": "web"}))
+	indexer.Add(testPod("ns-1", "db-1", "", map[string]string{"app": "db"}))
+	indexer.Add(testPod("ns-2", "web-2", "", map[string]string{"app": "web"}))
+
+	selector, err := labels.Parse("app=web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	appendName := func(obj interface{}) {
+		names = append(names, obj.(*v1.Pod).Name)
+	}
+	if err := ListAllByNamespace(indexer, "ns-1", selector, appendName); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "web-1" {
+		t.Errorf("expected web-1 only, got %v", names)
+	}
+
+	names = nil
+	if err := ListAllByNamespace(indexer, v1.NamespaceAll, selector, appendName); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "web-1" || names[1] != "web-2" {
+		t.Errorf("expected the web pods of all namespaces, got %v", names)
+	}
+}
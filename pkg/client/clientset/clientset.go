@@ -0,0 +1,69 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientset has the clientset of all the typed clients of the api
+// server.
+package clientset
+
+import (
+	carryv1 "github.com/opencarry/carry/pkg/client/clientset/typed/carry.i/v1"
+	rest "github.com/opencarry/carry/pkg/client/rest"
+)
+
+// Interface is the set of the typed clients of every group.
+type Interface interface {
+	CarryV1() carryv1.CarryV1Interface
+}
+
+// Clientset contains the clients for groups. Each group has exactly one
+// version included in a Clientset.
+type Clientset struct {
+	carryV1 *carryv1.CarryV1Client
+}
+
+// CarryV1 retrieves the CarryV1Client
+func (c *Clientset) CarryV1() carryv1.CarryV1Interface {
+	return c.carryV1
+}
+
+// NewForConfig creates a new Clientset for the given config.
+func NewForConfig(c *rest.Config) (*Clientset, error) {
+	configShallowCopy := *c
+	var cs Clientset
+	var err error
+	cs.carryV1, err = carryv1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+// NewForConfigOrDie creates a new Clientset for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *Clientset {
+	cs, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+// New creates a new Clientset for the given RESTClient.
+func New(c rest.Interface) *Clientset {
+	var cs Clientset
+	cs.carryV1 = carryv1.New(c)
+	return &cs
+}
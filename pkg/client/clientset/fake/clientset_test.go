@@ -0,0 +1,110 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	clienttesting "github.com/opencarry/carry/pkg/client/testing"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/types"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+func TestSimpleClientset(t *testing.T) {
+	ctx := context.Background()
+	cs := NewSimpleClientset(
+		&v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "db-1", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+		&v1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}},
+	)
+	pods := cs.CarryV1().Pods("default")
+
+	list, err := pods.List(ctx, v1.ListOptions{LabelSelector: "app=web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "web-1" {
+		t.Errorf("expected web-1 only, got %v", list.Items)
+	}
+	if list, err := cs.CarryV1().Pods("other").List(ctx, v1.ListOptions{}); err != nil || len(list.Items) != 0 {
+		t.Errorf("expected no pods in other namespace, got %v, %v", list, err)
+	}
+
+	pod, err := pods.Get(ctx, "web-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod.Spec.NodeName = "node-1"
+	if _, err := pods.Update(ctx, pod, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := pods.Get(ctx, "web-1"); got.Spec.NodeName != "node-1" {
+		t.Errorf("expected updated node name, got %q", got.Spec.NodeName)
+	}
+
+	patched, err := pods.Patch(ctx, "web-1", types.MergePatchType, []byte(`{"metadata":{"labels":{"tier":"frontend"}}}`), v1.PatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Labels["tier"] != "frontend" || patched.Labels["app"] != "web" {
+		t.Errorf("unexpected labels after patch: %v", patched.Labels)
+	}
+
+	if _, err := pods.Create(ctx, pod, v1.CreateOptions{}); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+	if err := pods.Delete(ctx, "db-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pods.Get(ctx, "db-1"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if _, err := cs.CarryV1().Nodes().Get(ctx, "node-1"); err != nil {
+		t.Errorf("expected node-1, got %v", err)
+	}
+
+	actions := cs.Actions()
+	if len(actions) != 10 {
+		t.Fatalf("expected 10 actions, got %d: %v", len(actions), actions)
+	}
+	if !actions[3].Matches("update", "pods") || actions[3].GetNamespace() != "default" {
+		t.Errorf("unexpected action %v", actions[3])
+	}
+}
+
+func TestSimpleClientsetWatch(t *testing.T) {
+	ctx := context.Background()
+	cs := NewSimpleClientset()
+	w, err := cs.CarryV1().ConfigMaps("").Watch(ctx, v1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	configMap := &v1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "config", Namespace: "default"}}
+	if _, err := cs.CarryV1().ConfigMaps("default").Create(ctx, configMap, v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	event := <-w.ResultChan()
+	if event.Type != watch.Added || event.Object.(*v1.ConfigMap).Name != "config" {
+		t.Errorf("unexpected event %v", event)
+	}
+}
+
+func TestSimpleClientsetReactor(t *testing.T) {
+	ctx := context.Background()
+	cs := NewSimpleClientset()
+	cs.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("injected")
+	})
+	if _, err := cs.CarryV1().Pods("default").Create(ctx, &v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web-1"}}, v1.CreateOptions{}); err == nil || err.Error() != "injected" {
+		t.Errorf("expected injected error, got %v", err)
+	}
+	if _, err := cs.Tracker().Get(v1.SchemeGroupVersion.WithResource("pods"), "default", "web-1"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the pod not to be created, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	rest "github.com/opencarry/carry/pkg/client/rest"
+)
+
+// CarryV1Interface has methods to work with the resources of the
+// carry.i/v1 group.
+type CarryV1Interface interface {
+	RESTClient() rest.Interface
+	PodsGetter
+	DeploymentsGetter
+	ReplicaSetsGetter
+	StatefulSetsGetter
+	DaemonSetsGetter
+	JobsGetter
+	ServicesGetter
+	ResourceQuotasGetter
+	ConfigMapsGetter
+	EventsGetter
+	LimitRangesGetter
+	NodesGetter
+	NamespacesGetter
+}
+
+// CarryV1Client is used to interact with features provided by the carry.i group.
+type CarryV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *CarryV1Client) Pods(namespace string) PodInterface {
+	return newPods(c, namespace)
+}
+
+func (c *CarryV1Client) Deployments(namespace string) DeploymentInterface {
+	return newDeployments(c, namespace)
+}
+
+func (c *CarryV1Client) ReplicaSets(namespace string) ReplicaSetInterface {
+	return newReplicaSets(c, namespace)
+}
+
+func (c *CarryV1Client) StatefulSets(namespace string) StatefulSetInterface {
+	return newStatefulSets(c, namespace)
+}
+
+func (c *CarryV1Client) DaemonSets(namespace string) DaemonSetInterface {
+	return newDaemonSets(c, namespace)
+}
+
+func (c *CarryV1Client) Jobs(namespace string) JobInterface {
+	return newJobs(c, namespace)
+}
+
+func (c *CarryV1Client) Services(namespace string) ServiceInterface {
+	return newServices(c, namespace)
+}
+
+func (c *CarryV1Client) ResourceQuotas(namespace string) ResourceQuotaInterface {
+	return newResourceQuotas(c, namespace)
+}
+
+func (c *CarryV1Client) ConfigMaps(namespace string) ConfigMapInterface {
+	return newConfigMaps(c, namespace)
+}
+
+func (c *CarryV1Client) Events(namespace string) EventInterface {
+	return newEvents(c, namespace)
+}
+
+func (c *CarryV1Client) LimitRanges(namespace string) LimitRangeInterface {
+	return newLimitRanges(c, namespace)
+}
+
+func (c *CarryV1Client) Nodes() NodeInterface {
+	return newNodes(c)
+}
+
+func (c *CarryV1Client) Namespaces() NamespaceInterface {
+	return newNamespaces(c)
+}
+
+// NewForConfig creates a new CarryV1Client for the given config.
+func NewForConfig(c *rest.Config) (*CarryV1Client, error) {
+	config := rest.CopyConfig(c)
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	client, err := rest.RESTClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &CarryV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new CarryV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *CarryV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new CarryV1Client for the given RESTClient.
+func New(c rest.Interface) *CarryV1Client {
+	return &CarryV1Client{c}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *CarryV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,201 @@
+package v1_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/apiserver"
+	carryv1 "github.com/opencarry/carry/pkg/client/clientset/typed/carry.i/v1"
+	"github.com/opencarry/carry/pkg/client/rest"
+	"github.com/opencarry/carry/pkg/storage/memory"
+	"github.com/opencarry/carry/pkg/types"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+func newTestClient(t *testing.T) *carryv1.CarryV1Client {
+	server := httptest.NewServer(apiserver.New(memory.New(), nil))
+	t.Cleanup(server.Close)
+	return carryv1.NewForConfigOrDie(&rest.Config{Host: server.URL, QPS: -1})
+}
+
+func validPod(name string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:               "web",
+				Image:              "nginx:1.21",
+				ImageDeploymentDir: "/opt/web",
+			}},
+		},
+	}
+}
+
+func TestPodsCRUD(t *testing.T) {
+	ctx := context.Background()
+	pods := newTestClient(t).Pods("default")
+
+	created, err := pods.Create(ctx, validPod("web-1", map[string]string{"app": "web"}), v1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Namespace != "default" || len(created.UID) == 0 || len(created.ResourceVersion) == 0 {
+		t.Errorf("unexpected created pod metadata: %+v", created.ObjectMeta)
+	}
+	if _, err := pods.Create(ctx, validPod("web-1", nil), v1.CreateOptions{}); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+
+	got, err := pods.Get(ctx, "web-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Labels["tier"] = "frontend"
+	updated, err := pods.Update(ctx, got, v1.UpdateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Labels["tier"] != "frontend" {
+		t.Errorf("expected updated labels, got %v", updated.Labels)
+	}
+
+	// got 的resource_version已经过期
+	got.Labels["tier"] = "backend"
+	if _, err := pods.Update(ctx, got, v1.UpdateOptions{}); !apierrors.IsConflict(err) {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+
+	updated.Status.Phase = v1.PodRunning
+	status, err := pods.UpdateStatus(ctx, updated, v1.UpdateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status.Phase != v1.PodRunning {
+		t.Errorf("expected phase %q, got %q", v1.PodRunning, status.Status.Phase)
+	}
+
+	patched, err := pods.Patch(ctx, "web-1", types.MergePatchType, []byte(`{"metadata":{"labels":{"tier":null}}}`), v1.PatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := patched.Labels["tier"]; ok {
+		t.Errorf("expected the patch to remove the tier label, got %v", patched.Labels)
+	}
+
+	if err := pods.Bind(ctx, &v1.Binding{ObjectMeta: v1.ObjectMeta{Name: "web-1"}, Host: "node-1"}, v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := pods.Get(ctx, "web-1"); err != nil || got.Spec.NodeName != "node-1" {
+		t.Errorf("expected pod bound to node-1, got %v, %v", got, err)
+	}
+
+	if err := pods.Delete(ctx, "web-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pods.Get(ctx, "web-1"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if err := pods.Delete(ctx, "web-1"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestPodsList(t *testing.T) {
+	ctx := context.Background()
+	pods := newTestClient(t).Pods("default")
+	for _, pod := range []*v1.Pod{
+		validPod("web-1", map[string]string{"app": "web"}),
+		validPod("web-2", map[string]string{"app": "web"}),
+		validPod("db-1", map[string]string{"app": "db"}),
+	} {
+		if _, err := pods.Create(ctx, pod, v1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := pods.List(ctx, v1.ListOptions{LabelSelector: "app=web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("expected 2 pods with app=web, got %d", len(list.Items))
+	}
+
+	list, err = pods.List(ctx, v1.ListOptions{FieldSelector: "metadata.name=db-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "db-1" {
+		t.Errorf("expected db-1 only, got %v", list.Items)
+	}
+
+	var names []string
+	opts := v1.ListOptions{Limit: 2}
+	for {
+		list, err := pods.List(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Items) > 2 {
+			t.Fatalf("expected at most 2 pods in a page, got %d", len(list.Items))
+		}
+		for _, pod := range list.Items {
+			names = append(names, pod.Name)
+		}
+		if len(list.Continue) == 0 {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	if len(names) != 3 {
+		t.Errorf("expected 3 pods in all the pages, got %v", names)
+	}
+
+	if _, err := pods.List(ctx, v1.ListOptions{LabelSelector: "app in (web"}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+}
+
+func TestNodesWatch(t *testing.T) {
+	ctx := context.Background()
+	nodes := newTestClient(t).Nodes()
+
+	w, err := nodes.Watch(ctx, v1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	if _, err := nodes.Create(ctx, &v1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}}, v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodes.Delete(ctx, "node-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []watch.EventType{watch.Added, watch.Deleted} {
+		select {
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				t.Fatal("watch closed unexpectedly")
+			}
+			if event.Type != expected {
+				t.Fatalf("expected %q event, got %q", expected, event.Type)
+			}
+			if node, ok := event.Object.(*v1.Node); !ok || node.Name != "node-1" {
+				t.Fatalf("unexpected object %#v", event.Object)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q event", expected)
+		}
+	}
+
+	w.Stop()
+	if _, ok := <-w.ResultChan(); ok {
+		t.Error("expected the result channel to be closed after stop")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	v1 "github.com/opencarry/carry/pkg/client/clientset/typed/carry.i/v1"
+	rest "github.com/opencarry/carry/pkg/client/rest"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+)
+
+// FakeCarryV1 implements v1.CarryV1Interface on top of testing.Fake.
+type FakeCarryV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeCarryV1) Pods(namespace string) v1.PodInterface {
+	return &FakePods{c, namespace}
+}
+
+func (c *FakeCarryV1) Deployments(namespace string) v1.DeploymentInterface {
+	return &FakeDeployments{c, namespace}
+}
+
+func (c *FakeCarryV1) ReplicaSets(namespace string) v1.ReplicaSetInterface {
+	return &FakeReplicaSets{c, namespace}
+}
+
+func (c *FakeCarryV1) StatefulSets(namespace string) v1.StatefulSetInterface {
+	return &FakeStatefulSets{c, namespace}
+}
+
+func (c *FakeCarryV1) DaemonSets(namespace string) v1.DaemonSetInterface {
+	return &FakeDaemonSets{c, namespace}
+}
+
+func (c *FakeCarryV1) Jobs(namespace string) v1.JobInterface {
+	return &FakeJobs{c, namespace}
+}
+
+func (c *FakeCarryV1) Services(namespace string) v1.ServiceInterface {
+	return &FakeServices{c, namespace}
+}
+
+func (c *FakeCarryV1) ResourceQuotas(namespace string) v1.ResourceQuotaInterface {
+	return &FakeResourceQuotas{c, namespace}
+}
+
+func (c *FakeCarryV1) ConfigMaps(namespace string) v1.ConfigMapInterface {
+	return &FakeConfigMaps{c, namespace}
+}
+
+func (c *FakeCarryV1) Events(namespace string) v1.EventInterface {
+	return &FakeEvents{c, namespace}
+}
+
+func (c *FakeCarryV1) LimitRanges(namespace string) v1.LimitRangeInterface {
+	return &FakeLimitRanges{c, namespace}
+}
+
+func (c *FakeCarryV1) Nodes() v1.NodeInterface {
+	return &FakeNodes{c}
+}
+
+func (c *FakeCarryV1) Namespaces() v1.NamespaceInterface {
+	return &FakeNamespaces{c}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeCarryV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
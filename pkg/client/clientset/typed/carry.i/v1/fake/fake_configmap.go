@@ -0,0 +1,117 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakeConfigMaps implements ConfigMapInterface
+type FakeConfigMaps struct {
+	Fake *FakeCarryV1
+	ns   string
+}
+
+var configMapsResource = v1.SchemeGroupVersion.WithResource("configmaps")
+
+var configMapsKind = v1.SchemeGroupVersion.WithKind("configmap")
+
+// Get takes name of the configMap, and returns the corresponding configMap object, and an error if there is any.
+func (c *FakeConfigMaps) Get(ctx context.Context, name string) (*v1.ConfigMap, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(configMapsResource, c.ns, name), &v1.ConfigMap{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.ConfigMap), err
+}
+
+// List takes label and field selectors, and returns the list of ConfigMaps that match those selectors.
+func (c *FakeConfigMaps) List(ctx context.Context, opts v1.ListOptions) (*v1.ConfigMapList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(configMapsResource, configMapsKind, c.ns, opts), &v1.ConfigMapList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.ConfigMapList{ListMeta: obj.(*v1.ConfigMapList).ListMeta}
+	for _, item := range obj.(*v1.ConfigMapList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested configMaps.
+func (c *FakeConfigMaps) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(configMapsResource, c.ns, opts))
+}
+
+// Create takes the representation of a configMap and creates it.  Returns the server's representation of the configMap, and an error, if there is any.
+func (c *FakeConfigMaps) Create(ctx context.Context, configMap *v1.ConfigMap, opts v1.CreateOptions) (*v1.ConfigMap, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(configMapsResource, c.ns, configMap), &v1.ConfigMap{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.ConfigMap), err
+}
+
+// Update takes the representation of a configMap and updates it. Returns the server's representation of the configMap, and an error, if there is any.
+func (c *FakeConfigMaps) Update(ctx context.Context, configMap *v1.ConfigMap, opts v1.UpdateOptions) (*v1.ConfigMap, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(configMapsResource, c.ns, configMap), &v1.ConfigMap{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.ConfigMap), err
+}
+
+// Delete takes name of the configMap and deletes it. Returns an error if one occurs.
+func (c *FakeConfigMaps) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(configMapsResource, c.ns, name), &v1.ConfigMap{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched configMap.
+func (c *FakeConfigMaps) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.ConfigMap, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(configMapsResource, c.ns, name, pt, data, subresources...), &v1.ConfigMap{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.ConfigMap), err
+}
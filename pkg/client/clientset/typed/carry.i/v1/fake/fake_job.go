@@ -0,0 +1,129 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakeJobs implements JobInterface
+type FakeJobs struct {
+	Fake *FakeCarryV1
+	ns   string
+}
+
+var jobsResource = v1.SchemeGroupVersion.WithResource("jobs")
+
+var jobsKind = v1.SchemeGroupVersion.WithKind("job")
+
+// Get takes name of the job, and returns the corresponding job object, and an error if there is any.
+func (c *FakeJobs) Get(ctx context.Context, name string) (*v1.Job, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(jobsResource, c.ns, name), &v1.Job{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Job), err
+}
+
+// List takes label and field selectors, and returns the list of Jobs that match those selectors.
+func (c *FakeJobs) List(ctx context.Context, opts v1.ListOptions) (*v1.JobList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(jobsResource, jobsKind, c.ns, opts), &v1.JobList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.JobList{ListMeta: obj.(*v1.JobList).ListMeta}
+	for _, item := range obj.(*v1.JobList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested jobs.
+func (c *FakeJobs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(jobsResource, c.ns, opts))
+}
+
+// Create takes the representation of a job and creates it.  Returns the server's representation of the job, and an error, if there is any.
+func (c *FakeJobs) Create(ctx context.Context, job *v1.Job, opts v1.CreateOptions) (*v1.Job, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(jobsResource, c.ns, job), &v1.Job{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Job), err
+}
+
+// Update takes the representation of a job and updates it. Returns the server's representation of the job, and an error, if there is any.
+func (c *FakeJobs) Update(ctx context.Context, job *v1.Job, opts v1.UpdateOptions) (*v1.Job, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(jobsResource, c.ns, job), &v1.Job{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Job), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeJobs) UpdateStatus(ctx context.Context, job *v1.Job, opts v1.UpdateOptions) (*v1.Job, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(jobsResource, "status", c.ns, job), &v1.Job{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Job), err
+}
+
+// Delete takes name of the job and deletes it. Returns an error if one occurs.
+func (c *FakeJobs) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(jobsResource, c.ns, name), &v1.Job{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched job.
+func (c *FakeJobs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.Job, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(jobsResource, c.ns, name, pt, data, subresources...), &v1.Job{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Job), err
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakeLimitRanges implements LimitRangeInterface
+type FakeLimitRanges struct {
+	Fake *FakeCarryV1
+	ns   string
+}
+
+var limitRangesResource = v1.SchemeGroupVersion.WithResource("limitranges")
+
+var limitRangesKind = v1.SchemeGroupVersion.WithKind("limitrange")
+
+// Get takes name of the limitRange, and returns the corresponding limitRange object, and an error if there is any.
+func (c *FakeLimitRanges) Get(ctx context.Context, name string) (*v1.LimitRange, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(limitRangesResource, c.ns, name), &v1.LimitRange{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.LimitRange), err
+}
+
+// List takes label and field selectors, and returns the list of LimitRanges that match those selectors.
+func (c *FakeLimitRanges) List(ctx context.Context, opts v1.ListOptions) (*v1.LimitRangeList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(limitRangesResource, limitRangesKind, c.ns, opts), &v1.LimitRangeList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.LimitRangeList{ListMeta: obj.(*v1.LimitRangeList).ListMeta}
+	for _, item := range obj.(*v1.LimitRangeList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested limitRanges.
+func (c *FakeLimitRanges) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(limitRangesResource, c.ns, opts))
+}
+
+// Create takes the representation of a limitRange and creates it.  Returns the server's representation of the limitRange, and an error, if there is any.
+func (c *FakeLimitRanges) Create(ctx context.Context, limitRange *v1.LimitRange, opts v1.CreateOptions) (*v1.LimitRange, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(limitRangesResource, c.ns, limitRange), &v1.LimitRange{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.LimitRange), err
+}
+
+// Update takes the representation of a limitRange and updates it. Returns the server's representation of the limitRange, and an error, if there is any.
+func (c *FakeLimitRanges) Update(ctx context.Context, limitRange *v1.LimitRange, opts v1.UpdateOptions) (*v1.LimitRange, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(limitRangesResource, c.ns, limitRange), &v1.LimitRange{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.LimitRange), err
+}
+
+// Delete takes name of the limitRange and deletes it. Returns an error if one occurs.
+func (c *FakeLimitRanges) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(limitRangesResource, c.ns, name), &v1.LimitRange{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched limitRange.
+func (c *FakeLimitRanges) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.LimitRange, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(limitRangesResource, c.ns, name, pt, data, subresources...), &v1.LimitRange{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.LimitRange), err
+}
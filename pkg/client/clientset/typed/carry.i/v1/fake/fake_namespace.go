@@ -0,0 +1,128 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakeNamespaces implements NamespaceInterface
+type FakeNamespaces struct {
+	Fake *FakeCarryV1
+}
+
+var namespacesResource = v1.SchemeGroupVersion.WithResource("namespaces")
+
+var namespacesKind = v1.SchemeGroupVersion.WithKind("namespace")
+
+// Get takes name of the namespace, and returns the corresponding namespace object, and an error if there is any.
+func (c *FakeNamespaces) Get(ctx context.Context, name string) (*v1.Namespace, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(namespacesResource, "", name), &v1.Namespace{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Namespace), err
+}
+
+// List takes label and field selectors, and returns the list of Namespaces that match those selectors.
+func (c *FakeNamespaces) List(ctx context.Context, opts v1.ListOptions) (*v1.NamespaceList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(namespacesResource, namespacesKind, "", opts), &v1.NamespaceList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.NamespaceList{ListMeta: obj.(*v1.NamespaceList).ListMeta}
+	for _, item := range obj.(*v1.NamespaceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested namespaces.
+func (c *FakeNamespaces) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(namespacesResource, "", opts))
+}
+
+// Create takes the representation of a namespace and creates it.  Returns the server's representation of the namespace, and an error, if there is any.
+func (c *FakeNamespaces) Create(ctx context.Context, namespace *v1.Namespace, opts v1.CreateOptions) (*v1.Namespace, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(namespacesResource, "", namespace), &v1.Namespace{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Namespace), err
+}
+
+// Update takes the representation of a namespace and updates it. Returns the server's representation of the namespace, and an error, if there is any.
+func (c *FakeNamespaces) Update(ctx context.Context, namespace *v1.Namespace, opts v1.UpdateOptions) (*v1.Namespace, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(namespacesResource, "", namespace), &v1.Namespace{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Namespace), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNamespaces) UpdateStatus(ctx context.Context, namespace *v1.Namespace, opts v1.UpdateOptions) (*v1.Namespace, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(namespacesResource, "status", "", namespace), &v1.Namespace{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Namespace), err
+}
+
+// Delete takes name of the namespace and deletes it. Returns an error if one occurs.
+func (c *FakeNamespaces) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(namespacesResource, "", name), &v1.Namespace{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched namespace.
+func (c *FakeNamespaces) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.Namespace, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(namespacesResource, "", name, pt, data, subresources...), &v1.Namespace{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Namespace), err
+}
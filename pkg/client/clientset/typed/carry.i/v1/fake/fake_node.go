@@ -0,0 +1,128 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakeNodes implements NodeInterface
+type FakeNodes struct {
+	Fake *FakeCarryV1
+}
+
+var nodesResource = v1.SchemeGroupVersion.WithResource("nodes")
+
+var nodesKind = v1.SchemeGroupVersion.WithKind("node")
+
+// Get takes name of the node, and returns the corresponding node object, and an error if there is any.
+func (c *FakeNodes) Get(ctx context.Context, name string) (*v1.Node, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(nodesResource, "", name), &v1.Node{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Node), err
+}
+
+// List takes label and field selectors, and returns the list of Nodes that match those selectors.
+func (c *FakeNodes) List(ctx context.Context, opts v1.ListOptions) (*v1.NodeList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(nodesResource, nodesKind, "", opts), &v1.NodeList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.NodeList{ListMeta: obj.(*v1.NodeList).ListMeta}
+	for _, item := range obj.(*v1.NodeList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested nodes.
+func (c *FakeNodes) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(nodesResource, "", opts))
+}
+
+// Create takes the representation of a node and creates it.  Returns the server's representation of the node, and an error, if there is any.
+func (c *FakeNodes) Create(ctx context.Context, node *v1.Node, opts v1.CreateOptions) (*v1.Node, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(nodesResource, "", node), &v1.Node{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Node), err
+}
+
+// Update takes the representation of a node and updates it. Returns the server's representation of the node, and an error, if there is any.
+func (c *FakeNodes) Update(ctx context.Context, node *v1.Node, opts v1.UpdateOptions) (*v1.Node, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(nodesResource, "", node), &v1.Node{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Node), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNodes) UpdateStatus(ctx context.Context, node *v1.Node, opts v1.UpdateOptions) (*v1.Node, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(nodesResource, "status", "", node), &v1.Node{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Node), err
+}
+
+// Delete takes name of the node and deletes it. Returns an error if one occurs.
+func (c *FakeNodes) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(nodesResource, "", name), &v1.Node{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched node.
+func (c *FakeNodes) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.Node, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(nodesResource, "", name, pt, data, subresources...), &v1.Node{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Node), err
+}
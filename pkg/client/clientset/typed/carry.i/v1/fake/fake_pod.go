@@ -0,0 +1,143 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakePods implements PodInterface
+type FakePods struct {
+	Fake *FakeCarryV1
+	ns   string
+}
+
+var podsResource = v1.SchemeGroupVersion.WithResource("pods")
+
+var podsKind = v1.SchemeGroupVersion.WithKind("pod")
+
+// Get takes name of the pod, and returns the corresponding pod object, and an error if there is any.
+func (c *FakePods) Get(ctx context.Context, name string) (*v1.Pod, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(podsResource, c.ns, name), &v1.Pod{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Pod), err
+}
+
+// List takes label and field selectors, and returns the list of Pods that match those selectors.
+func (c *FakePods) List(ctx context.Context, opts v1.ListOptions) (*v1.PodList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(podsResource, podsKind, c.ns, opts), &v1.PodList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.PodList{ListMeta: obj.(*v1.PodList).ListMeta}
+	for _, item := range obj.(*v1.PodList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested pods.
+func (c *FakePods) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(podsResource, c.ns, opts))
+}
+
+// Create takes the representation of a pod and creates it.  Returns the server's representation of the pod, and an error, if there is any.
+func (c *FakePods) Create(ctx context.Context, pod *v1.Pod, opts v1.CreateOptions) (*v1.Pod, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(podsResource, c.ns, pod), &v1.Pod{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Pod), err
+}
+
+// Update takes the representation of a pod and updates it. Returns the server's representation of the pod, and an error, if there is any.
+func (c *FakePods) Update(ctx context.Context, pod *v1.Pod, opts v1.UpdateOptions) (*v1.Pod, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(podsResource, c.ns, pod), &v1.Pod{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Pod), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePods) UpdateStatus(ctx context.Context, pod *v1.Pod, opts v1.UpdateOptions) (*v1.Pod, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(podsResource, "status", c.ns, pod), &v1.Pod{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Pod), err
+}
+
+// Delete takes name of the pod and deletes it. Returns an error if one occurs.
+func (c *FakePods) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(podsResource, c.ns, name), &v1.Pod{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched pod.
+func (c *FakePods) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.Pod, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(podsResource, c.ns, name, pt, data, subresources...), &v1.Pod{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Pod), err
+}
+
+// Bind records the binding of the named pod, the pod itself is not changed.
+func (c *FakePods) Bind(ctx context.Context, binding *v1.Binding, opts v1.CreateOptions) error {
+	action := testing.CreateActionImpl{}
+	action.Verb = "create"
+	action.Namespace = c.ns
+	action.Name = binding.Name
+	action.Resource = podsResource
+	action.Subresource = "binding"
+	action.Object = binding
+
+	_, err := c.Fake.Invokes(action, binding)
+	return err
+}
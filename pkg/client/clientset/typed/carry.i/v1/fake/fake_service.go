@@ -0,0 +1,129 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	testing "github.com/opencarry/carry/pkg/client/testing"
+	labels "github.com/opencarry/carry/pkg/labels"
+	types "github.com/opencarry/carry/pkg/types"
+	watch "github.com/opencarry/carry/pkg/watch"
+)
+
+// FakeServices implements ServiceInterface
+type FakeServices struct {
+	Fake *FakeCarryV1
+	ns   string
+}
+
+var servicesResource = v1.SchemeGroupVersion.WithResource("services")
+
+var servicesKind = v1.SchemeGroupVersion.WithKind("service")
+
+// Get takes name of the service, and returns the corresponding service object, and an error if there is any.
+func (c *FakeServices) Get(ctx context.Context, name string) (*v1.Service, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(servicesResource, c.ns, name), &v1.Service{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Service), err
+}
+
+// List takes label and field selectors, and returns the list of Services that match those selectors.
+func (c *FakeServices) List(ctx context.Context, opts v1.ListOptions) (*v1.ServiceList, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(servicesResource, servicesKind, c.ns, opts), &v1.ServiceList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.ServiceList{ListMeta: obj.(*v1.ServiceList).ListMeta}
+	for _, item := range obj.(*v1.ServiceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested services.
+func (c *FakeServices) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(servicesResource, c.ns, opts))
+}
+
+// Create takes the representation of a service and creates it.  Returns the server's representation of the service, and an error, if there is any.
+func (c *FakeServices) Create(ctx context.Context, service *v1.Service, opts v1.CreateOptions) (*v1.Service, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(servicesResource, c.ns, service), &v1.Service{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Service), err
+}
+
+// Update takes the representation of a service and updates it. Returns the server's representation of the service, and an error, if there is any.
+func (c *FakeServices) Update(ctx context.Context, service *v1.Service, opts v1.UpdateOptions) (*v1.Service, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(servicesResource, c.ns, service), &v1.Service{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Service), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeServices) UpdateStatus(ctx context.Context, service *v1.Service, opts v1.UpdateOptions) (*v1.Service, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(servicesResource, "status", c.ns, service), &v1.Service{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Service), err
+}
+
+// Delete takes name of the service and deletes it. Returns an error if one occurs.
+func (c *FakeServices) Delete(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(servicesResource, c.ns, name), &v1.Service{})
+
+	return err
+}
+
+// Patch applies the patch and returns the patched service.
+func (c *FakeServices) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1.Service, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(servicesResource, c.ns, name, pt, data, subresources...), &v1.Service{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Service), err
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/client/cache"
+	"github.com/opencarry/carry/pkg/client/clientset/fake"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/util/wait"
+)
+
+func TestSharedInformerFactory(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&v1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}},
+	)
+	factory := NewSharedInformerFactory(client, 0)
+	podInformer := factory.CarryV1().Pods()
+	if podInformer.Informer() != factory.CarryV1().Pods().Informer() {
+		t.Fatal("expected the factory to share one pod informer")
+	}
+	nodeLister := factory.CarryV1().Nodes().Lister()
+
+	var lock sync.Mutex
+	var events []string
+	record := func(event string, obj interface{}) {
+		lock.Lock()
+		defer lock.Unlock()
+		key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		events = append(events, event+" "+key)
+	}
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { record("add", obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) { record("update", newObj) },
+		DeleteFunc: func(obj interface{}) { record("delete", obj) },
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+	for typ, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			t.Fatalf("informer for %v did not sync", typ)
+		}
+	}
+
+	if node, err := nodeLister.Get("node-1"); err != nil || node.Name != "node-1" {
+		t.Errorf("expected node-1 from the lister, got %v, %v", node, err)
+	}
+	if _, err := nodeLister.Get("node-2"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	if pods, err := podInformer.Lister().Pods("default").List(selector); err != nil || len(pods) != 1 {
+		t.Errorf("expected one web pod, got %v, %v", pods, err)
+	}
+
+	pods := client.CarryV1().Pods("default")
+	if _, err := pods.Create(ctx, &v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "web-2", Namespace: "default"}}, v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	pod, err := pods.Get(ctx, "web-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod.Spec.NodeName = "node-1"
+	if _, err := pods.Update(ctx, pod, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pods.Delete(ctx, "web-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"add default/web-1", "add default/web-2", "update default/web-1", "delete default/web-2"}
+	deadline := time.Now().Add(wait.ForeverTestTimeout)
+	for {
+		lock.Lock()
+		got := append([]string(nil), events...)
+		lock.Unlock()
+		if len(got) >= len(expected) {
+			seen := map[string]bool{}
+			for _, event := range got {
+				seen[event] = true
+			}
+			for _, event := range expected {
+				if !seen[event] {
+					t.Errorf("missing event %q in %v", event, got)
+				}
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for events, got %v", got)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if pod, err := podInformer.Lister().Pods("default").Get("web-1"); err != nil || pod.Spec.NodeName != "node-1" {
+		t.Errorf("expected the lister to see the update, got %v, %v", pod, err)
+	}
+	if _, err := podInformer.Lister().Pods("default").Get("web-2"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected web-2 to be gone from the lister, got %v", err)
+	}
+}
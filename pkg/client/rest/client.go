@@ -0,0 +1,151 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rest sends the requests of the typed clients to the api server
+// and decodes its responses.
+package rest
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/types"
+	"github.com/opencarry/carry/pkg/util/flowcontrol"
+)
+
+// Interface captures the set of operations for generically interacting with the API.
+type Interface interface {
+	GetRateLimiter() flowcontrol.RateLimiter
+	Verb(verb string) *Request
+	Post() *Request
+	Put() *Request
+	Patch(pt types.PatchType) *Request
+	Get() *Request
+	Delete() *Request
+	APIVersion() schema.GroupVersion
+}
+
+// ClientContentConfig controls how RESTClient communicates with the server.
+type ClientContentConfig struct {
+	// BearerToken is sent in the Authorization header of every request, if
+	// not empty.
+	BearerToken string
+	// UserAgent is sent with every request, DefaultUserAgent() if empty.
+	UserAgent string
+	// Timeout limits the requests other than watches, if not zero.
+	Timeout time.Duration
+}
+
+// RESTClient imposes common carry API conventions on a set of resource paths.
+// The baseURL is expected to point to an HTTP or HTTPS path that is the parent
+// of one or more resources.  The server should return a decodable API resource
+// object, or an api.Status object which contains information about the reason for
+// any failure.
+//
+// Most consumers should use the typed clients of the clientset package
+// instead of a RESTClient directly.
+type RESTClient struct {
+	// base is the root URL for all invocations of the client
+	base *url.URL
+	// groupVersion is the API group version the client talks to, the
+	// requests are sent under /apis/{group}/{version}.
+	groupVersion schema.GroupVersion
+
+	// content describes how a RESTClient encodes and decodes responses.
+	content ClientContentConfig
+
+	// rateLimiter is shared among all requests created by this client unless specifically
+	// overridden.
+	rateLimiter flowcontrol.RateLimiter
+
+	// Set specific behavior of the client.  If not set http.DefaultClient will be used.
+	Client *http.Client
+}
+
+// NewRESTClient creates a new RESTClient. This client performs generic REST functions
+// such as Get, Put, Post, and Delete on specified paths. rateLimiter may be
+// nil to not limit the requests.
+func NewRESTClient(baseURL *url.URL, groupVersion schema.GroupVersion, config ClientContentConfig, rateLimiter flowcontrol.RateLimiter, client *http.Client) (*RESTClient, error) {
+	if len(config.UserAgent) == 0 {
+		config.UserAgent = DefaultUserAgent()
+	}
+	base := *baseURL
+	base.Path = ""
+	base.RawQuery = ""
+	base.Fragment = ""
+
+	return &RESTClient{
+		base:         &base,
+		groupVersion: groupVersion,
+		content:      config,
+		rateLimiter:  rateLimiter,
+		Client:       client,
+	}, nil
+}
+
+// GetRateLimiter returns rate limiter for a given client, or nil if it's called on a nil client
+func (c *RESTClient) GetRateLimiter() flowcontrol.RateLimiter {
+	if c == nil {
+		return nil
+	}
+	return c.rateLimiter
+}
+
+// Verb begins a request with a verb (GET, POST, PUT, DELETE).
+//
+// Example usage of RESTClient's request building interface:
+//
+//	c, err := NewRESTClient(...)
+//	if err != nil { ... }
+//	resp, err := c.Verb("GET").
+//	  Resource("pods").
+//	  Name("foo").
+//	  Do(context.TODO())
+func (c *RESTClient) Verb(verb string) *Request {
+	return NewRequest(c).Verb(verb)
+}
+
+// Post begins a POST request. Short for c.Verb("POST").
+func (c *RESTClient) Post() *Request {
+	return c.Verb("POST")
+}
+
+// Put begins a PUT request. Short for c.Verb("PUT").
+func (c *RESTClient) Put() *Request {
+	return c.Verb("PUT")
+}
+
+// Patch begins a PATCH request. Short for c.Verb("Patch").
+func (c *RESTClient) Patch(pt types.PatchType) *Request {
+	return c.Verb("PATCH").SetHeader("Content-Type", string(pt))
+}
+
+// Get begins a GET request. Short for c.Verb("GET").
+func (c *RESTClient) Get() *Request {
+	return c.Verb("GET")
+}
+
+// Delete begins a DELETE request. Short for c.Verb("DELETE").
+func (c *RESTClient) Delete() *Request {
+	return c.Verb("DELETE")
+}
+
+// APIVersion returns the APIVersion this RESTClient is expected to use.
+func (c *RESTClient) APIVersion() schema.GroupVersion {
+	return c.groupVersion
+}
@@ -0,0 +1,546 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	"github.com/opencarry/carry/pkg/api/scheme"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+// maxUnstructuredResponseTextBytes is the limit on the size of a response
+// body that is not a status quoted in an error.
+const maxUnstructuredResponseTextBytes = 2048
+
+// Request allows for building up a request to a server in a chained fashion.
+// Any errors are stored until the end of your call, so you only have to
+// check once.
+type Request struct {
+	c *RESTClient
+
+	verb    string
+	params  url.Values
+	headers http.Header
+
+	// generic components accessible via method setters
+	namespace    string
+	namespaceSet bool
+	resource     string
+	resourceName string
+	subresource  string
+
+	// output
+	err  error
+	body []byte
+}
+
+// NewRequest creates a new request helper object for accessing runtime.Objects on a server.
+func NewRequest(c *RESTClient) *Request {
+	r := &Request{
+		c:       c,
+		params:  url.Values{},
+		headers: http.Header{},
+	}
+	r.SetHeader("Accept", runtime.ContentTypeJSON)
+	return r
+}
+
+// Verb sets the verb this request will use.
+func (r *Request) Verb(verb string) *Request {
+	r.verb = verb
+	return r
+}
+
+// Resource sets the resource to access (<resource>/[ns/<namespace>/]<name>)
+func (r *Request) Resource(resource string) *Request {
+	if r.err != nil {
+		return r
+	}
+	if len(r.resource) != 0 {
+		r.err = fmt.Errorf("resource already set to %q, cannot change to %q", r.resource, resource)
+		return r
+	}
+	if msgs := isValidPathSegmentName(resource); len(msgs) != 0 {
+		r.err = fmt.Errorf("invalid resource %q: %v", resource, msgs)
+		return r
+	}
+	r.resource = resource
+	return r
+}
+
+// SubResource sets a sub-resource path which can be multiple segments after the resource
+// name but before the suffix.
+func (r *Request) SubResource(subresources ...string) *Request {
+	if r.err != nil {
+		return r
+	}
+	subresource := path.Join(subresources...)
+	if len(r.subresource) != 0 {
+		r.err = fmt.Errorf("subresource already set to %q, cannot change to %q", r.subresource, subresource)
+		return r
+	}
+	for _, s := range subresources {
+		if msgs := isValidPathSegmentName(s); len(msgs) != 0 {
+			r.err = fmt.Errorf("invalid subresource %q: %v", s, msgs)
+			return r
+		}
+	}
+	r.subresource = subresource
+	return r
+}
+
+// Name sets the name of a resource to access (<resource>/[ns/<namespace>/]<name>)
+func (r *Request) Name(resourceName string) *Request {
+	if r.err != nil {
+		return r
+	}
+	if len(resourceName) == 0 {
+		r.err = fmt.Errorf("resource name may not be empty")
+		return r
+	}
+	if len(r.resourceName) != 0 {
+		r.err = fmt.Errorf("resource name already set to %q, cannot change to %q", r.resourceName, resourceName)
+		return r
+	}
+	if msgs := isValidPathSegmentName(resourceName); len(msgs) != 0 {
+		r.err = fmt.Errorf("invalid resource name %q: %v", resourceName, msgs)
+		return r
+	}
+	r.resourceName = resourceName
+	return r
+}
+
+// Namespace applies the namespace scope to a request (<resource>/[ns/<namespace>/]<name>)
+func (r *Request) Namespace(namespace string) *Request {
+	if r.err != nil {
+		return r
+	}
+	if r.namespaceSet {
+		r.err = fmt.Errorf("namespace already set to %q, cannot change to %q", r.namespace, namespace)
+		return r
+	}
+	if msgs := isValidPathSegmentName(namespace); len(namespace) != 0 && len(msgs) != 0 {
+		r.err = fmt.Errorf("invalid namespace %q: %v", namespace, msgs)
+		return r
+	}
+	r.namespaceSet = true
+	r.namespace = namespace
+	return r
+}
+
+// NamespaceIfScoped is a convenience function to set a namespace if scoped is true
+func (r *Request) NamespaceIfScoped(namespace string, scoped bool) *Request {
+	if scoped {
+		return r.Namespace(namespace)
+	}
+	return r
+}
+
+// Param creates a query parameter with the given string value.
+func (r *Request) Param(paramName, s string) *Request {
+	if r.err != nil {
+		return r
+	}
+	r.params.Add(paramName, s)
+	return r
+}
+
+// Options adds the fields of opts, a struct like v1.ListOptions, to the
+// query parameters, named after their json tags. Fields with zero values are
+// left out.
+func (r *Request) Options(opts interface{}) *Request {
+	if r.err != nil {
+		return r
+	}
+	v := reflect.Indirect(reflect.ValueOf(opts))
+	if v.Kind() != reflect.Struct {
+		r.err = fmt.Errorf("options must be a struct, got %T", opts)
+		return r
+	}
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+		value := reflect.Indirect(v.Field(i))
+		if !value.IsValid() || value.IsZero() {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.String:
+			r.params.Set(name, value.String())
+		case reflect.Bool:
+			r.params.Set(name, strconv.FormatBool(value.Bool()))
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			r.params.Set(name, strconv.FormatInt(value.Int(), 10))
+		default:
+			r.err = fmt.Errorf("unsupported type %s of option %q", value.Type(), name)
+			return r
+		}
+	}
+	return r
+}
+
+// SetHeader sets the values of a header of the request.
+func (r *Request) SetHeader(key string, values ...string) *Request {
+	r.headers.Del(key)
+	for _, value := range values {
+		r.headers.Add(key, value)
+	}
+	return r
+}
+
+// Body makes the request use obj as the body. Optional.
+// If obj is a string, try to read a file of that name.
+// If obj is a []byte, send it directly.
+// If obj is a runtime.Object, marshal it in JSON, in the group version of
+// the client.
+// Otherwise, set an error.
+func (r *Request) Body(obj interface{}) *Request {
+	if r.err != nil {
+		return r
+	}
+	switch t := obj.(type) {
+	case string:
+		data, err := ioutil.ReadFile(t)
+		if err != nil {
+			r.err = err
+			return r
+		}
+		r.body = data
+	case []byte:
+		r.body = t
+	case runtime.Object:
+		// callers may pass typed interface pointers, therefore we must check nil with reflection
+		if reflect.ValueOf(t).IsNil() {
+			return r
+		}
+		data, err := runtime.Encode(scheme.Codecs.LegacyCodec(r.c.groupVersion), t)
+		if err != nil {
+			r.err = err
+			return r
+		}
+		r.body = data
+		if len(r.headers.Get("Content-Type")) == 0 {
+			r.SetHeader("Content-Type", runtime.ContentTypeJSON)
+		}
+	default:
+		r.err = fmt.Errorf("unknown type used for body: %+v", obj)
+	}
+	return r
+}
+
+// URL returns the current working URL.
+func (r *Request) URL() *url.URL {
+	p := path.Join("/apis", r.c.groupVersion.Group, r.c.groupVersion.Version)
+	if r.namespaceSet && len(r.namespace) > 0 {
+		p = path.Join(p, "namespaces", r.namespace)
+	}
+	if len(r.resource) != 0 {
+		p = path.Join(p, r.resource)
+	}
+	if len(r.resourceName) != 0 || len(r.subresource) != 0 {
+		p = path.Join(p, r.resourceName, r.subresource)
+	}
+
+	finalURL := *r.c.base
+	finalURL.Path = p
+	finalURL.RawQuery = r.params.Encode()
+	return &finalURL
+}
+
+// tryThrottle waits for a token of the rate limiter of the client.
+func (r *Request) tryThrottle(ctx context.Context) error {
+	if r.c.rateLimiter == nil {
+		return nil
+	}
+	return r.c.rateLimiter.Wait(ctx)
+}
+
+// newHTTPRequest returns the http request r builds.
+func (r *Request) newHTTPRequest(ctx context.Context) (*http.Request, error) {
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.verb, r.URL().String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = r.headers.Clone()
+	req.Header.Set("User-Agent", r.c.content.UserAgent)
+	if len(r.c.content.BearerToken) != 0 {
+		req.Header.Set("Authorization", "Bearer "+r.c.content.BearerToken)
+	}
+	return req, nil
+}
+
+func (r *Request) client() *http.Client {
+	if r.c.Client == nil {
+		return http.DefaultClient
+	}
+	return r.c.Client
+}
+
+// Do formats and executes the request. Returns a Result object for easy response
+// processing.
+//
+// Error type:
+//   - If the server responds with a status: *errors.StatusError or *errors.UnexpectedObjectError
+//   - http.Client.Do errors are returned directly.
+func (r *Request) Do(ctx context.Context) Result {
+	if r.err != nil {
+		return Result{err: r.err}
+	}
+	if err := r.tryThrottle(ctx); err != nil {
+		return Result{err: err}
+	}
+	if r.c.content.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.c.content.Timeout)
+		defer cancel()
+	}
+
+	req, err := r.newHTTPRequest(ctx)
+	if err != nil {
+		return Result{err: err}
+	}
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return Result{err: err}
+	}
+	defer resp.Body.Close()
+	return r.transformResponse(resp)
+}
+
+// DoRaw executes the request but does not process the response body.
+func (r *Request) DoRaw(ctx context.Context) ([]byte, error) {
+	result := r.Do(ctx)
+	return result.body, result.err
+}
+
+// transformResponse converts an API response into a structured API object
+func (r *Request) transformResponse(resp *http.Response) Result {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{err: err}
+	}
+	result := Result{
+		body:        body,
+		contentType: resp.Header.Get("Content-Type"),
+		statusCode:  resp.StatusCode,
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusPartialContent {
+		result.err = r.transformError(resp.StatusCode, body)
+	}
+	return result
+}
+
+// transformError returns the error of a response with code: the status in
+// body if there is one, or a generic error quoting body.
+func (r *Request) transformError(code int, body []byte) error {
+	if status, ok := decodeStatus(body); ok && status.Status != v1.StatusSuccess {
+		return &apierrors.StatusError{ErrStatus: *status}
+	}
+	message := ""
+	if len(body) > 0 && len(body) <= maxUnstructuredResponseTextBytes {
+		message = strings.TrimSpace(string(body))
+	}
+	groupResource := r.c.groupVersion.WithResource(r.resource).GroupResource()
+	if len(r.resource) == 0 {
+		groupResource.Group = ""
+	}
+	return apierrors.NewGenericServerResponse(code, r.verb, groupResource, r.resourceName, message)
+}
+
+// decodeStatus returns the status in body, if body is one.
+func decodeStatus(body []byte) (*v1.Status, bool) {
+	obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), body)
+	if err != nil {
+		return nil, false
+	}
+	status, ok := obj.(*v1.Status)
+	return status, ok
+}
+
+// Watch attempts to begin watching the requested location.
+// Returns a watch.Interface, or an error. The watch ends when ctx is done,
+// the timeout_seconds of the request expire or the connection breaks; the
+// client timeout does not apply.
+func (r *Request) Watch(ctx context.Context) (watch.Interface, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := r.tryThrottle(ctx); err != nil {
+		return nil, err
+	}
+	req, err := r.newHTTPRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		result := r.transformResponse(resp)
+		if result.err == nil {
+			result.err = fmt.Errorf("unexpected status code %d for a watch", resp.StatusCode)
+		}
+		return nil, result.err
+	}
+	return watch.NewStreamWatcher(newWatchDecoder(resp.Body), errorReporter{}), nil
+}
+
+// Result contains the result of calling Request.Do().
+type Result struct {
+	body        []byte
+	contentType string
+	err         error
+	statusCode  int
+}
+
+// Raw returns the raw result.
+func (r Result) Raw() ([]byte, error) {
+	return r.body, r.err
+}
+
+// Get returns the result as an object, which means it passes through the decoder.
+func (r Result) Get() (runtime.Object, error) {
+	if r.err != nil {
+		return nil, r.Error()
+	}
+	return runtime.Decode(scheme.Codecs.UniversalDeserializer(), r.body)
+}
+
+// StatusCode returns the HTTP status code of the request. (Only valid if no
+// error was returned.)
+func (r Result) StatusCode(statusCode *int) Result {
+	*statusCode = r.statusCode
+	return r
+}
+
+// Into stores the result into obj, if possible. If obj is nil it is ignored.
+// If the returned object is of type Status and has .Status != StatusSuccess, the
+// additional information in Status will be used to enrich the error.
+func (r Result) Into(obj runtime.Object) error {
+	if r.err != nil {
+		// Check whether the result has a Status object in the body and prefer that.
+		return r.Error()
+	}
+	if len(r.body) == 0 {
+		return fmt.Errorf("0-length response with status code: %d and content type: %s",
+			r.statusCode, r.contentType)
+	}
+
+	out, _, err := scheme.Codecs.UniversalDeserializer().Decode(r.body, nil, obj)
+	if err != nil {
+		return err
+	}
+	if out != obj {
+		if status, ok := out.(*v1.Status); ok && status.Status != v1.StatusSuccess {
+			return &apierrors.StatusError{ErrStatus: *status}
+		}
+		return fmt.Errorf("expected an object of type %T in the response, got %T", obj, out)
+	}
+	return nil
+}
+
+// Error returns the error executing the request, nil if no error occurred.
+// If the returned object is of type Status and has Status != StatusSuccess, the
+// additional information in Status will be used to enrich the error.
+// See the Request.Do() comment for what errors you might get.
+func (r Result) Error() error {
+	return r.err
+}
+
+// watchDecoder decodes the newline delimited v1.WatchEvents of a watch
+// response.
+type watchDecoder struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+}
+
+func newWatchDecoder(body io.ReadCloser) *watchDecoder {
+	return &watchDecoder{body: body, decoder: json.NewDecoder(body)}
+}
+
+// Decode blocks until it can return the next object in the reader. Returns an error
+// if the reader is closed or an object can't be decoded.
+func (d *watchDecoder) Decode() (watch.EventType, runtime.Object, error) {
+	var event v1.WatchEvent
+	if err := d.decoder.Decode(&event); err != nil {
+		return "", nil, err
+	}
+	switch watch.EventType(event.Type) {
+	case watch.Added, watch.Modified, watch.Deleted, watch.Bookmark, watch.Error:
+	default:
+		return "", nil, fmt.Errorf("got invalid watch event type: %v", event.Type)
+	}
+	obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), event.Object)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to decode watch event: %v", err)
+	}
+	return watch.EventType(event.Type), obj, nil
+}
+
+// Close closes the underlying r.
+func (d *watchDecoder) Close() {
+	d.body.Close()
+}
+
+// errorReporter reports the errors decoding a watch as internal errors.
+type errorReporter struct{}
+
+func (errorReporter) AsObject(err error) runtime.Object {
+	status := apierrors.NewInternalError(fmt.Errorf("unable to decode an event from the watch stream: %v", err)).Status()
+	return &status
+}
+
+// isValidPathSegmentName validates the name can be safely encoded as a path segment
+func isValidPathSegmentName(name string) []string {
+	for _, illegalName := range []string{".", ".."} {
+		if name == illegalName {
+			return []string{fmt.Sprintf(`may not be '%s'`, illegalName)}
+		}
+	}
+
+	var errors []string
+	for _, illegalContent := range []string{"/", "%"} {
+		if strings.Contains(name, illegalContent) {
+			errors = append(errors, fmt.Sprintf(`may not contain '%s'`, illegalContent))
+		}
+	}
+
+	return errors
+}
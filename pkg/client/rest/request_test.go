@@ -0,0 +1,154 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/util/flowcontrol"
+)
+
+func testRESTClient(t *testing.T, server *httptest.Server, rateLimiter flowcontrol.RateLimiter) *RESTClient {
+	t.Helper()
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewRESTClient(baseURL, v1.SchemeGroupVersion, ClientContentConfig{BearerToken: "secret"}, rateLimiter, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRequestURL(t *testing.T) {
+	c, err := NewRESTClient(&url.URL{Scheme: "https", Host: "localhost:6443"}, v1.SchemeGroupVersion, ClientContentConfig{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeout := int64(30)
+	testCases := []struct {
+		request  *Request
+		expected string
+	}{
+		{
+			request:  c.Get().Namespace("default").Resource("pods").Name("web-1"),
+			expected: "https://localhost:6443/apis/carry.i/v1/namespaces/default/pods/web-1",
+		},
+		{
+			request:  c.Put().Namespace("default").Resource("pods").Name("web-1").SubResource("status"),
+			expected: "https://localhost:6443/apis/carry.i/v1/namespaces/default/pods/web-1/status",
+		},
+		{
+			request:  c.Get().Resource("nodes").Options(v1.ListOptions{LabelSelector: "zone=a", Limit: 10, TimeoutSeconds: &timeout, Watch: true}),
+			expected: "https://localhost:6443/apis/carry.i/v1/nodes?label_selector=zone%3Da&limit=10&timeout_seconds=30&watch=true",
+		},
+		{
+			request:  c.Get().NamespaceIfScoped("default", false).Resource("nodes").Param("resource_version", "5"),
+			expected: "https://localhost:6443/apis/carry.i/v1/nodes?resource_version=5",
+		},
+	}
+	for _, tc := range testCases {
+		if actual := tc.request.URL().String(); actual != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, actual)
+		}
+	}
+}
+
+func TestRequestInvalidSegments(t *testing.T) {
+	c, err := NewRESTClient(&url.URL{Scheme: "https", Host: "localhost:6443"}, v1.SchemeGroupVersion, ClientContentConfig{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*Request{
+		c.Get().Resource("pods").Name(""),
+		c.Get().Resource("pods").Name("../secrets"),
+		c.Get().Namespace("a/b").Resource("pods"),
+		c.Get().Resource("pods").Resource("nodes"),
+		c.Get().Resource("pods").Options("not a struct"),
+	} {
+		if err := r.Do(context.Background()).Error(); err == nil {
+			t.Errorf("expected an error for %s", r.URL())
+		}
+	}
+}
+
+func TestRequestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/apis/carry.i/v1/namespaces/default/pods/web-1":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"kind":"pod","api_version":"carry.i/v1","metadata":{"name":"web-1","namespace":"default"}}`))
+		case "/apis/carry.i/v1/namespaces/default/pods/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"status","api_version":"carry.i/v1","status":"failure","reason":"not_found","code":404,"message":"pods \"missing\" not found"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("upstream is down"))
+		}
+	}))
+	defer server.Close()
+	c := testRESTClient(t, server, flowcontrol.NewFakeAlwaysRateLimiter())
+	ctx := context.Background()
+
+	pod := &v1.Pod{}
+	if err := c.Get().Namespace("default").Resource("pods").Name("web-1").Do(ctx).Into(pod); err != nil {
+		t.Fatal(err)
+	}
+	if pod.Name != "web-1" || pod.Namespace != "default" {
+		t.Errorf("unexpected pod %+v", pod.ObjectMeta)
+	}
+
+	err := c.Get().Namespace("default").Resource("pods").Name("missing").Do(ctx).Into(pod)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	var code int
+	err = c.Get().Namespace("default").Resource("services").Name("web").Do(ctx).StatusCode(&code).Error()
+	if code != http.StatusBadGateway || !apierrors.IsInternalError(err) {
+		t.Errorf("expected a generic server error with code %d, got %d, %v", http.StatusBadGateway, code, err)
+	}
+}
+
+func TestRequestRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	c := testRESTClient(t, server, flowcontrol.NewTokenBucketRateLimiter(0.001, 1))
+
+	if err := c.Get().Resource("nodes").Do(context.Background()).Error(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Get().Resource("nodes").Do(ctx).Error(); err != context.Canceled {
+		t.Errorf("expected the throttled request to fail with %v, got %v", context.Canceled, err)
+	}
+}
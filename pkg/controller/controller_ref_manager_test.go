@@ -0,0 +1,143 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/labels"
+)
+
+var rsKind = v1.SchemeGroupVersion.WithKind("replicaset")
+
+func ownedPod(name string, podLabels map[string]string, owner *v1.ReplicaSet) *v1.Pod {
+	pod := newPod(name, podLabels)
+	pod.OwnerReferences = []v1.OwnerReference{*v1.NewControllerRef(owner, rsKind.GroupVersion().String(), rsKind.Kind)}
+	return pod
+}
+
+// patchedOwnerUIDs 解析patch中的owner references
+func patchedOwnerUIDs(t *testing.T, patch []byte) []v1.UID {
+	t.Helper()
+	var parsed struct {
+		Metadata struct {
+			OwnerReferences []v1.OwnerReference `json:"owner_references"`
+			UID             v1.UID              `json:"uid"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(patch, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Metadata.UID) == 0 {
+		t.Errorf("expected the patch to carry the uid of the pod: %s", patch)
+	}
+	var uids []v1.UID
+	for _, ref := range parsed.Metadata.OwnerReferences {
+		uids = append(uids, ref.UID)
+	}
+	return uids
+}
+
+func TestClaimPods(t *testing.T) {
+	rs := newReplicaSet("web", 3)
+	other := newReplicaSet("other", 1)
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	matching := map[string]string{"app": "web"}
+
+	orphan := newPod("orphan", matching)
+	owned := ownedPod("owned", matching, rs)
+	stale := ownedPod("stale", map[string]string{"app": "db"}, rs)
+	foreign := ownedPod("foreign", matching, other)
+	deletingOrphan := newPod("deleting-orphan", matching)
+	deletingOrphan.DeletionTime = time.Now()
+	unmatchedOrphan := newPod("unmatched-orphan", map[string]string{"app": "db"})
+
+	podControl := &FakePodControl{}
+	m := NewPodControllerRefManager(podControl, rs, selector, rsKind, func(ctx context.Context) error { return nil })
+	claimed, err := m.ClaimPods(context.TODO(), []*v1.Pod{orphan, owned, stale, foreign, deletingOrphan, unmatchedOrphan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 2 || claimed[0] != orphan || claimed[1] != owned {
+		t.Errorf("expected the orphan and the owned pod to be claimed, got %v", claimed)
+	}
+
+	// 一次领养，一次释放
+	if len(podControl.Patches) != 2 {
+		t.Fatalf("expected 2 patches, got %d", len(podControl.Patches))
+	}
+	if uids := patchedOwnerUIDs(t, podControl.Patches[0]); len(uids) != 1 || uids[0] != rs.UID {
+		t.Errorf("expected the orphan to be adopted by %v, got %v", rs.UID, uids)
+	}
+	if uids := patchedOwnerUIDs(t, podControl.Patches[1]); len(uids) != 0 {
+		t.Errorf("expected the stale pod to be released, got owners %v", uids)
+	}
+}
+
+func TestClaimPodsCannotAdopt(t *testing.T) {
+	rs := newReplicaSet("web", 3)
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	podControl := &FakePodControl{}
+	calls := 0
+	m := NewPodControllerRefManager(podControl, rs, selector, rsKind, func(ctx context.Context) error {
+		calls++
+		return fmt.Errorf("replica set is being deleted")
+	})
+
+	claimed, err := m.ClaimPods(context.TODO(), []*v1.Pod{
+		newPod("a", map[string]string{"app": "web"}),
+		newPod("b", map[string]string{"app": "web"}),
+	})
+	if err == nil {
+		t.Error("expected an error when the controller cannot adopt")
+	}
+	if len(claimed) != 0 || len(podControl.Patches) != 0 {
+		t.Errorf("expected nothing to be adopted, got %v and %d patches", claimed, len(podControl.Patches))
+	}
+	if calls != 1 {
+		t.Errorf("expected CanAdopt to be checked once, got %d", calls)
+	}
+}
+
+func TestClaimPodsControllerBeingDeleted(t *testing.T) {
+	rs := newReplicaSet("web", 3)
+	rs.DeletionTime = time.Now()
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	podControl := &FakePodControl{}
+	m := NewPodControllerRefManager(podControl, rs, selector, rsKind, nil)
+
+	owned := ownedPod("owned", map[string]string{"app": "web"}, rs)
+	claimed, err := m.ClaimPods(context.TODO(), []*v1.Pod{
+		newPod("orphan", map[string]string{"app": "web"}),
+		owned,
+		ownedPod("stale", map[string]string{"app": "db"}, rs),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0] != owned {
+		t.Errorf("expected only the owned pod, got %v", claimed)
+	}
+	if len(podControl.Patches) != 0 {
+		t.Errorf("expected no adoption or release while being deleted, got %d patches", len(podControl.Patches))
+	}
+}
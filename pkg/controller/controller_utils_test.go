@@ -0,0 +1,257 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/client/clientset/fake"
+)
+
+func newReplicaSet(name string, replicas int64) *v1.ReplicaSet {
+	return &v1.ReplicaSet{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default", UID: v1.UID(name + "-uid")},
+		Spec: v1.ReplicaSetSpec{
+			Replicas: &replicas,
+			Selector: &v1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels:      map[string]string{"app": name, "tier": "web"},
+					Annotations: map[string]string{"owner": "team"},
+				},
+				Spec: v1.PodSpec{NodeName: ""},
+			},
+		},
+	}
+}
+
+func newPod(name string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default", UID: v1.UID(name + "-uid"), Labels: labels},
+	}
+}
+
+func readyCondition(since time.Time) v1.PodCondition {
+	return v1.PodCondition{Type: v1.PodReady, State: v1.ConditionTrue, LastTransitionTime: since}
+}
+
+func TestControllerExpectations(t *testing.T) {
+	e := NewControllerExpectations()
+	key := "default/rs"
+
+	if !e.SatisfiedExpectations(key) {
+		t.Error("expected a controller without expectations to be satisfied")
+	}
+	if err := e.ExpectCreations(key, 2); err != nil {
+		t.Fatal(err)
+	}
+	if e.SatisfiedExpectations(key) {
+		t.Error("expected unsatisfied expectations after ExpectCreations")
+	}
+	e.CreationObserved(key)
+	if e.SatisfiedExpectations(key) {
+		t.Error("expected unsatisfied expectations after one of two creations")
+	}
+	e.CreationObserved(key)
+	if !e.SatisfiedExpectations(key) {
+		t.Error("expected satisfied expectations after all creations")
+	}
+
+	e.RaiseExpectations(key, 1, 1)
+	if add, del := mustGetExpectations(t, e, key); add != 1 || del != 1 {
+		t.Errorf("expected 1 add and 1 del, got %d and %d", add, del)
+	}
+
+	// 过期的期望被视为已满足
+	exp, _, _ := e.GetExpectations(key)
+	exp.timestamp = time.Now().Add(-ExpectationsTimeout - time.Second)
+	if !e.SatisfiedExpectations(key) {
+		t.Error("expected expired expectations to be satisfied")
+	}
+
+	e.DeleteExpectations(key)
+	if _, exists, _ := e.GetExpectations(key); exists {
+		t.Error("expected the expectations to be deleted")
+	}
+}
+
+func mustGetExpectations(t *testing.T, e ControllerExpectationsInterface, key string) (int64, int64) {
+	t.Helper()
+	exp, exists, err := e.GetExpectations(key)
+	if err != nil || !exists {
+		t.Fatalf("expected expectations for %s, got %v, %v", key, exists, err)
+	}
+	return exp.GetExpectations()
+}
+
+func TestUIDExpectations(t *testing.T) {
+	e := NewUIDTrackingControllerExpectations(NewControllerExpectations())
+	key := "default/rs"
+
+	if err := e.ExpectDeletions(key, []string{"default/a", "default/b"}); err != nil {
+		t.Fatal(err)
+	}
+	// 同一个pod的删除只计一次，例如先看到deletion_time再看到删除事件
+	e.DeletionObserved(key, "default/a")
+	e.DeletionObserved(key, "default/a")
+	e.DeletionObserved(key, "default/unknown")
+	if e.SatisfiedExpectations(key) {
+		t.Fatal("expected unsatisfied expectations with one deletion left")
+	}
+	e.DeletionObserved(key, "default/b")
+	if !e.SatisfiedExpectations(key) {
+		t.Error("expected satisfied expectations after all deletions")
+	}
+
+	if err := e.ExpectDeletions(key, []string{"default/c"}); err != nil {
+		t.Fatal(err)
+	}
+	e.DeleteExpectations(key)
+	if uids := e.GetUIDs(key); uids != nil {
+		t.Errorf("expected the uids to be deleted, got %v", uids)
+	}
+	if !e.SatisfiedExpectations(key) {
+		t.Error("expected no expectations after DeleteExpectations")
+	}
+}
+
+func TestRealPodControlCreatePods(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	podControl := RealPodControl{Client: client}
+	rs := newReplicaSet("web", 1)
+	controllerRef := v1.NewControllerRef(rs, v1.SchemeGroupVersion.String(), "replicaset")
+
+	if err := podControl.CreatePods(context.TODO(), rs.Namespace, &rs.Spec.Template, rs, controllerRef); err != nil {
+		t.Fatal(err)
+	}
+	pods, err := client.CarryV1().Pods(rs.Namespace).List(context.TODO(), v1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 1 {
+		t.Fatalf("expected one pod, got %d", len(pods.Items))
+	}
+	pod := pods.Items[0]
+	if pod.GenerateName != "web-" {
+		t.Errorf("expected generate_name web-, got %q", pod.GenerateName)
+	}
+	if !reflect.DeepEqual(pod.Labels, rs.Spec.Template.Labels) || !reflect.DeepEqual(pod.Annotations, rs.Spec.Template.Annotations) {
+		t.Errorf("expected the labels and annotations of the template, got %v and %v", pod.Labels, pod.Annotations)
+	}
+	if ref := v1.GetControllerOf(&pod); ref == nil || ref.UID != rs.UID || ref.Kind != "replicaset" {
+		t.Errorf("expected the replica set as controller, got %+v", ref)
+	}
+
+	if err := podControl.CreatePods(context.TODO(), rs.Namespace, &rs.Spec.Template, rs, &v1.OwnerReference{}); err == nil {
+		t.Error("expected an error for an invalid controller ref")
+	}
+}
+
+func TestSortingActivePods(t *testing.T) {
+	now := time.Now()
+	unscheduled := newPod("unscheduled", nil)
+	pending := newPod("pending", nil)
+	pending.Spec.NodeName = "node"
+	pending.Status.Phase = v1.PodPending
+	unknown := newPod("unknown", nil)
+	unknown.Spec.NodeName = "node"
+	unknown.Status.Phase = v1.PodUnknown
+	notReady := newPod("not-ready", nil)
+	notReady.Spec.NodeName = "node"
+	notReady.Status.Phase = v1.PodRunning
+	readyRecently := newPod("ready-recently", nil)
+	readyRecently.Spec.NodeName = "node"
+	readyRecently.Status.Phase = v1.PodRunning
+	readyRecently.Status.Conditions = []v1.PodCondition{readyCondition(now.Add(-time.Minute))}
+	restarting := newPod("restarting", nil)
+	restarting.Spec.NodeName = "node"
+	restarting.Status.Phase = v1.PodRunning
+	restarting.Status.Conditions = []v1.PodCondition{readyCondition(now.Add(-time.Hour))}
+	restarting.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: 3}}
+	young := newPod("young", nil)
+	young.Spec.NodeName = "node"
+	young.Status.Phase = v1.PodRunning
+	young.Status.Conditions = []v1.PodCondition{readyCondition(now.Add(-time.Hour))}
+	young.CreationTime = now.Add(-time.Hour)
+	old := newPod("old", nil)
+	old.Spec.NodeName = "node"
+	old.Status.Phase = v1.PodRunning
+	old.Status.Conditions = []v1.PodCondition{readyCondition(now.Add(-time.Hour))}
+	old.CreationTime = now.Add(-2 * time.Hour)
+
+	expected := []*v1.Pod{unscheduled, pending, unknown, notReady, readyRecently, restarting, young, old}
+	for i := 0; i < 20; i++ {
+		pods := make([]*v1.Pod, len(expected))
+		for j, k := range rand.Perm(len(expected)) {
+			pods[j] = expected[k]
+		}
+		sort.Sort(ActivePods(pods))
+		for j := range expected {
+			if pods[j] != expected[j] {
+				names := make([]string, len(pods))
+				for k := range pods {
+					names[k] = pods[k].Name
+				}
+				t.Fatalf("unexpected order %v", names)
+			}
+		}
+	}
+}
+
+func TestFilterActivePods(t *testing.T) {
+	running := newPod("running", nil)
+	succeeded := newPod("succeeded", nil)
+	succeeded.Status.Phase = v1.PodSucceeded
+	failed := newPod("failed", nil)
+	failed.Status.Phase = v1.PodFailed
+	deleting := newPod("deleting", nil)
+	deleting.DeletionTime = time.Now()
+
+	active := FilterActivePods([]*v1.Pod{running, succeeded, failed, deleting})
+	if len(active) != 1 || active[0] != running {
+		t.Errorf("expected only the running pod, got %v", active)
+	}
+}
+
+func TestIsPodAvailable(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name            string
+		conditions      []v1.PodCondition
+		minReadySeconds int64
+		expected        bool
+	}{
+		{name: "not ready", expected: false},
+		{name: "ready without min ready seconds", conditions: []v1.PodCondition{readyCondition(now)}, expected: true},
+		{name: "ready for less than min ready seconds", conditions: []v1.PodCondition{readyCondition(now.Add(-time.Second))}, minReadySeconds: 10, expected: false},
+		{name: "ready for longer than min ready seconds", conditions: []v1.PodCondition{readyCondition(now.Add(-time.Minute))}, minReadySeconds: 10, expected: true},
+		{name: "not ready with min ready seconds", conditions: []v1.PodCondition{{Type: v1.PodReady, State: v1.ConditionFalse, LastTransitionTime: now.Add(-time.Minute)}}, minReadySeconds: 10, expected: false},
+	}
+	for _, test := range tests {
+		pod := newPod("pod", nil)
+		pod.Status.Conditions = test.conditions
+		if actual := IsPodAvailable(pod, test.minReadySeconds, now); actual != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,690 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replicaset contains the ReplicaSet controller. It creates and
+// deletes pods so that every ReplicaSet runs the number of replicas it asks
+// for, and keeps the status of the ReplicaSet up to date.
+//
+// NOTE: using this controller next to another controller that manages pods
+// with overlapping selectors is not supported, the ReplicaSet only adopts
+// pods that have no controller.
+package replicaset
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	apierrors "github.com/opencarry/carry/pkg/api/errors"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/client/cache"
+	"github.com/opencarry/carry/pkg/client/clientset"
+	informers "github.com/opencarry/carry/pkg/client/informers/carry.i/v1"
+	listers "github.com/opencarry/carry/pkg/client/listers/carry.i/v1"
+	"github.com/opencarry/carry/pkg/controller"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	utilruntime "github.com/opencarry/carry/pkg/util/runtime"
+	"github.com/opencarry/carry/pkg/util/wait"
+	"github.com/opencarry/carry/pkg/util/workqueue"
+)
+
+const (
+	// BurstReplicas is the maximum number of pods a ReplicaSet creates or
+	// deletes in a single sync.
+	BurstReplicas = 500
+
+	// The number of times we retry updating a ReplicaSet's status.
+	statusUpdateRetries = 1
+)
+
+// controllerKind contains the schema.GroupVersionKind for this controller type.
+var controllerKind = v1.SchemeGroupVersion.WithKind("replicaset")
+
+// ReplicaSetController is responsible for synchronizing ReplicaSet objects stored
+// in the system with actual running pods.
+type ReplicaSetController struct {
+	// GroupVersionKind indicates the controller type.
+	schema.GroupVersionKind
+
+	client     clientset.Interface
+	podControl controller.PodControlInterface
+
+	// A ReplicaSet is temporarily suspended after creating/deleting these many replicas.
+	// It resumes normal action after observing the watch events for them.
+	burstReplicas int
+	// To allow injection of syncReplicaSet for testing.
+	syncHandler func(ctx context.Context, rsKey string) error
+
+	// A TTLCache of pod creates/deletes each rc expects to see.
+	expectations *controller.UIDTrackingControllerExpectations
+
+	// A store of ReplicaSets, populated by the shared informer passed to NewReplicaSetController
+	rsLister listers.ReplicaSetLister
+	// rsListerSynced returns true if the pod store has been synced at least once.
+	// Added as a member to the struct to allow injection for testing.
+	rsListerSynced cache.InformerSynced
+
+	// A store of pods, populated by the shared informer passed to NewReplicaSetController
+	podLister listers.PodLister
+	// podListerSynced returns true if the pod store has been synced at least once.
+	// Added as a member to the struct to allow injection for testing.
+	podListerSynced cache.InformerSynced
+
+	// Controllers that need to be synced
+	queue workqueue.RateLimitingInterface
+}
+
+// NewReplicaSetController configures a replica set controller that manages
+// pods through client, creating or deleting at most burstReplicas pods of a
+// ReplicaSet per sync.
+func NewReplicaSetController(rsInformer informers.ReplicaSetInformer, podInformer informers.PodInformer, client clientset.Interface, burstReplicas int) *ReplicaSetController {
+	rsc := &ReplicaSetController{
+		GroupVersionKind: controllerKind,
+		client:           client,
+		podControl:       controller.RealPodControl{Client: client},
+		burstReplicas:    burstReplicas,
+		expectations:     controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "replicaset"),
+	}
+
+	rsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rsc.addRS,
+		UpdateFunc: rsc.updateRS,
+		DeleteFunc: rsc.deleteRS,
+	})
+	rsc.rsLister = rsInformer.Lister()
+	rsc.rsListerSynced = rsInformer.Informer().HasSynced
+
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: rsc.addPod,
+		// This invokes the ReplicaSet for every pod change, eg: host assignment. Though this might seem like
+		// overkill the most frequent pod update is status, and the associated ReplicaSet will only list from
+		// local storage, so it should be ok.
+		UpdateFunc: rsc.updatePod,
+		DeleteFunc: rsc.deletePod,
+	})
+	rsc.podLister = podInformer.Lister()
+	rsc.podListerSynced = podInformer.Informer().HasSynced
+
+	rsc.syncHandler = rsc.syncReplicaSet
+
+	return rsc
+}
+
+// Run begins watching and syncing.
+func (rsc *ReplicaSetController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer rsc.queue.ShutDown()
+
+	log.Printf("Starting %v controller", rsc.Kind)
+	defer log.Printf("Shutting down %v controller", rsc.Kind)
+
+	if !cache.WaitForNamedCacheSync(rsc.Kind, ctx.Done(), rsc.podListerSynced, rsc.rsListerSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() { rsc.worker(ctx) }, time.Second, ctx.Done())
+	}
+
+	<-ctx.Done()
+}
+
+// getPodReplicaSets returns a list of ReplicaSets matching the given pod.
+func (rsc *ReplicaSetController) getPodReplicaSets(pod *v1.Pod) []*v1.ReplicaSet {
+	rss, err := rsc.rsLister.GetPodReplicaSets(pod)
+	if err != nil {
+		return nil
+	}
+	if len(rss) > 1 {
+		// ControllerRef will ensure we don't do anything crazy, but more than one
+		// item in this list nevertheless constitutes user error.
+		utilruntime.HandleError(fmt.Errorf("user error! more than one %v is selecting pods with labels: %+v", rsc.Kind, pod.Labels))
+	}
+	return rss
+}
+
+// resolveControllerRef returns the controller referenced by a ControllerRef,
+// or nil if the ControllerRef could not be resolved to a matching controller
+// of the correct Kind.
+func (rsc *ReplicaSetController) resolveControllerRef(namespace string, controllerRef *v1.OwnerReference) *v1.ReplicaSet {
+	// We can't look up by UID, so look up by Name and then verify UID.
+	// Don't even try to look up by Name if it's the wrong Kind.
+	if controllerRef.Kind != rsc.Kind {
+		return nil
+	}
+	rs, err := rsc.rsLister.ReplicaSets(namespace).Get(controllerRef.Name)
+	if err != nil {
+		return nil
+	}
+	if rs.UID != controllerRef.UID {
+		// The controller we found with this Name is not the same one that the
+		// ControllerRef points to.
+		return nil
+	}
+	return rs
+}
+
+func (rsc *ReplicaSetController) enqueueRS(rs *v1.ReplicaSet) {
+	key, err := controller.KeyFunc(rs)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", rs, err))
+		return
+	}
+
+	rsc.queue.Add(key)
+}
+
+func (rsc *ReplicaSetController) enqueueRSAfter(rs *v1.ReplicaSet, duration time.Duration) {
+	key, err := controller.KeyFunc(rs)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", rs, err))
+		return
+	}
+
+	rsc.queue.AddAfter(key, duration)
+}
+
+func (rsc *ReplicaSetController) addRS(obj interface{}) {
+	rs := obj.(*v1.ReplicaSet)
+	log.Printf("Adding %s %s/%s", rsc.Kind, rs.Namespace, rs.Name)
+	rsc.enqueueRS(rs)
+}
+
+// callback when RS is updated
+func (rsc *ReplicaSetController) updateRS(old, cur interface{}) {
+	oldRS := old.(*v1.ReplicaSet)
+	curRS := cur.(*v1.ReplicaSet)
+
+	// A ReplicaSet that was deleted and created again under the same name
+	// shows up as an update, handle the delete of the old one first.
+	if curRS.UID != oldRS.UID {
+		key, err := controller.KeyFunc(oldRS)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", oldRS, err))
+			return
+		}
+		rsc.deleteRS(cache.DeletedFinalStateUnknown{
+			Key: key,
+			Obj: oldRS,
+		})
+	}
+
+	// You might imagine that we only really need to enqueue the
+	// replica set when Spec changes, but it is safer to sync any
+	// time this function is triggered. That way a full informer
+	// resync can requeue any replica set that don't yet have pods
+	// but whose last attempts at creating a pod have failed (since
+	// we don't block on creation of pods) instead of those
+	// replica sets stalling indefinitely. Enqueueing every time
+	// does result in some spurious syncs (like when Status.Replica
+	// is updated and the watch notification from it retriggers
+	// this function), but in general extra resyncs shouldn't be
+	// that bad as ReplicaSets that haven't met expectations yet won't
+	// sync, and all the listing is done using local stores.
+	if replicasOf(oldRS) != replicasOf(curRS) {
+		log.Printf("%v %v updated. Desired pod count change: %d->%d", rsc.Kind, curRS.Name, replicasOf(oldRS), replicasOf(curRS))
+	}
+	rsc.enqueueRS(curRS)
+}
+
+func (rsc *ReplicaSetController) deleteRS(obj interface{}) {
+	rs, ok := obj.(*v1.ReplicaSet)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %#v", obj))
+			return
+		}
+		rs, ok = tombstone.Obj.(*v1.ReplicaSet)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not a ReplicaSet %#v", obj))
+			return
+		}
+	}
+
+	key, err := controller.KeyFunc(rs)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", rs, err))
+		return
+	}
+
+	log.Printf("Deleting %s %s", rsc.Kind, key)
+
+	// Delete expectations for the ReplicaSet so if we create a new one with the same name it starts clean
+	rsc.expectations.DeleteExpectations(key)
+
+	rsc.queue.Add(key)
+}
+
+// When a pod is created, enqueue the replica set that manages it and update its expectations.
+func (rsc *ReplicaSetController) addPod(obj interface{}) {
+	pod := obj.(*v1.Pod)
+
+	if !pod.DeletionTime.IsZero() {
+		// on a restart of the controller manager, it's possible a new pod shows up in a state that
+		// is already pending deletion. Prevent the pod from being a creation observation.
+		rsc.deletePod(pod)
+		return
+	}
+
+	// If it has a ControllerRef, that's all that matters.
+	if controllerRef := v1.GetControllerOf(pod); controllerRef != nil {
+		rs := rsc.resolveControllerRef(pod.Namespace, controllerRef)
+		if rs == nil {
+			return
+		}
+		rsKey, err := controller.KeyFunc(rs)
+		if err != nil {
+			return
+		}
+		rsc.expectations.CreationObserved(rsKey)
+		rsc.queue.Add(rsKey)
+		return
+	}
+
+	// Otherwise, it's an orphan. Get a list of all matching ReplicaSets and sync
+	// them to see if anyone wants to adopt it.
+	// DO NOT observe creation because no controller should be waiting for an
+	// orphan.
+	rss := rsc.getPodReplicaSets(pod)
+	if len(rss) == 0 {
+		return
+	}
+	for _, rs := range rss {
+		rsc.enqueueRS(rs)
+	}
+}
+
+// When a pod is updated, figure out what replica set/s manage it and wake them
+// up. If the labels of the pod have changed we need to awaken both the old
+// and new replica set. old and cur must be *v1.Pod types.
+func (rsc *ReplicaSetController) updatePod(old, cur interface{}) {
+	curPod := cur.(*v1.Pod)
+	oldPod := old.(*v1.Pod)
+	if curPod.ResourceVersion == oldPod.ResourceVersion {
+		// Periodic resync will send update events for all known pods.
+		// Two different versions of the same pod will always have different RVs.
+		return
+	}
+
+	labelChanged := !reflect.DeepEqual(curPod.Labels, oldPod.Labels)
+	if !curPod.DeletionTime.IsZero() {
+		// when a pod is deleted gracefully it's deletion time is first modified to reflect a grace period,
+		// and after such time has passed, carry actually deletes it from the store. We receive an update
+		// for modification of the deletion time and expect an rs to create more replicas asap, not wait
+		// until carry actually deletes the pod. This is different from the Phase of a pod changing, because
+		// an rs never initiates a phase change, and so is never asleep waiting for the same.
+		rsc.deletePod(curPod)
+		if labelChanged {
+			// we don't need to check the oldPod.DeletionTime because DeletionTime cannot be unset.
+			rsc.deletePod(oldPod)
+		}
+		return
+	}
+
+	curControllerRef := v1.GetControllerOf(curPod)
+	oldControllerRef := v1.GetControllerOf(oldPod)
+	controllerRefChanged := !reflect.DeepEqual(curControllerRef, oldControllerRef)
+	if controllerRefChanged && oldControllerRef != nil {
+		// The ControllerRef was changed. Sync the old controller, if any.
+		if rs := rsc.resolveControllerRef(oldPod.Namespace, oldControllerRef); rs != nil {
+			rsc.enqueueRS(rs)
+		}
+	}
+
+	// If it has a ControllerRef, that's all that matters.
+	if curControllerRef != nil {
+		rs := rsc.resolveControllerRef(curPod.Namespace, curControllerRef)
+		if rs == nil {
+			return
+		}
+		rsc.enqueueRS(rs)
+		// Nothing tells us when a ready pod becomes available, so resync the
+		// replica set MinReadySeconds after the pod transitioned to ready to
+		// count the newly available replica.
+		if !controller.IsPodReady(oldPod) && controller.IsPodReady(curPod) && rs.Spec.MinReadySeconds > 0 {
+			// Add a second to avoid milliseconds skew in AddAfter.
+			rsc.enqueueRSAfter(rs, (time.Duration(rs.Spec.MinReadySeconds)*time.Second)+time.Second)
+		}
+		return
+	}
+
+	// Otherwise, it's an orphan. If anything changed, sync matching controllers
+	// to see if anyone wants to adopt it now.
+	if labelChanged || controllerRefChanged {
+		rss := rsc.getPodReplicaSets(curPod)
+		if len(rss) == 0 {
+			return
+		}
+		for _, rs := range rss {
+			rsc.enqueueRS(rs)
+		}
+	}
+}
+
+// When a pod is deleted, enqueue the replica set that manages the pod and update its expectations.
+// obj could be an *v1.Pod, or a DeletionFinalStateUnknown marker item.
+func (rsc *ReplicaSetController) deletePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+
+	// When a delete is dropped, the relist will notice a pod in the store not
+	// in the list, leading to the insertion of a tombstone object which contains
+	// the deleted key/value. Note that this value might be stale. If the pod
+	// changed labels the new ReplicaSet will not be woken up till the periodic resync.
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %+v", obj))
+			return
+		}
+		pod, ok = tombstone.Obj.(*v1.Pod)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not a pod %#v", obj))
+			return
+		}
+	}
+
+	controllerRef := v1.GetControllerOf(pod)
+	if controllerRef == nil {
+		// No controller should care about orphans being deleted.
+		return
+	}
+	rs := rsc.resolveControllerRef(pod.Namespace, controllerRef)
+	if rs == nil {
+		return
+	}
+	rsKey, err := controller.KeyFunc(rs)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", rs, err))
+		return
+	}
+	rsc.expectations.DeletionObserved(rsKey, controller.PodKey(pod))
+	rsc.queue.Add(rsKey)
+}
+
+// worker runs a worker thread that just dequeues items, processes them, and marks them done.
+// It enforces that the syncHandler is never invoked concurrently with the same key.
+func (rsc *ReplicaSetController) worker(ctx context.Context) {
+	for rsc.processNextWorkItem(ctx) {
+	}
+}
+
+func (rsc *ReplicaSetController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := rsc.queue.Get()
+	if quit {
+		return false
+	}
+	defer rsc.queue.Done(key)
+
+	err := rsc.syncHandler(ctx, key.(string))
+	if err == nil {
+		rsc.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("sync %q failed with %v", key, err))
+	rsc.queue.AddRateLimited(key)
+
+	return true
+}
+
+// manageReplicas checks and updates replicas for the given ReplicaSet.
+// Does NOT modify <filteredPods>.
+// It will requeue the replica set in case of an error while creating/deleting pods.
+func (rsc *ReplicaSetController) manageReplicas(ctx context.Context, filteredPods []*v1.Pod, rs *v1.ReplicaSet) error {
+	diff := len(filteredPods) - int(replicasOf(rs))
+	rsKey, err := controller.KeyFunc(rs)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for %v %#v: %v", rsc.Kind, rs, err))
+		return nil
+	}
+	if diff < 0 {
+		diff *= -1
+		if diff > rsc.burstReplicas {
+			diff = rsc.burstReplicas
+		}
+		// TODO: Track UIDs of creates just like deletes. The problem currently
+		// is we'd need to wait on the result of a create to record the pod's
+		// UID, which would require locking *across* the create, which will turn
+		// into a performance bottleneck. We should generate a UID for the pod
+		// beforehand and store it via ExpectCreations.
+		if err := rsc.expectations.ExpectCreations(rsKey, diff); err != nil {
+			return err
+		}
+		log.Printf("Too few replicas for %v %s/%s, need %d, creating %d", rsc.Kind, rs.Namespace, rs.Name, replicasOf(rs), diff)
+		// Batch the pod creates. Batch sizes start at SlowStartInitialBatchSize
+		// and double with each successful iteration in a kind of "slow start".
+		// This handles attempts to start large numbers of pods that would
+		// likely all fail with the same error. For example a project with a
+		// low quota that attempts to create a large number of pods will be
+		// prevented from spamming the API service with the pod create requests
+		// after one of its pods fails.  Conveniently, this also prevents the
+		// event spam that those failures would generate.
+		successfulCreations, err := slowStartBatch(diff, controller.SlowStartInitialBatchSize, func() error {
+			return rsc.podControl.CreatePods(ctx, rs.Namespace, &rs.Spec.Template, rs, v1.NewControllerRef(rs, rsc.GroupVersion().String(), rsc.Kind))
+		})
+
+		// Any skipped pods that we never attempted to start shouldn't be expected.
+		// The skipped pods will be retried later. The next controller resync will
+		// retry the slow start process.
+		if skippedPods := diff - successfulCreations; skippedPods > 0 {
+			log.Printf("Slow-start failure. Skipping creation of %d pods, decrementing expectations for %v %v/%v", skippedPods, rsc.Kind, rs.Namespace, rs.Name)
+			for i := 0; i < skippedPods; i++ {
+				// Decrement the expected number of creates because the informer won't observe this pod
+				rsc.expectations.CreationObserved(rsKey)
+			}
+		}
+		return err
+	} else if diff > 0 {
+		if diff > rsc.burstReplicas {
+			diff = rsc.burstReplicas
+		}
+		log.Printf("Too many replicas for %v %s/%s, need %d, deleting %d", rsc.Kind, rs.Namespace, rs.Name, replicasOf(rs), diff)
+
+		// Choose which Pods to delete, preferring those in earlier phases of startup.
+		podsToDelete := getPodsToDelete(filteredPods, diff)
+
+		// Snapshot the UIDs (ns/name) of the pods we're expecting to see
+		// deleted, so we know to record their expectations exactly once either
+		// when we see it as an update of the deletion time, or as a delete.
+		// Note that if the labels on a pod/rs change in a way that the pod gets
+		// orphaned, the rs will only wake up after the expectations have
+		// expired even if other pods are deleted.
+		if err := rsc.expectations.ExpectDeletions(rsKey, getPodKeys(podsToDelete)); err != nil {
+			return err
+		}
+
+		errCh := make(chan error, diff)
+		var wg sync.WaitGroup
+		wg.Add(diff)
+		for _, pod := range podsToDelete {
+			go func(targetPod *v1.Pod) {
+				defer wg.Done()
+				if err := rsc.podControl.DeletePod(ctx, rs.Namespace, targetPod.Name, rs); err != nil {
+					// Decrement the expected number of deletes because the informer won't observe this deletion
+					podKey := controller.PodKey(targetPod)
+					rsc.expectations.DeletionObserved(rsKey, podKey)
+					if !apierrors.IsNotFound(err) {
+						log.Printf("Failed to delete %v, decremented expectations for %v %s/%s", podKey, rsc.Kind, rs.Namespace, rs.Name)
+						errCh <- err
+					}
+				}
+			}(pod)
+		}
+		wg.Wait()
+
+		select {
+		case err := <-errCh:
+			// all errors have been reported before and they're likely to be the same, so we'll only return the first one we hit.
+			if err != nil {
+				return err
+			}
+		default:
+		}
+	}
+
+	return nil
+}
+
+// syncReplicaSet will sync the ReplicaSet with the given key if it has had its expectations fulfilled,
+// meaning it did not expect to see any more of its pods created or deleted. This function is not meant to be
+// invoked concurrently with the same key.
+func (rsc *ReplicaSetController) syncReplicaSet(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	rs, err := rsc.rsLister.ReplicaSets(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Printf("%v %v has been deleted", rsc.Kind, key)
+		rsc.expectations.DeleteExpectations(key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rsNeedsSync := rsc.expectations.SatisfiedExpectations(key)
+	selector, err := v1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("error converting pod selector to selector for rs %v/%v: %v", namespace, name, err))
+		return nil
+	}
+
+	// list all pods to include the pods that don't match the rs`s selector
+	// anymore but has the stale controller ref.
+	allPods, err := rsc.podLister.Pods(rs.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	// Ignore inactive pods.
+	filteredPods := controller.FilterActivePods(allPods)
+
+	// NOTE: filteredPods are pointing to objects from cache - if you need to
+	// modify them, you need to copy it first.
+	filteredPods, err = rsc.claimPods(ctx, rs, selector, filteredPods)
+	if err != nil {
+		return err
+	}
+
+	var manageReplicasErr error
+	if rsNeedsSync && rs.DeletionTime.IsZero() {
+		manageReplicasErr = rsc.manageReplicas(ctx, filteredPods, rs)
+	}
+	rs = rs.DeepCopy()
+	newStatus := calculateStatus(rs, filteredPods, manageReplicasErr)
+
+	// Always updates status as pods come up or die.
+	updatedRS, err := updateReplicaSetStatus(ctx, rsc.client.CarryV1().ReplicaSets(rs.Namespace), rs, newStatus)
+	if err != nil {
+		// Multiple things could lead to this update failing. Returning an error
+		// requeues the replica set without forcing a hotloop.
+		return err
+	}
+	// Resync the ReplicaSet after MinReadySeconds as a last line of defense to guard against clock-skew.
+	if manageReplicasErr == nil && updatedRS.Spec.MinReadySeconds > 0 &&
+		updatedRS.Status.ReadyReplicas == replicasOf(updatedRS) &&
+		updatedRS.Status.AvailableReplicas != replicasOf(updatedRS) {
+		rsc.queue.AddAfter(key, time.Duration(updatedRS.Spec.MinReadySeconds)*time.Second)
+	}
+	return manageReplicasErr
+}
+
+func (rsc *ReplicaSetController) claimPods(ctx context.Context, rs *v1.ReplicaSet, selector labels.Selector, filteredPods []*v1.Pod) ([]*v1.Pod, error) {
+	// If any adoptions are attempted, we should first recheck for deletion with
+	// an uncached read sometime after listing Pods.
+	canAdoptFunc := controller.RecheckDeletionTimestamp(func(ctx context.Context) (v1.Object, error) {
+		fresh, err := rsc.client.CarryV1().ReplicaSets(rs.Namespace).Get(ctx, rs.Name)
+		if err != nil {
+			return nil, err
+		}
+		if fresh.UID != rs.UID {
+			return nil, fmt.Errorf("original %v %v/%v is gone: got uid %v, wanted %v", rsc.Kind, rs.Namespace, rs.Name, fresh.UID, rs.UID)
+		}
+		return fresh, nil
+	})
+	cm := controller.NewPodControllerRefManager(rsc.podControl, rs, selector, rsc.GroupVersionKind, canAdoptFunc)
+	return cm.ClaimPods(ctx, filteredPods)
+}
+
+// slowStartBatch tries to call the provided function a total of 'count' times,
+// starting slow to check for errors, then speeding up if calls succeed.
+//
+// It groups the calls into batches, starting with a group of initialBatchSize.
+// Within each batch, it may call the function multiple times concurrently.
+//
+// If a whole batch succeeds, the next batch may get exponentially larger.
+// If there are any failures in a batch, all remaining batches are skipped
+// after waiting for the current batch to complete.
+//
+// It returns the number of successful calls to the function.
+func slowStartBatch(count int, initialBatchSize int, fn func() error) (int, error) {
+	remaining := count
+	successes := 0
+	for batchSize := integerMin(remaining, initialBatchSize); batchSize > 0; batchSize = integerMin(2*batchSize, remaining) {
+		errCh := make(chan error, batchSize)
+		var wg sync.WaitGroup
+		wg.Add(batchSize)
+		for i := 0; i < batchSize; i++ {
+			go func() {
+				defer wg.Done()
+				if err := fn(); err != nil {
+					errCh <- err
+				}
+			}()
+		}
+		wg.Wait()
+		curSuccesses := batchSize - len(errCh)
+		successes += curSuccesses
+		if len(errCh) > 0 {
+			return successes, <-errCh
+		}
+		remaining -= batchSize
+	}
+	return successes, nil
+}
+
+func integerMin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// getPodsToDelete ranks the pods so that unscheduled, not ready and young
+// pods are deleted first, and returns the first diff of them.
+func getPodsToDelete(filteredPods []*v1.Pod, diff int) []*v1.Pod {
+	// No need to sort pods if we are about to delete all of them.
+	// diff will always be <= len(filteredPods), so not need to handle > case.
+	if diff < len(filteredPods) {
+		sort.Sort(controller.ActivePods(filteredPods))
+	}
+	return filteredPods[:diff]
+}
+
+func getPodKeys(pods []*v1.Pod) []string {
+	podKeys := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podKeys = append(podKeys, controller.PodKey(pod))
+	}
+	return podKeys
+}
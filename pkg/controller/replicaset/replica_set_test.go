@@ -0,0 +1,415 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/client/cache"
+	"github.com/opencarry/carry/pkg/client/clientset/fake"
+	"github.com/opencarry/carry/pkg/client/informers"
+	clienttesting "github.com/opencarry/carry/pkg/client/testing"
+	"github.com/opencarry/carry/pkg/controller"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/util/wait"
+)
+
+func alwaysReady() bool { return true }
+
+func newReplicaSet(replicas int64, selectorMap map[string]string) *v1.ReplicaSet {
+	return &v1.ReplicaSet{
+		ObjectMeta: v1.ObjectMeta{
+			UID:             "rs-uid",
+			Name:            "foobar",
+			Namespace:       "default",
+			ResourceVersion: "18",
+		},
+		Spec: v1.ReplicaSetSpec{
+			Replicas: &replicas,
+			Selector: &v1.LabelSelector{MatchLabels: selectorMap},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"name": "foo", "type": "production"},
+				},
+			},
+		},
+	}
+}
+
+// newPod creates a pod of rs, an empty rs makes an orphan.
+func newPod(name string, rs *v1.ReplicaSet, phase v1.PodPhase) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       v1.UID(name + "-uid"),
+			Labels:    map[string]string{"name": "foo", "type": "production"},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+	if rs != nil {
+		pod.OwnerReferences = []v1.OwnerReference{*v1.NewControllerRef(rs, controllerKind.GroupVersion().String(), controllerKind.Kind)}
+	}
+	return pod
+}
+
+func readyPod(pod *v1.Pod, since time.Time) *v1.Pod {
+	pod.Spec.NodeName = "node"
+	pod.Status.Phase = v1.PodRunning
+	pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, State: v1.ConditionTrue, LastTransitionTime: since}}
+	return pod
+}
+
+type testController struct {
+	*ReplicaSetController
+	client     *fake.Clientset
+	podControl *controller.FakePodControl
+	rsStore    cache.Indexer
+	podStore   cache.Indexer
+}
+
+// newTestController creates a controller whose listers are filled by the test
+// instead of running informers.
+func newTestController(rs *v1.ReplicaSet, pods ...*v1.Pod) *testController {
+	client := fake.NewSimpleClientset(rs)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	rsInformer := factory.CarryV1().ReplicaSets()
+	podInformer := factory.CarryV1().Pods()
+	rsc := NewReplicaSetController(rsInformer, podInformer, client, BurstReplicas)
+	rsc.rsListerSynced = alwaysReady
+	rsc.podListerSynced = alwaysReady
+	podControl := &controller.FakePodControl{}
+	rsc.podControl = podControl
+
+	tc := &testController{
+		ReplicaSetController: rsc,
+		client:               client,
+		podControl:           podControl,
+		rsStore:              rsInformer.Informer().GetIndexer(),
+		podStore:             podInformer.Informer().GetIndexer(),
+	}
+	tc.rsStore.Add(rs)
+	for _, pod := range pods {
+		tc.podStore.Add(pod)
+	}
+	return tc
+}
+
+func (tc *testController) getRS(t *testing.T) *v1.ReplicaSet {
+	t.Helper()
+	rs, err := tc.client.CarryV1().ReplicaSets("default").Get(context.TODO(), "foobar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+func validateSyncReplicaSet(t *testing.T, podControl *controller.FakePodControl, expectedCreates, expectedDeletes, expectedPatches int) {
+	t.Helper()
+	if e, a := expectedCreates, len(podControl.Templates); e != a {
+		t.Errorf("unexpected number of creates. Expected %d, saw %d", e, a)
+	}
+	if e, a := expectedDeletes, len(podControl.DeletePodName); e != a {
+		t.Errorf("unexpected number of deletes. Expected %d, saw %d", e, a)
+	}
+	if e, a := expectedPatches, len(podControl.Patches); e != a {
+		t.Errorf("unexpected number of patches. Expected %d, saw %d", e, a)
+	}
+}
+
+func TestSyncReplicaSetCreatesPods(t *testing.T) {
+	labelMap := map[string]string{"foo": "bar"}
+	rs := newReplicaSet(2, labelMap)
+	rs.Spec.Template.Labels = labelMap
+	tc := newTestController(rs)
+
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 2, 0, 0)
+	for _, ref := range tc.podControl.ControllerRefs {
+		if ref.UID != rs.UID || ref.Kind != "replicaset" || ref.Controller == nil || !*ref.Controller {
+			t.Errorf("unexpected controller ref %+v", ref)
+		}
+	}
+
+	// 创建的pod还没被观察到之前，不会重复创建
+	tc.podControl.Clear()
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 0, 0, 0)
+
+	// 期望过的pod一直没有出现，处理完期望之后重新创建
+	tc.expectations.CreationObserved("default/foobar")
+	tc.expectations.CreationObserved("default/foobar")
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 2, 0, 0)
+}
+
+func TestSyncReplicaSetDeletesPods(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(1, labelMap)
+	now := time.Now()
+	unscheduled := newPod("unscheduled", rs, v1.PodPending)
+	notReady := newPod("not-ready", rs, v1.PodRunning)
+	notReady.Spec.NodeName = "node"
+	ready := readyPod(newPod("ready", rs, v1.PodRunning), now.Add(-time.Hour))
+	succeeded := newPod("succeeded", rs, v1.PodSucceeded)
+	tc := newTestController(rs, ready, notReady, unscheduled, succeeded)
+
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 0, 2, 0)
+	deleted := append([]string(nil), tc.podControl.DeletePodName...)
+	sort.Strings(deleted)
+	if deleted[0] != "not-ready" || deleted[1] != "unscheduled" {
+		t.Errorf("expected the unscheduled and not ready pods to be deleted, got %v", deleted)
+	}
+	if status := tc.getRS(t).Status; status.Replicas != 3 || status.ReadyReplicas != 1 || status.AvailableReplicas != 1 {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	// 删除事件被观察到之前不会再删除
+	tc.podControl.Clear()
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 0, 0, 0)
+
+	// 设置deletion_time的更新和随后的删除事件只计一次
+	deleting := unscheduled.DeepCopy()
+	deleting.ResourceVersion = "2"
+	deleting.DeletionTime = now
+	tc.updatePod(unscheduled, deleting)
+	tc.deletePod(deleting)
+	if tc.expectations.SatisfiedExpectations("default/foobar") {
+		t.Error("expected one deletion to be still expected")
+	}
+	tc.deletePod(notReady)
+	if !tc.expectations.SatisfiedExpectations("default/foobar") {
+		t.Error("expected the deletions to be observed")
+	}
+}
+
+func TestSyncReplicaSetReplicaFailure(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(3, labelMap)
+	tc := newTestController(rs)
+	tc.podControl.Err = errors.New("exceeded quota")
+
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err == nil {
+		t.Fatal("expected the create error to be returned")
+	}
+	// slow start在第一次失败后就停止
+	if e, a := 1, tc.podControl.CreateCallCount; e != a {
+		t.Errorf("expected %d create call, got %d", e, a)
+	}
+	if !tc.expectations.SatisfiedExpectations("default/foobar") {
+		t.Error("expected the skipped creations not to be expected")
+	}
+	cond := GetCondition(tc.getRS(t).Status, v1.ReplicaSetReplicaFailure)
+	if cond == nil || cond.State != v1.ConditionTrue || cond.Reason != controller.FailedCreatePodReason || cond.Message != "exceeded quota" {
+		t.Fatalf("expected a replica_failure condition, got %+v", cond)
+	}
+
+	// 副本数达到之后清除replica_failure
+	tc.podControl.Clear()
+	tc.podControl.Err = nil
+	tc.rsStore.Update(tc.getRS(t))
+	for i := 0; i < 3; i++ {
+		tc.podStore.Add(newPod(fmt.Sprintf("pod-%d", i), rs, v1.PodRunning))
+	}
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 0, 0, 0)
+	if cond := GetCondition(tc.getRS(t).Status, v1.ReplicaSetReplicaFailure); cond != nil {
+		t.Errorf("expected the replica_failure condition to be removed, got %+v", cond)
+	}
+}
+
+func TestSyncReplicaSetAdoptsAndReleases(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(2, labelMap)
+	orphan := newPod("orphan", nil, v1.PodRunning)
+	owned := newPod("owned", rs, v1.PodRunning)
+	stale := newPod("stale", rs, v1.PodRunning)
+	stale.Labels = map[string]string{"name": "bar"}
+	other := newReplicaSet(1, labelMap)
+	other.UID = "other-uid"
+	foreign := newPod("foreign", other, v1.PodRunning)
+	tc := newTestController(rs, orphan, owned, stale, foreign)
+
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	// 领养orphan，释放stale，已经有两个副本不需要创建
+	validateSyncReplicaSet(t, tc.podControl, 0, 0, 2)
+	if e, a := int64(2), tc.getRS(t).Status.Replicas; e != a {
+		t.Errorf("expected %d replicas in the status, got %d", e, a)
+	}
+}
+
+func TestSyncReplicaSetBeingDeleted(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(2, labelMap)
+	rs.DeletionTime = time.Now()
+	tc := newTestController(rs, newPod("orphan", nil, v1.PodRunning))
+
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	validateSyncReplicaSet(t, tc.podControl, 0, 0, 0)
+}
+
+func TestAvailableReplicasWithMinReadySeconds(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(2, labelMap)
+	rs.Spec.MinReadySeconds = 60
+	now := time.Now()
+	tc := newTestController(rs,
+		readyPod(newPod("available", rs, v1.PodRunning), now.Add(-2*time.Minute)),
+		readyPod(newPod("just-ready", rs, v1.PodRunning), now),
+	)
+
+	if err := tc.syncReplicaSet(context.TODO(), "default/foobar"); err != nil {
+		t.Fatal(err)
+	}
+	status := tc.getRS(t).Status
+	if status.ReadyReplicas != 2 || status.AvailableReplicas != 1 {
+		t.Errorf("expected 2 ready and 1 available replicas, got %+v", status)
+	}
+}
+
+func TestPodEventsEnqueueReplicaSet(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(1, labelMap)
+	tc := newTestController(rs)
+
+	// 标签变为匹配的orphan唤醒对应的replica set
+	old := newPod("pod", nil, v1.PodRunning)
+	old.Labels = map[string]string{"name": "bar"}
+	old.ResourceVersion = "1"
+	cur := newPod("pod", nil, v1.PodRunning)
+	cur.ResourceVersion = "2"
+	tc.updatePod(old, cur)
+	if e, a := 1, tc.queue.Len(); e != a {
+		t.Fatalf("expected %d queued replica set, got %d", e, a)
+	}
+	key, _ := tc.queue.Get()
+	tc.queue.Done(key)
+	if key != "default/foobar" {
+		t.Errorf("expected default/foobar, got %v", key)
+	}
+
+	// 属于其它controller的pod不唤醒
+	other := newReplicaSet(1, labelMap)
+	other.UID = "other-uid"
+	tc.addPod(newPod("foreign", other, v1.PodRunning))
+	if e, a := 0, tc.queue.Len(); e != a {
+		t.Errorf("expected %d queued replica sets, got %d", e, a)
+	}
+
+	// 自己的pod创建后记录期望
+	if err := tc.expectations.ExpectCreations("default/foobar", 1); err != nil {
+		t.Fatal(err)
+	}
+	tc.addPod(newPod("owned", rs, v1.PodRunning))
+	if !tc.expectations.SatisfiedExpectations("default/foobar") {
+		t.Error("expected the creation to be observed")
+	}
+	if e, a := 1, tc.queue.Len(); e != a {
+		t.Errorf("expected %d queued replica set, got %d", e, a)
+	}
+}
+
+func TestReplicaSetControllerRun(t *testing.T) {
+	labelMap := map[string]string{"name": "foo"}
+	rs := newReplicaSet(3, labelMap)
+	client := fake.NewSimpleClientset(rs)
+	// 服务端会根据generate_name生成名字，fake clientset不会
+	var created int64
+	client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		pod := action.(clienttesting.CreateAction).GetObject().(*v1.Pod)
+		if len(pod.Name) == 0 {
+			n := atomic.AddInt64(&created, 1)
+			pod.Name = fmt.Sprintf("%s%d", pod.GenerateName, n)
+			pod.UID = v1.UID(pod.Name + "-uid")
+		}
+		return false, nil, nil
+	})
+	factory := informers.NewSharedInformerFactory(client, 0)
+	rsc := NewReplicaSetController(factory.CarryV1().ReplicaSets(), factory.CarryV1().Pods(), client, BurstReplicas)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory.Start(ctx.Done())
+	go rsc.Run(ctx, 2)
+
+	waitForPods := func(expected int) []v1.Pod {
+		var pods *v1.PodList
+		err := wait.PollImmediateUntil(10*time.Millisecond, func() (bool, error) {
+			var err error
+			pods, err = client.CarryV1().Pods("default").List(ctx, v1.ListOptions{})
+			if err != nil {
+				return false, err
+			}
+			rs, err := client.CarryV1().ReplicaSets("default").Get(ctx, "foobar")
+			if err != nil {
+				return false, err
+			}
+			return len(pods.Items) == expected && rs.Status.Replicas == int64(expected), nil
+		}, timeoutCh(wait.ForeverTestTimeout))
+		if err != nil {
+			t.Fatalf("waiting for %d pods: %v, have %d", expected, err, len(pods.Items))
+		}
+		return pods.Items
+	}
+
+	pods := waitForPods(3)
+	for i := range pods {
+		if !v1.IsControlledBy(&pods[i], rs) {
+			t.Errorf("expected pod %s to be controlled by the replica set", pods[i].Name)
+		}
+	}
+
+	// 删掉一个pod之后补齐
+	if err := client.CarryV1().Pods("default").Delete(ctx, pods[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	waitForPods(3)
+	if n := atomic.LoadInt64(&created); n != 4 {
+		t.Errorf("expected 4 pods to be created, got %d", n)
+	}
+}
+
+func timeoutCh(d time.Duration) <-chan struct{} {
+	ch := make(chan struct{})
+	time.AfterFunc(d, func() { close(ch) })
+	return ch
+}
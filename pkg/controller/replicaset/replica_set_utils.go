@@ -0,0 +1,185 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaset
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/opencarry/carry/pkg/api/defaulting"
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	carryv1 "github.com/opencarry/carry/pkg/client/clientset/typed/carry.i/v1"
+	"github.com/opencarry/carry/pkg/controller"
+	"github.com/opencarry/carry/pkg/labels"
+)
+
+// replicasOf 返回期望的副本数，未设置时为默认值
+func replicasOf(rs *v1.ReplicaSet) int64 {
+	if rs.Spec.Replicas == nil {
+		return defaulting.DefaultReplicas
+	}
+	return *rs.Spec.Replicas
+}
+
+// updateReplicaSetStatus attempts to update the Status.Replicas of the given ReplicaSet, with a single GET/PUT retry.
+func updateReplicaSetStatus(ctx context.Context, c carryv1.ReplicaSetInterface, rs *v1.ReplicaSet, newStatus v1.ReplicaSetStatus) (*v1.ReplicaSet, error) {
+	// This is the steady state. It happens when the ReplicaSet doesn't have any expectations
+	// and nothing changed since the last sync. If the generations differ but the replicas are
+	// the same, a caller might've resized to the same replica count.
+	if rs.Status.Replicas == newStatus.Replicas &&
+		rs.Status.FullyLabeledReplicas == newStatus.FullyLabeledReplicas &&
+		rs.Status.ReadyReplicas == newStatus.ReadyReplicas &&
+		rs.Status.AvailableReplicas == newStatus.AvailableReplicas &&
+		rs.Generation == rs.Status.ObservedGeneration &&
+		reflect.DeepEqual(rs.Status.Conditions, newStatus.Conditions) {
+		return rs, nil
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully indicate
+	// that we've seen a spec update when we retry.
+	// TODO: This can clobber an update if we allow multiple agents to write to the
+	// same status.
+	newStatus.ObservedGeneration = rs.Generation
+
+	var getErr, updateErr error
+	var updatedRS *v1.ReplicaSet
+	for i, rs := 0, rs; ; i++ {
+		log.Printf("Updating status for %v %s/%s, replicas %d->%d (need %d), fully_labeled_replicas %d->%d, ready_replicas %d->%d, available_replicas %d->%d, sequence No: %v->%v",
+			controllerKind.Kind, rs.Namespace, rs.Name,
+			rs.Status.Replicas, newStatus.Replicas, replicasOf(rs),
+			rs.Status.FullyLabeledReplicas, newStatus.FullyLabeledReplicas,
+			rs.Status.ReadyReplicas, newStatus.ReadyReplicas,
+			rs.Status.AvailableReplicas, newStatus.AvailableReplicas,
+			rs.Status.ObservedGeneration, newStatus.ObservedGeneration)
+
+		rs.Status = newStatus
+		updatedRS, updateErr = c.UpdateStatus(ctx, rs, v1.UpdateOptions{})
+		if updateErr == nil {
+			return updatedRS, nil
+		}
+		// Stop retrying if we exceed statusUpdateRetries - the replicaSet will be requeued with a rate limit.
+		if i >= statusUpdateRetries {
+			break
+		}
+		// Update the ReplicaSet with the latest resource version for the next poll
+		if rs, getErr = c.Get(ctx, rs.Name); getErr != nil {
+			// If the GET fails we can't trust status.Replicas anymore. This error
+			// is bound to be more interesting than the update failure.
+			return nil, getErr
+		}
+	}
+
+	return nil, updateErr
+}
+
+func calculateStatus(rs *v1.ReplicaSet, filteredPods []*v1.Pod, manageReplicasErr error) v1.ReplicaSetStatus {
+	newStatus := rs.Status
+	// Count the number of pods that have labels matching the labels of the pod
+	// template of the replica set, the matching pods may have more
+	// labels than are in the template. Because the label of podTemplateSpec is
+	// a superset of the selector of the replica set, so the possible
+	// matching pods must be part of the filteredPods.
+	fullyLabeledReplicasCount := 0
+	readyReplicasCount := 0
+	availableReplicasCount := 0
+	templateLabel := labels.Set(rs.Spec.Template.Labels).AsSelectorPreValidated()
+	now := time.Now()
+	for _, pod := range filteredPods {
+		if templateLabel.Matches(labels.Set(pod.Labels)) {
+			fullyLabeledReplicasCount++
+		}
+		if controller.IsPodReady(pod) {
+			readyReplicasCount++
+			if controller.IsPodAvailable(pod, rs.Spec.MinReadySeconds, now) {
+				availableReplicasCount++
+			}
+		}
+	}
+
+	failureCond := GetCondition(rs.Status, v1.ReplicaSetReplicaFailure)
+	if manageReplicasErr != nil && failureCond == nil {
+		var reason string
+		if diff := len(filteredPods) - int(replicasOf(rs)); diff < 0 {
+			reason = controller.FailedCreatePodReason
+		} else if diff > 0 {
+			reason = controller.FailedDeletePodReason
+		}
+		cond := NewReplicaSetCondition(v1.ReplicaSetReplicaFailure, v1.ConditionTrue, reason, manageReplicasErr.Error())
+		SetCondition(&newStatus, cond)
+	} else if manageReplicasErr == nil && failureCond != nil {
+		RemoveCondition(&newStatus, v1.ReplicaSetReplicaFailure)
+	}
+
+	newStatus.Replicas = int64(len(filteredPods))
+	newStatus.FullyLabeledReplicas = int64(fullyLabeledReplicasCount)
+	newStatus.ReadyReplicas = int64(readyReplicasCount)
+	newStatus.AvailableReplicas = int64(availableReplicasCount)
+	return newStatus
+}
+
+// NewReplicaSetCondition creates a new replicaset condition.
+func NewReplicaSetCondition(condType v1.ReplicaSetConditionType, state v1.ConditionState, reason, msg string) v1.ReplicaSetCondition {
+	now := time.Now()
+	return v1.ReplicaSetCondition{
+		Type:               condType,
+		State:              state,
+		LastTransitionTime: now,
+		LastUpdateTime:     now,
+		Reason:             reason,
+		Message:            msg,
+	}
+}
+
+// GetCondition returns a replicaset condition with the provided type if it exists.
+func GetCondition(status v1.ReplicaSetStatus, condType v1.ReplicaSetConditionType) *v1.ReplicaSetCondition {
+	for _, c := range status.Conditions {
+		if c.Type == condType {
+			return &c
+		}
+	}
+	return nil
+}
+
+// SetCondition adds/replaces the given condition in the replicaset status. If the condition that we
+// are about to add already exists and has the same state and reason then we are not going to update.
+func SetCondition(status *v1.ReplicaSetStatus, condition v1.ReplicaSetCondition) {
+	currentCond := GetCondition(*status, condition.Type)
+	if currentCond != nil && currentCond.State == condition.State && currentCond.Reason == condition.Reason {
+		return
+	}
+	newConditions := filterOutCondition(status.Conditions, condition.Type)
+	status.Conditions = append(newConditions, condition)
+}
+
+// RemoveCondition removes the condition with the provided type from the replicaset status.
+func RemoveCondition(status *v1.ReplicaSetStatus, condType v1.ReplicaSetConditionType) {
+	status.Conditions = filterOutCondition(status.Conditions, condType)
+}
+
+// filterOutCondition returns a new slice of replicaset conditions without conditions with the provided type.
+func filterOutCondition(conditions []v1.ReplicaSetCondition, condType v1.ReplicaSetConditionType) []v1.ReplicaSetCondition {
+	var newConditions []v1.ReplicaSetCondition
+	for _, c := range conditions {
+		if c.Type == condType {
+			continue
+		}
+		newConditions = append(newConditions, c)
+	}
+	return newConditions
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"testing"
+
+	"github.com/opencarry/carry/pkg/selection"
+)
+
+func TestSelectorParse(t *testing.T) {
+	testGoodStrings := []struct {
+		in, out string
+	}{
+		{"x=a,y=b,z=c", "x=a,y=b,z=c"},
+		{"", ""},
+		{"x!=y", "x!=y"},
+		{"x==a", "x==a"},
+		{"x=", "x="},
+		{"x= ", "x="},
+		{"x=,z= ", "x=,z="},
+		{"!x", "!x"},
+		{"x>1", "x>1"},
+		{"x<7", "x<7"},
+		{"app=web,tier in (a,b),!legacy", "app=web,!legacy,tier in (a,b)"},
+		{"x in (b,a)", "x in (a,b)"},
+		{"x notin (a)", "x notin (a)"},
+	}
+	testBadStrings := []string{
+		"x=a||y=b",
+		"x==a==b",
+		"!x=a",
+		"x<a",
+		"x in",
+		"x in (a",
+		"x notin ()a",
+		",x",
+		"x,",
+	}
+	for _, test := range testGoodStrings {
+		lq, err := Parse(test.in)
+		if err != nil {
+			t.Errorf("%v: error %v (%#v)\n", test.in, err, err)
+			continue
+		}
+		if test.out != lq.String() {
+			t.Errorf("%v restring gave: %v\n", test.in, lq.String())
+		}
+	}
+	for _, test := range testBadStrings {
+		if _, err := Parse(test); err == nil {
+			t.Errorf("%v: did not get expected error\n", test)
+		}
+	}
+}
+
+func expectMatch(t *testing.T, selector string, ls Set) {
+	lq, err := Parse(selector)
+	if err != nil {
+		t.Errorf("Unable to parse %v as a selector\n", selector)
+		return
+	}
+	if !lq.Matches(ls) {
+		t.Errorf("Wanted %s to match '%s', but it did not.\n", selector, ls)
+	}
+}
+
+func expectNoMatch(t *testing.T, selector string, ls Set) {
+	lq, err := Parse(selector)
+	if err != nil {
+		t.Errorf("Unable to parse %v as a selector\n", selector)
+		return
+	}
+	if lq.Matches(ls) {
+		t.Errorf("Wanted '%s' to not match '%s', but it did.", selector, ls)
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	expectMatch(t, "", Set{"x": "y"})
+	expectMatch(t, "x=y", Set{"x": "y"})
+	expectMatch(t, "x=y,z=w", Set{"x": "y", "z": "w"})
+	expectMatch(t, "x!=y,z!=w", Set{"x": "z", "z": "a"})
+	expectMatch(t, "notin=in", Set{"notin": "in"})
+	expectMatch(t, "x", Set{"x": "z"})
+	expectMatch(t, "!x", Set{"y": "z"})
+	expectMatch(t, "x>1", Set{"x": "2"})
+	expectMatch(t, "x<1", Set{"x": "0"})
+	expectMatch(t, "app=web,tier in (a,b),!legacy", Set{"app": "web", "tier": "b"})
+	expectNoMatch(t, "x=z", Set{})
+	expectNoMatch(t, "x=y", Set{"x": "z"})
+	expectNoMatch(t, "x=y,z=w", Set{"x": "w", "z": "w"})
+	expectNoMatch(t, "x!=y,z!=w", Set{"x": "z", "z": "w"})
+	expectNoMatch(t, "x", Set{"y": "z"})
+	expectNoMatch(t, "!x", Set{"x": "z"})
+	expectNoMatch(t, "x>1", Set{"x": "0"})
+	expectNoMatch(t, "x<1", Set{"x": "2"})
+	expectNoMatch(t, "app=web,tier in (a,b),!legacy", Set{"app": "web", "tier": "b", "legacy": "true"})
+
+	labelset := Set{
+		"foo": "bar",
+		"baz": "blah",
+	}
+	expectMatch(t, "foo=bar", labelset)
+	expectMatch(t, "baz=blah", labelset)
+	expectMatch(t, "foo=bar,baz=blah", labelset)
+	expectNoMatch(t, "foo=blah", labelset)
+	expectNoMatch(t, "baz=bar", labelset)
+	expectNoMatch(t, "foo=bar,foobar=bar,baz=blah", labelset)
+}
+
+func TestEverythingAndNothing(t *testing.T) {
+	if !Everything().Matches(Set{"x": "y"}) {
+		t.Errorf("Everything() should match all labels")
+	}
+	if !Everything().Empty() {
+		t.Errorf("Everything() should be empty")
+	}
+	if Nothing().Matches(Set{}) {
+		t.Errorf("Nothing() should not match any labels")
+	}
+	if Nothing().Empty() {
+		t.Errorf("Nothing() should not be empty")
+	}
+}
+
+func TestNewRequirement(t *testing.T) {
+	tests := []struct {
+		key     string
+		op      selection.Operator
+		vals    []string
+		success bool
+	}{
+		{"x", selection.In, []string{"a"}, true},
+		{"x", selection.In, nil, false},
+		{"x", selection.Equals, []string{"a", "b"}, false},
+		{"x", selection.Exists, nil, true},
+		{"x", selection.Exists, []string{"a"}, false},
+		{"x", selection.GreaterThan, []string{"a"}, false},
+		{"x", selection.Operator("unknown"), []string{"a"}, false},
+		{"", selection.Exists, nil, false},
+		{"x", selection.In, []string{"a b"}, false},
+	}
+	for _, test := range tests {
+		_, err := NewRequirement(test.key, test.op, test.vals)
+		if test.success && err != nil {
+			t.Errorf("%s %s %v: unexpected error: %v", test.key, test.op, test.vals, err)
+		}
+		if !test.success && err == nil {
+			t.Errorf("%s %s %v: expected error", test.key, test.op, test.vals)
+		}
+	}
+}
+
+func TestRequiresExactMatch(t *testing.T) {
+	sel, err := Parse("x=a,y in (b),z in (c,d)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, found := sel.RequiresExactMatch("x"); !found || v != "a" {
+		t.Errorf("expected x=a, got %q %v", v, found)
+	}
+	if v, found := sel.RequiresExactMatch("y"); !found || v != "b" {
+		t.Errorf("expected y=b, got %q %v", v, found)
+	}
+	if _, found := sel.RequiresExactMatch("z"); found {
+		t.Errorf("expected z not to require an exact match")
+	}
+}
+
+func TestSetSelectorParser(t *testing.T) {
+	ls := Set{"foo": "bar", "baz": "qux"}
+	sel := SelectorFromSet(ls)
+	if sel.String() != "baz=qux,foo=bar" {
+		t.Errorf("unexpected selector string %q", sel.String())
+	}
+	if !sel.Matches(ls) {
+		t.Errorf("expected %v to match %v", sel, ls)
+	}
+	if _, err := ValidatedSelectorFromSet(Set{"a b": "c"}); err == nil {
+		t.Errorf("expected an error for an invalid label key")
+	}
+}
@@ -0,0 +1,116 @@
+package runtime_test
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+type unregistered struct {
+	v1.TypeMeta
+}
+
+func (in *unregistered) DeepCopyObject() runtime.Object {
+	out := *in
+	return &out
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := v1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSchemeNew(t *testing.T) {
+	s := newScheme(t)
+	testCases := map[string]runtime.Object{
+		"pod":           &v1.Pod{},
+		"podlist":       &v1.PodList{},
+		"deployment":    &v1.Deployment{},
+		"replicaset":    &v1.ReplicaSet{},
+		"statefulset":   &v1.StatefulSet{},
+		"daemonset":     &v1.DaemonSet{},
+		"job":           &v1.Job{},
+		"service":       &v1.Service{},
+		"node":          &v1.Node{},
+		"namespace":     &v1.Namespace{},
+		"configmap":     &v1.ConfigMap{},
+		"configmaplist": &v1.ConfigMapList{},
+		"event":         &v1.Event{},
+		"binding":       &v1.Binding{},
+	}
+	for kind, expected := range testCases {
+		gvk := v1.SchemeGroupVersion.WithKind(kind)
+		if !s.Recognizes(gvk) {
+			t.Errorf("%s: expected scheme to recognize kind", kind)
+		}
+		obj, err := s.New(gvk)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", kind, err)
+			continue
+		}
+		if reflect.TypeOf(obj) != reflect.TypeOf(expected) {
+			t.Errorf("%s: expected %T, got %T", kind, expected, obj)
+		}
+	}
+}
+
+func TestSchemeObjectKinds(t *testing.T) {
+	s := newScheme(t)
+	gvks, unversioned, err := s.ObjectKinds(&v1.ReplicaSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []schema.GroupVersionKind{{Group: "carry.i", Version: "v1", Kind: "replicaset"}}
+	if unversioned || !reflect.DeepEqual(gvks, expected) {
+		t.Errorf("expected %v, got %v (unversioned=%t)", expected, gvks, unversioned)
+	}
+	if !s.IsGroupRegistered(v1.GroupName) {
+		t.Errorf("expected group %q to be registered", v1.GroupName)
+	}
+	if !s.IsVersionRegistered(v1.SchemeGroupVersion) {
+		t.Errorf("expected version %v to be registered", v1.SchemeGroupVersion)
+	}
+}
+
+func TestSchemeNotRegistered(t *testing.T) {
+	s := newScheme(t)
+	_, err := s.New(schema.GroupVersionKind{Group: "carry.i", Version: "v1", Kind: "unknown"})
+	if !runtime.IsNotRegisteredError(err) {
+		t.Errorf("expected not registered error, got %v", err)
+	}
+	_, _, err = s.ObjectKinds(&unregistered{})
+	if !runtime.IsNotRegisteredError(err) {
+		t.Errorf("expected not registered error, got %v", err)
+	}
+	if s.IsGroupRegistered("other.carry.i") {
+		t.Errorf("unexpected group registered")
+	}
+}
+
+func TestDeepCopyObject(t *testing.T) {
+	grace := int64(30)
+	pod := &v1.Pod{
+		TypeMeta:   v1.TypeMeta{Kind: "pod", APIVersion: "carry.i/v1"},
+		ObjectMeta: v1.ObjectMeta{Name: "foo", Namespace: "bar", Labels: map[string]string{"a": "b"}},
+		Spec:       v1.PodSpec{TerminationGracePeriodSeconds: &grace},
+	}
+	var obj runtime.Object = pod
+	copied := obj.DeepCopyObject().(*v1.Pod)
+	if !reflect.DeepEqual(pod, copied) {
+		t.Fatalf("expected copy to equal original")
+	}
+	copied.Labels["a"] = "c"
+	*copied.Spec.TerminationGracePeriodSeconds = 10
+	if pod.Labels["a"] != "b" || *pod.Spec.TerminationGracePeriodSeconds != 30 {
+		t.Errorf("copy shares memory with the original")
+	}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk != v1.SchemeGroupVersion.WithKind("pod") {
+		t.Errorf("unexpected gvk %v", gvk)
+	}
+}
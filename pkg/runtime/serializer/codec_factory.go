@@ -0,0 +1,286 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serializer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+	"github.com/opencarry/carry/pkg/runtime/serializer/json"
+	"github.com/opencarry/carry/pkg/runtime/serializer/protobuf"
+	"github.com/opencarry/carry/pkg/runtime/serializer/versioning"
+)
+
+type serializerType struct {
+	AcceptContentTypes []string
+	ContentType        string
+	// EncodesAsText should be true if this content type can be represented safely in UTF-8
+	EncodesAsText bool
+
+	Serializer       runtime.Serializer
+	PrettySerializer runtime.Serializer
+	StrictSerializer runtime.Serializer
+
+	Framer           runtime.Framer
+	StreamSerializer runtime.Serializer
+}
+
+func newSerializersForScheme(scheme *runtime.Scheme, mf json.MetaFactory) []serializerType {
+	jsonSerializer := json.NewSerializerWithOptions(
+		mf, scheme, scheme,
+		json.SerializerOptions{Yaml: false, Pretty: false, Strict: false},
+	)
+	jsonSerializerType := serializerType{
+		AcceptContentTypes: []string{runtime.ContentTypeJSON},
+		ContentType:        runtime.ContentTypeJSON,
+		EncodesAsText:      true,
+		Serializer:         jsonSerializer,
+
+		Framer:           json.Framer,
+		StreamSerializer: jsonSerializer,
+	}
+	jsonSerializerType.PrettySerializer = json.NewSerializerWithOptions(
+		mf, scheme, scheme,
+		json.SerializerOptions{Yaml: false, Pretty: true, Strict: false},
+	)
+	jsonSerializerType.StrictSerializer = json.NewSerializerWithOptions(
+		mf, scheme, scheme,
+		json.SerializerOptions{Yaml: false, Pretty: false, Strict: true},
+	)
+
+	yamlSerializer := json.NewSerializerWithOptions(
+		mf, scheme, scheme,
+		json.SerializerOptions{Yaml: true, Pretty: false, Strict: false},
+	)
+	yamlStrictSerializer := json.NewSerializerWithOptions(
+		mf, scheme, scheme,
+		json.SerializerOptions{Yaml: true, Pretty: false, Strict: true},
+	)
+	protoSerializer := protobuf.NewSerializer(scheme, scheme)
+
+	serializers := []serializerType{
+		jsonSerializerType,
+		{
+			AcceptContentTypes: []string{runtime.ContentTypeYAML},
+			ContentType:        runtime.ContentTypeYAML,
+			EncodesAsText:      true,
+			Serializer:         yamlSerializer,
+			StrictSerializer:   yamlStrictSerializer,
+
+			Framer:           json.YAMLFramer,
+			StreamSerializer: yamlSerializer,
+		},
+		{
+			AcceptContentTypes: []string{runtime.ContentTypeProtobuf},
+			ContentType:        runtime.ContentTypeProtobuf,
+			Serializer:         protoSerializer,
+
+			Framer:           protobuf.LengthDelimitedFramer,
+			StreamSerializer: protoSerializer,
+		},
+	}
+	return serializers
+}
+
+// CodecFactory provides methods for retrieving codecs and serializers for specific
+// versions and content types.
+type CodecFactory struct {
+	scheme    *runtime.Scheme
+	universal runtime.Decoder
+	strict    runtime.Decoder
+	accepts   []runtime.SerializerInfo
+
+	legacySerializer runtime.Serializer
+}
+
+// NewCodecFactory provides methods for retrieving serializers for the supported wire formats
+// (JSON, YAML and protobuf) of the types registered in scheme.
+func NewCodecFactory(scheme *runtime.Scheme) CodecFactory {
+	serializers := newSerializersForScheme(scheme, json.DefaultMetaFactory)
+	return newCodecFactory(scheme, serializers)
+}
+
+// newCodecFactory is a helper for testing that allows a different metafactory to be specified.
+func newCodecFactory(scheme *runtime.Scheme, serializers []serializerType) CodecFactory {
+	decoders := make([]runtime.Decoder, 0, len(serializers))
+	strictDecoders := make([]runtime.Decoder, 0, len(serializers))
+	var accepts []runtime.SerializerInfo
+	var legacySerializer runtime.Serializer
+	alreadyAccepted := make(map[string]struct{})
+
+	for _, d := range serializers {
+		decoders = append(decoders, d.Serializer)
+		if d.ContentType == runtime.ContentTypeJSON {
+			legacySerializer = d.Serializer
+		}
+		if d.StrictSerializer != nil {
+			strictDecoders = append(strictDecoders, d.StrictSerializer)
+		} else {
+			strictDecoders = append(strictDecoders, d.Serializer)
+		}
+		for _, mediaType := range d.AcceptContentTypes {
+			if _, ok := alreadyAccepted[mediaType]; ok {
+				continue
+			}
+			alreadyAccepted[mediaType] = struct{}{}
+			info := runtime.SerializerInfo{
+				MediaType:        d.ContentType,
+				EncodesAsText:    d.EncodesAsText,
+				Serializer:       d.Serializer,
+				PrettySerializer: d.PrettySerializer,
+				StrictSerializer: d.StrictSerializer,
+			}
+
+			mediaType, mediaTypeSubType := splitMediaType(d.ContentType)
+			info.MediaTypeType = mediaType
+			info.MediaTypeSubType = mediaTypeSubType
+
+			if d.StreamSerializer != nil {
+				info.StreamSerializer = &runtime.StreamSerializerInfo{
+					Serializer:    d.StreamSerializer,
+					EncodesAsText: d.EncodesAsText,
+					Framer:        d.Framer,
+				}
+			}
+			accepts = append(accepts, info)
+		}
+	}
+
+	return CodecFactory{
+		scheme:    scheme,
+		universal: newRecognizerDecoder(decoders...),
+		strict:    newRecognizerDecoder(strictDecoders...),
+		accepts:   accepts,
+
+		legacySerializer: legacySerializer,
+	}
+}
+
+func splitMediaType(mediaType string) (string, string) {
+	parts := strings.SplitN(mediaType, "/", 2)
+	if len(parts) != 2 {
+		return mediaType, ""
+	}
+	return parts[0], parts[1]
+}
+
+// SupportedMediaTypes returns the RFC2046 media types that this factory has serializers for.
+func (f CodecFactory) SupportedMediaTypes() []runtime.SerializerInfo {
+	return f.accepts
+}
+
+// LegacyCodec encodes output to the given API version as JSON, and decodes and defaults any
+// supported wire format. The encoded objects always carry the kind they are registered as.
+func (f CodecFactory) LegacyCodec(version schema.GroupVersion) runtime.Codec {
+	return versioning.NewCodec(f.legacySerializer, f.universal, f.scheme, f.scheme, version)
+}
+
+// UniversalDecoder returns a runtime.Decoder capable of decoding all known API objects in all
+// known formats. Unlike UniversalDeserializer, the decoded objects are defaulted.
+func (f CodecFactory) UniversalDecoder() runtime.Decoder {
+	return versioning.NewCodec(nil, f.universal, f.scheme, f.scheme, schema.GroupVersion{})
+}
+
+// UniversalDeserializer can convert any stored data recognized by this factory into a Go object that satisfies
+// runtime.Object. It does not perform conversion. It does not perform defaulting.
+func (f CodecFactory) UniversalDeserializer() runtime.Decoder {
+	return f.universal
+}
+
+// UniversalStrictDeserializer is like UniversalDeserializer, but rejects data with
+// fields that are unknown to the target type.
+func (f CodecFactory) UniversalStrictDeserializer() runtime.Decoder {
+	return f.strict
+}
+
+// CodecForVersions creates a codec with the provided serializer. The encoder writes objects
+// with the kind registered for the given version, decoded objects are defaulted.
+func (f CodecFactory) CodecForVersions(encoder runtime.Encoder, decoder runtime.Decoder, encode schema.GroupVersion) runtime.Codec {
+	return versioning.NewCodec(encoder, decoder, f.scheme, f.scheme, encode)
+}
+
+// EncoderForVersion returns an encoder that ensures objects being written to the provided
+// serializer are in the provided group version.
+func (f CodecFactory) EncoderForVersion(encoder runtime.Encoder, gv schema.GroupVersion) runtime.Encoder {
+	return f.CodecForVersions(encoder, nil, gv)
+}
+
+// recognizerDecoder picks the first decoder that recognizes the data. Decoders that cannot
+// tell (like YAML) are tried last.
+type recognizerDecoder struct {
+	decoders []runtime.Decoder
+}
+
+// recognizingDecoder is implemented by decoders that can sniff their wire format.
+type recognizingDecoder interface {
+	runtime.Decoder
+	RecognizesData(peek []byte) (ok, unknown bool, err error)
+}
+
+func newRecognizerDecoder(decoders ...runtime.Decoder) runtime.Decoder {
+	return &recognizerDecoder{decoders: decoders}
+}
+
+func (d *recognizerDecoder) Decode(data []byte, gvk *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	var lastErr error
+	var skipped []runtime.Decoder
+
+	// try recognizers, record any decoders we need to give a chance later
+	for _, r := range d.decoders {
+		switch t := r.(type) {
+		case recognizingDecoder:
+			ok, unknown, err := t.RecognizesData(data)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if unknown {
+				skipped = append(skipped, t)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			return r.Decode(data, gvk, into)
+		default:
+			skipped = append(skipped, t)
+		}
+	}
+
+	// try recognizers that returned unknown or didn't recognize their data
+	for _, r := range skipped {
+		out, actual, err := r.Decode(data, gvk, into)
+		if err != nil {
+			// if we got an object back from the decoder, and the
+			// error was a strict decoding error (e.g. unknown or
+			// duplicate fields), we still consider the recognizer
+			// to have understood the object
+			if out == nil || !runtime.IsStrictDecodingError(err) {
+				lastErr = err
+				continue
+			}
+		}
+		return out, actual, err
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no serialization format matched the provided data")
+	}
+	return nil, nil, lastErr
+}
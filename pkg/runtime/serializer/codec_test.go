@@ -0,0 +1,278 @@
+package serializer_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/resource"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/serializer"
+	"github.com/opencarry/carry/pkg/runtime/serializer/streaming"
+)
+
+func newCodecs(t *testing.T) serializer.CodecFactory {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return serializer.NewCodecFactory(scheme)
+}
+
+func serializerFor(t *testing.T, codecs serializer.CodecFactory, mediaType string) runtime.SerializerInfo {
+	info, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), mediaType)
+	if !ok {
+		t.Fatalf("no serializer for %s", mediaType)
+	}
+	return info
+}
+
+func testPod() *v1.Pod {
+	grace := int64(30)
+	suspended := false
+	mode := int64(0644)
+	return &v1.Pod{
+		TypeMeta: v1.TypeMeta{Kind: "pod", APIVersion: "carry.i/v1"},
+		ObjectMeta: v1.ObjectMeta{
+			Name:         "foo",
+			Namespace:    "bar",
+			UID:          "6d3b1a9e-0000-4000-8000-000000000001",
+			Labels:       map[string]string{"app": "foo"},
+			Annotations:  map[string]string{"a": ""},
+			CreationTime: time.Unix(1600000000, 123),
+		},
+		Spec: v1.PodSpec{
+			NodeName:                      "node-1",
+			TerminationGracePeriodSeconds: &grace,
+			Suspended:                     &suspended,
+			Containers: []v1.Container{{
+				Name:    "main",
+				Image:   "mountain/foo:1.0",
+				Command: []string{"foo", "--bar"},
+				Ports:   []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{"cpu": resource.MustParse("500m"), "memory": resource.MustParse("1Gi")},
+				},
+			}},
+			Volumes: []v1.Volume{{
+				Name: "config",
+				VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: "foo-config"},
+					DefaultMode:          &mode,
+				}},
+			}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, HostIp: "10.0.0.1"},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	codecs := newCodecs(t)
+	for _, mediaType := range []string{runtime.ContentTypeJSON, runtime.ContentTypeYAML, runtime.ContentTypeProtobuf} {
+		info := serializerFor(t, codecs, mediaType)
+		pod := testPod()
+		data, err := runtime.Encode(info.Serializer, pod)
+		if err != nil {
+			t.Fatalf("%s: %v", mediaType, err)
+		}
+		obj, err := runtime.Decode(codecs.UniversalDeserializer(), data)
+		if err != nil {
+			t.Fatalf("%s: %v", mediaType, err)
+		}
+		if diff := cmp.Diff(pod, obj); diff != "" {
+			t.Errorf("%s: unexpected diff (-want +got):\n%s", mediaType, diff)
+		}
+	}
+}
+
+func TestLegacyCodecSetsKind(t *testing.T) {
+	codecs := newCodecs(t)
+	codec := codecs.LegacyCodec(v1.SchemeGroupVersion)
+	node := &v1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}}
+	data, err := runtime.Encode(codec, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"kind":"node","api_version":"carry.i/v1"`)) {
+		t.Errorf("expected kind and api_version in %s", data)
+	}
+	if !node.GroupVersionKind().Empty() {
+		t.Errorf("encoding should not modify the type meta of the object: %v", node.TypeMeta)
+	}
+	obj, err := runtime.Decode(codec, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, ok := obj.(*v1.Node); !ok || decoded.Name != "node-1" {
+		t.Errorf("unexpected object %#v", obj)
+	}
+}
+
+func TestDecodeYAMLUsesTypeMeta(t *testing.T) {
+	codecs := newCodecs(t)
+	data := []byte(`
+kind: deployment
+api_version: carry.i/v1
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  replicas: 3
+`)
+	obj, err := runtime.Decode(codecs.UniversalDeserializer(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment, ok := obj.(*v1.Deployment)
+	if !ok {
+		t.Fatalf("expected a deployment, got %T", obj)
+	}
+	if deployment.Name != "foo" || deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 3 {
+		t.Errorf("unexpected deployment %#v", deployment)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	codecs := newCodecs(t)
+	decoder := codecs.UniversalDeserializer()
+
+	_, err := runtime.Decode(decoder, []byte(`{"api_version":"carry.i/v1","metadata":{"name":"foo"}}`))
+	if !runtime.IsMissingKind(err) {
+		t.Errorf("expected missing kind error, got %v", err)
+	}
+	_, err = runtime.Decode(decoder, []byte(`{"kind":"pod","metadata":{"name":"foo"}}`))
+	if !runtime.IsMissingVersion(err) {
+		t.Errorf("expected missing version error, got %v", err)
+	}
+	_, err = runtime.Decode(decoder, []byte(`{"kind":"unknown","api_version":"carry.i/v1"}`))
+	if !runtime.IsNotRegisteredError(err) {
+		t.Errorf("expected not registered error, got %v", err)
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	codecs := newCodecs(t)
+	testCases := map[string][]byte{
+		runtime.ContentTypeJSON: []byte(`{"kind":"configmap","api_version":"carry.i/v1","metadata":{"name":"foo"},"dat":{"a":"b"}}`),
+		runtime.ContentTypeYAML: []byte("kind: configmap\napi_version: carry.i/v1\nmetadata:\n  name: foo\ndat:\n  a: b\n"),
+	}
+	for mediaType, data := range testCases {
+		info := serializerFor(t, codecs, mediaType)
+
+		obj, err := runtime.Decode(info.Serializer, data)
+		if err != nil {
+			t.Errorf("%s: lenient decoding failed: %v", mediaType, err)
+		}
+		obj, err = runtime.Decode(info.StrictSerializer, data)
+		if !runtime.IsStrictDecodingError(err) {
+			t.Errorf("%s: expected strict decoding error, got %v", mediaType, err)
+		}
+		if configMap, ok := obj.(*v1.ConfigMap); !ok || configMap.Name != "foo" {
+			t.Errorf("%s: expected the decoded object along with the strict error, got %#v", mediaType, obj)
+		}
+	}
+
+	duplicate := []byte("kind: configmap\napi_version: carry.i/v1\nmetadata:\n  name: foo\n  name: bar\n")
+	if _, err := runtime.Decode(serializerFor(t, codecs, runtime.ContentTypeYAML).StrictSerializer, duplicate); !runtime.IsStrictDecodingError(err) {
+		t.Errorf("expected strict decoding error for duplicate field, got %v", err)
+	}
+}
+
+func TestStreamingYAML(t *testing.T) {
+	codecs := newCodecs(t)
+	info := serializerFor(t, codecs, runtime.ContentTypeYAML)
+	stream := `kind: namespace
+api_version: carry.i/v1
+metadata:
+  name: ns-1
+---
+kind: configmap
+api_version: carry.i/v1
+metadata:
+  name: cm-1
+  namespace: ns-1
+data:
+  key: value
+---
+# trailing comment
+kind: service
+api_version: carry.i/v1
+metadata:
+  name: svc-1
+  namespace: ns-1
+`
+	reader := info.StreamSerializer.NewFrameReader(ioutil.NopCloser(strings.NewReader(stream)))
+	decoder := streaming.NewDecoder(reader, info.StreamSerializer)
+	defer decoder.Close()
+
+	var names []string
+	for {
+		obj, _, err := decoder.Decode(nil, nil)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		accessor, err := v1.Accessor(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, obj.GetObjectKind().GroupVersionKind().Kind+"/"+accessor.GetName())
+	}
+	expected := []string{"namespace/ns-1", "configmap/cm-1", "service/svc-1"}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreamingProtobuf(t *testing.T) {
+	codecs := newCodecs(t)
+	info := serializerFor(t, codecs, runtime.ContentTypeProtobuf)
+
+	buf := &bytes.Buffer{}
+	encoder := streaming.NewEncoder(info.StreamSerializer.NewFrameWriter(buf), info.StreamSerializer)
+	pods := []*v1.Pod{testPod(), testPod()}
+	pods[1].Name = "other"
+	for _, pod := range pods {
+		if err := encoder.Encode(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	decoder := streaming.NewDecoder(info.StreamSerializer.NewFrameReader(ioutil.NopCloser(buf)), info.StreamSerializer)
+	for _, pod := range pods {
+		obj, _, err := decoder.Decode(nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(pod, obj); diff != "" {
+			t.Errorf("unexpected diff (-want +got):\n%s", diff)
+		}
+	}
+	if _, _, err := decoder.Decode(nil, nil); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestProtobufIsCompact(t *testing.T) {
+	codecs := newCodecs(t)
+	pod := testPod()
+	jsonData, err := runtime.Encode(serializerFor(t, codecs, runtime.ContentTypeJSON).Serializer, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	protoData, err := runtime.Encode(serializerFor(t, codecs, runtime.ContentTypeProtobuf).Serializer, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protoData) >= len(jsonData) {
+		t.Errorf("expected protobuf (%d bytes) to be smaller than json (%d bytes)", len(protoData), len(jsonData))
+	}
+}
@@ -0,0 +1,4 @@
+// Package serializer provides the codecs for the carry.i API objects. JSON and YAML read the
+// `kind` and `api_version` fields to find the type to decode into, protobuf carries them in a
+// runtime.Unknown envelope.
+package serializer
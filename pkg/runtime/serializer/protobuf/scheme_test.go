@@ -0,0 +1,41 @@
+package protobuf_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opencarry/carry/pkg/api/scheme"
+	"github.com/opencarry/carry/pkg/runtime/serializer/protobuf"
+)
+
+// TestSchemeTypesTagged checks that every struct reachable from a registered
+// kind has explicit field numbers, so that encoding it does not fail.
+func TestSchemeTypesTagged(t *testing.T) {
+	seen := map[reflect.Type]bool{}
+	var visit func(rt reflect.Type)
+	visit = func(rt reflect.Type) {
+		for rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice || rt.Kind() == reflect.Map {
+			rt = rt.Elem()
+		}
+		if rt.Kind() != reflect.Struct || seen[rt] || rt == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		seen[rt] = true
+		// types with their own encoding, like resource.Quantity
+		if _, ok := reflect.New(rt).Interface().(interface{ Marshal() ([]byte, error) }); ok {
+			return
+		}
+		if _, err := protobuf.Marshal(reflect.New(rt).Interface()); err != nil {
+			t.Errorf("%s: %v", rt, err)
+		}
+		for i := 0; i < rt.NumField(); i++ {
+			if rt.Field(i).PkgPath == "" {
+				visit(rt.Field(i).Type)
+			}
+		}
+	}
+	for _, rt := range scheme.Scheme.AllKnownTypes() {
+		visit(rt)
+	}
+}
@@ -0,0 +1,487 @@
+package protobuf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// v1下的类型没有生成的.pb.go，这里通过反射按protobuf wire格式编解码：
+//   - 字段号取自`protobuf:"bytes,1,opt,name=..."`标签，导出字段必须有标签（不编码的字段用`protobuf:"-"`），
+//     避免字段的增删和调整顺序改变其它字段的字段号
+//   - 实现了Marshal/Unmarshal的类型（如resource.Quantity）使用其自身的编码
+//   - time.Time编码为{1: seconds, 2: nanos}
+//   - map编码为重复的{1: key, 2: value}条目
+//   - 指针字段只要非nil就会编码，以保留零值和nil的区别
+//   - 无法识别的字段号在解码时被忽略，便于字段的新增
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	errTruncated = errors.New("protobuf: unexpected end of data")
+)
+
+// marshaler is implemented by types with their own protobuf encoding, such as the
+// generated code of resource.Quantity.
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type unmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*unmarshaler)(nil)).Elem()
+)
+
+// Marshal encodes the struct pointed to by v.
+func Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("protobuf: expected a non-nil pointer to a struct, got %T", v)
+	}
+	return appendStruct(nil, rv.Elem())
+}
+
+// Unmarshal decodes data into the struct pointed to by v.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("protobuf: expected a non-nil pointer to a struct, got %T", v)
+	}
+	return decodeStruct(data, rv.Elem())
+}
+
+type fieldInfo struct {
+	index int
+	num   uint64
+	name  string
+}
+
+type structInfo struct {
+	fields []fieldInfo
+	byNum  map[uint64]fieldInfo
+}
+
+var structInfos sync.Map // map[reflect.Type]*structInfo
+
+func getStructInfo(t reflect.Type) (*structInfo, error) {
+	if info, ok := structInfos.Load(t); ok {
+		return info.(*structInfo), nil
+	}
+	info := &structInfo{byNum: map[uint64]fieldInfo{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("protobuf")
+		if tag == "" {
+			return nil, fmt.Errorf("protobuf: missing protobuf tag on %s.%s", t, f.Name)
+		}
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("protobuf: invalid tag %q on %s.%s", tag, t, f.Name)
+		}
+		num, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil || num == 0 {
+			return nil, fmt.Errorf("protobuf: invalid field number in tag %q on %s.%s", tag, t, f.Name)
+		}
+		if _, ok := info.byNum[num]; ok {
+			return nil, fmt.Errorf("protobuf: duplicate field number %d on %s", num, t)
+		}
+		fi := fieldInfo{index: i, num: num, name: f.Name}
+		info.fields = append(info.fields, fi)
+		info.byNum[num] = fi
+	}
+	actual, _ := structInfos.LoadOrStore(t, info)
+	return actual.(*structInfo), nil
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendTag(b []byte, num uint64, wire int) []byte {
+	return appendVarint(b, num<<3|uint64(wire))
+}
+
+func appendBytes(b []byte, num uint64, data []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = appendVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func appendStruct(b []byte, v reflect.Value) ([]byte, error) {
+	info, err := getStructInfo(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range info.fields {
+		if b, err = appendField(b, f.num, v.Field(f.index), false); err != nil {
+			return nil, fmt.Errorf("%s: %v", f.name, err)
+		}
+	}
+	return b, nil
+}
+
+// appendField appends the value v as field num. Zero values are omitted unless force is
+// set, which is the case for pointer targets, repeated elements and map entries.
+func appendField(b []byte, num uint64, v reflect.Value, force bool) ([]byte, error) {
+	t := v.Type()
+	if t == timeType {
+		tm := v.Interface().(time.Time)
+		if tm.IsZero() && !force {
+			return b, nil
+		}
+		var msg []byte
+		if s := tm.Unix(); s != 0 {
+			msg = appendTag(msg, 1, wireVarint)
+			msg = appendVarint(msg, uint64(s))
+		}
+		if n := tm.Nanosecond(); n != 0 {
+			msg = appendTag(msg, 2, wireVarint)
+			msg = appendVarint(msg, uint64(n))
+		}
+		return appendBytes(b, num, msg), nil
+	}
+	if reflect.PtrTo(t).Implements(marshalerType) && t.Kind() == reflect.Struct {
+		if v.IsZero() && !force {
+			return b, nil
+		}
+		ptr := reflect.New(t)
+		ptr.Elem().Set(v)
+		data, err := ptr.Interface().(marshaler).Marshal()
+		if err != nil {
+			return nil, err
+		}
+		return appendBytes(b, num, data), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if !v.Bool() && !force {
+			return b, nil
+		}
+		b = appendTag(b, num, wireVarint)
+		if v.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() == 0 && !force {
+			return b, nil
+		}
+		b = appendTag(b, num, wireVarint)
+		return appendVarint(b, uint64(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() == 0 && !force {
+			return b, nil
+		}
+		b = appendTag(b, num, wireVarint)
+		return appendVarint(b, v.Uint()), nil
+	case reflect.Float32:
+		if v.Float() == 0 && !force {
+			return b, nil
+		}
+		b = appendTag(b, num, wireFixed32)
+		var buf [4]byte
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(float32(v.Float())))
+		return append(b, buf[:]...), nil
+	case reflect.Float64:
+		if v.Float() == 0 && !force {
+			return b, nil
+		}
+		b = appendTag(b, num, wireFixed64)
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v.Float()))
+		return append(b, buf[:]...), nil
+	case reflect.String:
+		if v.Len() == 0 && !force {
+			return b, nil
+		}
+		return appendBytes(b, num, []byte(v.String())), nil
+	case reflect.Struct:
+		msg, err := appendStruct(nil, v)
+		if err != nil {
+			return nil, err
+		}
+		if len(msg) == 0 && !force {
+			return b, nil
+		}
+		return appendBytes(b, num, msg), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return b, nil
+		}
+		return appendField(b, num, v.Elem(), true)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if v.Len() == 0 && !force {
+				return b, nil
+			}
+			return appendBytes(b, num, v.Bytes()), nil
+		}
+		if force {
+			return nil, fmt.Errorf("unsupported nested repeated type %s", t)
+		}
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			if b, err = appendField(b, num, v.Index(i), true); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Map:
+		if force {
+			return nil, fmt.Errorf("unsupported nested map type %s", t)
+		}
+		var err error
+		iter := v.MapRange()
+		for iter.Next() {
+			var entry []byte
+			if entry, err = appendField(entry, 1, iter.Key(), true); err != nil {
+				return nil, err
+			}
+			if entry, err = appendField(entry, 2, iter.Value(), true); err != nil {
+				return nil, err
+			}
+			b = appendBytes(b, num, entry)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// wireValue is a single undecoded field read off the wire.
+type wireValue struct {
+	wire  int
+	value uint64
+	data  []byte
+}
+
+func readVarint(data []byte) (uint64, int, error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, 0, errTruncated
+	}
+	return v, n, nil
+}
+
+// readField reads the next field from data and returns its number, value and
+// the number of bytes consumed.
+func readField(data []byte) (uint64, wireValue, int, error) {
+	key, n, err := readVarint(data)
+	if err != nil {
+		return 0, wireValue{}, 0, err
+	}
+	num, wire := key>>3, int(key&7)
+	w := wireValue{wire: wire}
+	switch wire {
+	case wireVarint:
+		v, m, err := readVarint(data[n:])
+		if err != nil {
+			return 0, w, 0, err
+		}
+		w.value = v
+		n += m
+	case wireFixed64:
+		if len(data) < n+8 {
+			return 0, w, 0, errTruncated
+		}
+		w.value = binary.LittleEndian.Uint64(data[n:])
+		n += 8
+	case wireFixed32:
+		if len(data) < n+4 {
+			return 0, w, 0, errTruncated
+		}
+		w.value = uint64(binary.LittleEndian.Uint32(data[n:]))
+		n += 4
+	case wireBytes:
+		l, m, err := readVarint(data[n:])
+		if err != nil {
+			return 0, w, 0, err
+		}
+		n += m
+		if uint64(len(data)-n) < l {
+			return 0, w, 0, errTruncated
+		}
+		w.data = data[n : n+int(l)]
+		n += int(l)
+	default:
+		return 0, w, 0, fmt.Errorf("protobuf: unsupported wire type %d", wire)
+	}
+	return num, w, n, nil
+}
+
+func decodeStruct(data []byte, v reflect.Value) error {
+	info, err := getStructInfo(v.Type())
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		num, w, n, err := readField(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		f, ok := info.byNum[num]
+		if !ok {
+			continue
+		}
+		if err := decodeField(w, v.Field(f.index)); err != nil {
+			return fmt.Errorf("%s: %v", f.name, err)
+		}
+	}
+	return nil
+}
+
+func expectWire(w wireValue, wire int) error {
+	if w.wire != wire {
+		return fmt.Errorf("protobuf: unexpected wire type %d, expected %d", w.wire, wire)
+	}
+	return nil
+}
+
+func decodeField(w wireValue, v reflect.Value) error {
+	t := v.Type()
+	if t == timeType {
+		if err := expectWire(w, wireBytes); err != nil {
+			return err
+		}
+		var seconds, nanos uint64
+		data := w.data
+		for len(data) > 0 {
+			num, fw, n, err := readField(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+			switch num {
+			case 1:
+				seconds = fw.value
+			case 2:
+				nanos = fw.value
+			}
+		}
+		v.Set(reflect.ValueOf(time.Unix(int64(seconds), int64(nanos))))
+		return nil
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) && t.Kind() == reflect.Struct {
+		if err := expectWire(w, wireBytes); err != nil {
+			return err
+		}
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(unmarshaler).Unmarshal(w.data); err != nil {
+			return err
+		}
+		v.Set(ptr.Elem())
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if err := expectWire(w, wireVarint); err != nil {
+			return err
+		}
+		v.SetBool(w.value != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if err := expectWire(w, wireVarint); err != nil {
+			return err
+		}
+		v.SetInt(int64(w.value))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if err := expectWire(w, wireVarint); err != nil {
+			return err
+		}
+		v.SetUint(w.value)
+	case reflect.Float32:
+		if err := expectWire(w, wireFixed32); err != nil {
+			return err
+		}
+		v.SetFloat(float64(math.Float32frombits(uint32(w.value))))
+	case reflect.Float64:
+		if err := expectWire(w, wireFixed64); err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(w.value))
+	case reflect.String:
+		if err := expectWire(w, wireBytes); err != nil {
+			return err
+		}
+		v.SetString(string(w.data))
+	case reflect.Struct:
+		if err := expectWire(w, wireBytes); err != nil {
+			return err
+		}
+		return decodeStruct(w.data, v)
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return decodeField(w, v.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if err := expectWire(w, wireBytes); err != nil {
+				return err
+			}
+			v.SetBytes(append([]byte{}, w.data...))
+			return nil
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if err := decodeField(w, elem); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, elem))
+	case reflect.Map:
+		if err := expectWire(w, wireBytes); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(t))
+		}
+		key := reflect.New(t.Key()).Elem()
+		value := reflect.New(t.Elem()).Elem()
+		data := w.data
+		for len(data) > 0 {
+			num, fw, n, err := readField(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+			switch num {
+			case 1:
+				err = decodeField(fw, key)
+			case 2:
+				err = decodeField(fw, value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		v.SetMapIndex(key, value)
+	default:
+		return fmt.Errorf("unsupported type %s", t)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package protobuf
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opencarry/carry/pkg/resource"
+)
+
+type inner struct {
+	Name  string   `protobuf:"bytes,1,opt,name=name"`
+	Items []string `protobuf:"bytes,2,rep,name=items"`
+}
+
+type wireTest struct {
+	Int      int64             `protobuf:"varint,1,opt,name=int"`
+	Negative int32             `protobuf:"varint,2,opt,name=negative"`
+	Uint     uint32            `protobuf:"varint,3,opt,name=uint"`
+	Bool     bool              `protobuf:"varint,4,opt,name=bool"`
+	Float    float64           `protobuf:"fixed64,5,opt,name=float"`
+	String   string            `protobuf:"bytes,6,opt,name=string"`
+	Bytes    []byte            `protobuf:"bytes,7,opt,name=bytes"`
+	Zero     *int64            `protobuf:"varint,8,opt,name=zero"`
+	Nil      *int64            `protobuf:"varint,9,opt,name=nil"`
+	Ptr      *inner            `protobuf:"bytes,10,opt,name=ptr"`
+	Inner    inner             `protobuf:"bytes,11,opt,name=inner"`
+	Slice    []inner           `protobuf:"bytes,12,rep,name=slice"`
+	Map      map[string]string `protobuf:"bytes,13,rep,name=map"`
+	Binary   map[string][]byte `protobuf:"bytes,14,rep,name=binary"`
+	Quantity resource.Quantity `protobuf:"bytes,15,opt,name=quantity"`
+	Time     time.Time         `protobuf:"bytes,16,opt,name=time"`
+	Tagged   string            `protobuf:"bytes,100,opt,name=tagged"`
+	Skipped  string            `protobuf:"-"`
+}
+
+type wireTestV2 struct {
+	Int    int64  `protobuf:"varint,1,opt,name=int"`
+	Tagged string `protobuf:"bytes,100,opt,name=tagged"`
+}
+
+func TestWireRoundTrip(t *testing.T) {
+	zero := int64(0)
+	in := &wireTest{
+		Int:      1 << 40,
+		Negative: -5,
+		Uint:     7,
+		Bool:     true,
+		Float:    0.25,
+		String:   "foo",
+		Bytes:    []byte{0, 1, 2},
+		Zero:     &zero,
+		Ptr:      &inner{},
+		Inner:    inner{Name: "a", Items: []string{"x", ""}},
+		Slice:    []inner{{Name: "b"}, {}},
+		Map:      map[string]string{"k": "v", "empty": ""},
+		Binary:   map[string][]byte{"bin": {0xff}},
+		Quantity: resource.MustParse("1500m"),
+		Time:     time.Unix(1600000000, 42),
+		Tagged:   "tagged",
+		Skipped:  "skipped",
+	}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &wireTest{}
+	if err := Unmarshal(data, out); err != nil {
+		t.Fatal(err)
+	}
+	expected := *in
+	expected.Skipped = ""
+	if diff := cmp.Diff(&expected, out); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+	if out.Zero == nil || out.Nil != nil {
+		t.Errorf("expected zero pointer to be kept and nil pointer to stay nil")
+	}
+
+	// fields unknown to the target are skipped
+	v2 := &wireTestV2{}
+	if err := Unmarshal(data, v2); err != nil {
+		t.Fatal(err)
+	}
+	if v2.Int != in.Int || v2.Tagged != in.Tagged {
+		t.Errorf("unexpected result %#v", v2)
+	}
+}
+
+func TestWireErrors(t *testing.T) {
+	if _, err := Marshal(wireTest{}); err == nil {
+		t.Errorf("expected error for non pointer")
+	}
+	data, err := Marshal(&wireTest{String: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Unmarshal(data[:len(data)-1], &wireTest{}); err == nil {
+		t.Errorf("expected error for truncated data")
+	}
+
+	// the field numbers must not depend on the order of the fields
+	untagged := &struct {
+		Name string
+	}{Name: "foo"}
+	if _, err := Marshal(untagged); err == nil || !strings.Contains(err.Error(), "missing protobuf tag") {
+		t.Errorf("expected error for a field without a tag, got %v", err)
+	}
+	if err := Unmarshal(data, untagged); err == nil {
+		t.Errorf("expected error decoding into a field without a tag")
+	}
+}
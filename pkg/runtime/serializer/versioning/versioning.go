@@ -0,0 +1,96 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versioning
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+// NewCodec takes objects in their internal versions and converts them to external versions before
+// serializing them. It assumes the serializer provided to it only deals with external versions.
+// Since carry only has external versions, the codec only takes care of the kind information:
+// objects are encoded with the group, version and kind the scheme registered them under, even
+// if the caller left TypeMeta empty. Decoded objects are defaulted if defaulter is not nil.
+func NewCodec(
+	encoder runtime.Encoder,
+	decoder runtime.Decoder,
+	typer runtime.ObjectTyper,
+	defaulter runtime.ObjectDefaulter,
+	encodeVersion schema.GroupVersion,
+) runtime.Codec {
+	return &codec{
+		encoder:       encoder,
+		decoder:       decoder,
+		typer:         typer,
+		defaulter:     defaulter,
+		encodeVersion: encodeVersion,
+	}
+}
+
+type codec struct {
+	encoder   runtime.Encoder
+	decoder   runtime.Decoder
+	typer     runtime.ObjectTyper
+	defaulter runtime.ObjectDefaulter
+
+	encodeVersion schema.GroupVersion
+}
+
+// Decode attempts a decode of the object, then defaults it.
+func (c *codec) Decode(data []byte, defaultGVK *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	obj, gvk, err := c.decoder.Decode(data, defaultGVK, into)
+	if err != nil {
+		// strict decoding errors still return the decoded object
+		if obj == nil || !runtime.IsStrictDecodingError(err) {
+			return nil, gvk, err
+		}
+	}
+	if c.defaulter != nil {
+		c.defaulter.Default(obj)
+	}
+	return obj, gvk, err
+}
+
+// Encode ensures the provided object carries the kind it is registered as in the target
+// version, and then invokes the encoder.
+func (c *codec) Encode(obj runtime.Object, w io.Writer) error {
+	kinds, _, err := c.typer.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	target := schema.GroupVersionKind{}
+	for _, kind := range kinds {
+		if kind.GroupVersion() == c.encodeVersion {
+			target = kind
+			break
+		}
+	}
+	if target.Empty() {
+		return fmt.Errorf("%T is not registered in %s", obj, c.encodeVersion)
+	}
+
+	objectKind := obj.GetObjectKind()
+	old := objectKind.GroupVersionKind()
+	// restore the old GVK after encoding
+	defer objectKind.SetGroupVersionKind(old)
+	objectKind.SetGroupVersionKind(target)
+	return c.encoder.Encode(obj, w)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidContinueToken is returned for continue tokens that are
+	// malformed, were tampered with or belong to another list.
+	ErrInvalidContinueToken = errors.New("continue key is not valid")
+	// ErrInvalidStartRV is returned for continue tokens without a valid
+	// resource version.
+	ErrInvalidStartRV = errors.New("continue key is not valid: incorrect encoded start resourceVersion (version carry.i/v1)")
+	// ErrEmptyStartKey is returned for continue tokens without a start key.
+	ErrEmptyStartKey = errors.New("continue key is not valid: encoded start key empty (version carry.i/v1)")
+)
+
+// continueToken is a simple structured object for encoding the state of a
+// continue token.
+type continueToken struct {
+	APIVersion      string `json:"v"`
+	ResourceVersion int64  `json:"rv"`
+	StartKey        string `json:"start"`
+}
+
+const continueTokenVersion = "carry.i/v1"
+
+// EncodeContinue returns a string representing the encoded continuation of
+// the list under keyPrefix at resourceVersion, starting at key. The token is
+// signed with secret, so a client can neither forge nor alter it and it is
+// only accepted for the same key prefix.
+func EncodeContinue(key, keyPrefix string, resourceVersion int64, secret []byte) (string, error) {
+	nextKey := strings.TrimPrefix(key, keyPrefix)
+	if nextKey == key {
+		return "", ErrInvalidContinueToken
+	}
+	out, err := json.Marshal(&continueToken{APIVersion: continueTokenVersion, ResourceVersion: resourceVersion, StartKey: nextKey})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(out) + "." +
+		base64.RawURLEncoding.EncodeToString(continueSignature(out, keyPrefix, secret)), nil
+}
+
+// DecodeContinue transforms an encoded continue token created by
+// EncodeContinue into the key to start listing from and the resource version
+// of the list.
+func DecodeContinue(continueValue, keyPrefix string, secret []byte) (fromKey string, rv int64, err error) {
+	parts := strings.Split(continueValue, ".")
+	if len(parts) != 2 {
+		return "", 0, ErrInvalidContinueToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", 0, ErrInvalidContinueToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", 0, ErrInvalidContinueToken
+	}
+	if !hmac.Equal(signature, continueSignature(data, keyPrefix, secret)) {
+		return "", 0, ErrInvalidContinueToken
+	}
+
+	var c continueToken
+	if err := json.Unmarshal(data, &c); err != nil {
+		return "", 0, ErrInvalidContinueToken
+	}
+	if c.APIVersion != continueTokenVersion {
+		return "", 0, ErrInvalidContinueToken
+	}
+	if c.ResourceVersion <= 0 {
+		return "", 0, ErrInvalidStartRV
+	}
+	if len(c.StartKey) == 0 {
+		return "", 0, ErrEmptyStartKey
+	}
+	return keyPrefix + c.StartKey, c.ResourceVersion, nil
+}
+
+func continueSignature(data []byte, keyPrefix string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(keyPrefix))
+	mac.Write([]byte{0})
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeContinue(t *testing.T) {
+	secret := []byte("secret")
+	token, err := EncodeContinue("/pod/default/foo\x00", "/pod/", 12, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromKey, rv, err := DecodeContinue(token, "/pod/", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromKey != "/pod/default/foo\x00" || rv != 12 {
+		t.Errorf("unexpected decoded token: %q %d", fromKey, rv)
+	}
+
+	if _, err := EncodeContinue("/node/foo", "/pod/", 12, secret); err != ErrInvalidContinueToken {
+		t.Errorf("expected key outside of the prefix to be rejected, got %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	forged, _ := EncodeContinue("/pod/zzz", "/pod/", 12, secret)
+	tests := map[string]struct {
+		token  string
+		prefix string
+		secret []byte
+	}{
+		"empty":          {token: "", prefix: "/pod/", secret: secret},
+		"not signed":     {token: parts[0], prefix: "/pod/", secret: secret},
+		"garbage":        {token: "a.b.c", prefix: "/pod/", secret: secret},
+		"altered":        {token: strings.Split(forged, ".")[0] + "." + parts[1], prefix: "/pod/", secret: secret},
+		"other prefix":   {token: token, prefix: "/pod/default/", secret: secret},
+		"other secret":   {token: token, prefix: "/pod/", secret: []byte("other")},
+		"bad base64 mac": {token: parts[0] + ".!!", prefix: "/pod/", secret: secret},
+	}
+	for name, tc := range tests {
+		if _, _, err := DecodeContinue(tc.token, tc.prefix, tc.secret); err != ErrInvalidContinueToken {
+			t.Errorf("%s: expected invalid continue token, got %v", name, err)
+		}
+	}
+}
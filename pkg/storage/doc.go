@@ -0,0 +1,4 @@
+// Package storage defines the interface to the object store of the api server
+// together with the errors and selection predicates shared by its
+// implementations. See package memory for an in-memory implementation.
+package storage
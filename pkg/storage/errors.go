@@ -0,0 +1,196 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+)
+
+const (
+	ErrCodeKeyNotFound int = iota + 1
+	ErrCodeKeyExists
+	ErrCodeResourceVersionConflicts
+	ErrCodeInvalidObj
+	ErrCodeResourceVersionTooOld
+	ErrCodeResourceVersionTooLarge
+	ErrCodeResourceExpired
+)
+
+var errCodeToMessage = map[int]string{
+	ErrCodeKeyNotFound:              "key not found",
+	ErrCodeKeyExists:                "key exists",
+	ErrCodeResourceVersionConflicts: "resource version conflicts",
+	ErrCodeInvalidObj:               "invalid object",
+	ErrCodeResourceVersionTooOld:    "resource version too old",
+	ErrCodeResourceVersionTooLarge:  "resource version too large",
+	ErrCodeResourceExpired:          "resource expired",
+}
+
+func NewKeyNotFoundError(key string, rv int64) *StorageError {
+	return &StorageError{
+		Code:            ErrCodeKeyNotFound,
+		Key:             key,
+		ResourceVersion: rv,
+	}
+}
+
+func NewKeyExistsError(key string, rv int64) *StorageError {
+	return &StorageError{
+		Code:            ErrCodeKeyExists,
+		Key:             key,
+		ResourceVersion: rv,
+	}
+}
+
+func NewResourceVersionConflictsError(key string, rv int64) *StorageError {
+	return &StorageError{
+		Code:            ErrCodeResourceVersionConflicts,
+		Key:             key,
+		ResourceVersion: rv,
+	}
+}
+
+func NewInvalidObjError(key, msg string) *StorageError {
+	return &StorageError{
+		Code:               ErrCodeInvalidObj,
+		Key:                key,
+		AdditionalErrorMsg: msg,
+	}
+}
+
+// NewResourceVersionTooOldError is returned when a watch is started from a
+// revision whose events have already been compacted. oldest is the oldest
+// revision a watch can still be started from.
+func NewResourceVersionTooOldError(key string, rv, oldest int64) *StorageError {
+	return &StorageError{
+		Code:               ErrCodeResourceVersionTooOld,
+		Key:                key,
+		ResourceVersion:    rv,
+		AdditionalErrorMsg: fmt.Sprintf("oldest available resource version is %d", oldest),
+	}
+}
+
+// NewResourceVersionTooLargeError is returned when a watch is started from a
+// revision the storage has not reached yet.
+func NewResourceVersionTooLargeError(key string, rv, current int64) *StorageError {
+	return &StorageError{
+		Code:               ErrCodeResourceVersionTooLarge,
+		Key:                key,
+		ResourceVersion:    rv,
+		AdditionalErrorMsg: fmt.Sprintf("current resource version is %d", current),
+	}
+}
+
+// NewResourceExpiredError is returned when a list is continued at a revision
+// that can no longer be served consistently.
+func NewResourceExpiredError(key string, rv int64, msg string) *StorageError {
+	return &StorageError{
+		Code:               ErrCodeResourceExpired,
+		Key:                key,
+		ResourceVersion:    rv,
+		AdditionalErrorMsg: msg,
+	}
+}
+
+// StorageError is the error returned by a storage.Interface. Code tells the
+// reason of the failure, Key the object it happened on.
+type StorageError struct {
+	Code               int
+	Key                string
+	ResourceVersion    int64
+	AdditionalErrorMsg string
+}
+
+func (e *StorageError) Error() string {
+	return fmt.Sprintf("StorageError: %s, Code: %d, Key: %s, ResourceVersion: %d, AdditionalErrorMsg: %s",
+		errCodeToMessage[e.Code], e.Code, e.Key, e.ResourceVersion, e.AdditionalErrorMsg)
+}
+
+// IsNotFound returns true if and only if err is "key" not found error.
+func IsNotFound(err error) bool {
+	return isErrCode(err, ErrCodeKeyNotFound)
+}
+
+// IsExist returns true if and only if err is "key" already exists error.
+func IsExist(err error) bool {
+	return isErrCode(err, ErrCodeKeyExists)
+}
+
+// IsConflict returns true if and only if err is a write conflict.
+func IsConflict(err error) bool {
+	return isErrCode(err, ErrCodeResourceVersionConflicts)
+}
+
+// IsInvalidObj returns true if and only if err is invalid error
+func IsInvalidObj(err error) bool {
+	return isErrCode(err, ErrCodeInvalidObj)
+}
+
+// IsResourceVersionTooOld returns true if and only if err is a watch started
+// from a compacted resource version.
+func IsResourceVersionTooOld(err error) bool {
+	return isErrCode(err, ErrCodeResourceVersionTooOld)
+}
+
+// IsResourceVersionTooLarge returns true if and only if err is a watch started
+// from a resource version the storage has not reached yet.
+func IsResourceVersionTooLarge(err error) bool {
+	return isErrCode(err, ErrCodeResourceVersionTooLarge)
+}
+
+// IsResourceExpired returns true if and only if err is a list continued at a
+// revision that is no longer available.
+func IsResourceExpired(err error) bool {
+	return isErrCode(err, ErrCodeResourceExpired)
+}
+
+func isErrCode(err error, code int) bool {
+	if err == nil {
+		return false
+	}
+	if e, ok := err.(*StorageError); ok {
+		return e.Code == code
+	}
+	return false
+}
+
+// NewInternalError returns a new internal error for a storage operation that
+// failed for a reason other than the object itself.
+func NewInternalError(reason string) InternalError {
+	return InternalError{reason}
+}
+
+// NewInternalErrorf is like NewInternalError but takes a format string.
+func NewInternalErrorf(format string, a ...interface{}) InternalError {
+	return InternalError{fmt.Sprintf(format, a...)}
+}
+
+// InternalError is generated when an error occurs in the storage package, i.e.,
+// not from the underlying storage backend (e.g., etcd).
+type InternalError struct {
+	Reason string
+}
+
+func (e InternalError) Error() string {
+	return e.Reason
+}
+
+// IsInternalError returns true if and only if err is an InternalError.
+func IsInternalError(err error) bool {
+	_, ok := err.(InternalError)
+	return ok
+}
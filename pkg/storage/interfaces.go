@@ -0,0 +1,172 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+// UpdateFunc takes the current state of the object and returns the desired
+// new state. The returned object may be the input modified in place. If the
+// returned object carries a resource version it must match the current one,
+// otherwise the update is rejected with a conflict error.
+type UpdateFunc func(input runtime.Object) (output runtime.Object, err error)
+
+// Preconditions must be fulfilled before an operation (update, delete, etc.) is carried out.
+type Preconditions struct {
+	// Specifies the target UID.
+	UID *v1.UID `json:"uid,omitempty"`
+	// Specifies the target ResourceVersion
+	ResourceVersion *string `json:"resource_version,omitempty"`
+}
+
+// NewUIDPreconditions returns a Preconditions with UID set.
+func NewUIDPreconditions(uid string) *Preconditions {
+	u := v1.UID(uid)
+	return &Preconditions{UID: &u}
+}
+
+// Check returns an error if obj does not satisfy the preconditions.
+func (p *Preconditions) Check(key string, obj runtime.Object) error {
+	if p == nil {
+		return nil
+	}
+	objMeta, err := v1.Accessor(obj)
+	if err != nil {
+		return NewInternalErrorf(
+			"can't enforce preconditions %v on un-introspectable object %v, got error: %v",
+			*p,
+			obj,
+			err)
+	}
+	if p.UID != nil && *p.UID != objMeta.GetUID() {
+		err := fmt.Sprintf(
+			"Precondition failed: UID in precondition: %v, UID in object meta: %v",
+			*p.UID,
+			objMeta.GetUID())
+		return NewInvalidObjError(key, err)
+	}
+	if p.ResourceVersion != nil && *p.ResourceVersion != objMeta.GetResourceVersion() {
+		err := fmt.Sprintf(
+			"Precondition failed: ResourceVersion in precondition: %v, ResourceVersion in object meta: %v",
+			*p.ResourceVersion,
+			objMeta.GetResourceVersion())
+		return NewInvalidObjError(key, err)
+	}
+	return nil
+}
+
+// GetOptions provides the options that may be provided for storage get operations.
+type GetOptions struct {
+	// IgnoreNotFound determines what is returned if the requested object is not found. If
+	// true, a zero object is returned. If false, an error is returned.
+	IgnoreNotFound bool
+}
+
+// ListOptions provides the options that may be provided for storage list and watch operations.
+type ListOptions struct {
+	// ResourceVersion is the revision a watch starts after: every change made
+	// after it is sent down the watch. Empty or "0" means "start at the most
+	// recent revision".
+	ResourceVersion string
+	// Predicate provides the selection rules for the list operation.
+	Predicate SelectionPredicate
+	// Limit is the maximum number of items a list returns. When more items
+	// are available the list carries a continue token to fetch the next chunk
+	// with. Zero means no limit. Ignored by watch.
+	Limit int64
+	// Continue is the token returned by a previous chunked list. The next
+	// chunk is served at the resource version of the first one, so the chunks
+	// form a consistent list. Ignored by watch.
+	Continue string
+	// AllowWatchBookmarks requests watch events with type "bookmark", which
+	// carry only the resource version the watch has reached.
+	AllowWatchBookmarks bool
+}
+
+// Interface offers a common interface for object marshaling/unmarshaling operations and
+// hides all the storage-related operations behind it.
+//
+// Objects are stored under keys built by Key and KeyPrefix, that is
+// /<kind>/<namespace>/<name> for namespaced kinds and /<kind>/<name> for
+// cluster scoped ones.
+type Interface interface {
+	// Create adds a new object at a key unless it already exists. The object
+	// must not carry a resource version. The storage sets the uid, creation
+	// time, generation and resource version of the object. If no error is
+	// returned and out is not nil, out will be set to the read value from
+	// storage.
+	Create(ctx context.Context, key string, obj, out runtime.Object) error
+
+	// Delete removes the specified key and returns the value that existed at that spot.
+	// If key didn't exist, it will return NotFound storage error.
+	// If 'preconditions' is non-nil, the object is only deleted if it fulfills them.
+	Delete(ctx context.Context, key string, out runtime.Object, preconditions *Preconditions) error
+
+	// Watch begins watching the specified key or key prefix. Events are
+	// decoded into API objects, and any items selected by the predicate are
+	// sent down to returned watch.Interface.
+	// If the changes after opts.ResourceVersion are no longer available a
+	// ResourceVersionTooOld storage error is returned, and the caller has to
+	// list again.
+	Watch(ctx context.Context, key string, opts ListOptions) (watch.Interface, error)
+
+	// Get unmarshals the object found at key into objPtr. On a not found error,
+	// will either return a zero object of the requested type, or an error,
+	// depending on 'opts.IgnoreNotFound'.
+	Get(ctx context.Context, key string, opts GetOptions, objPtr runtime.Object) error
+
+	// List unmarshalls the objects found under the key prefix into a *List
+	// api object (an object that satisfies runtime.IsList definition). The
+	// list resource version is set to the current revision of the storage, or
+	// to the revision of the first chunk when opts.Continue is set.
+	// If opts.Limit is set and more items are left, the list continue token
+	// and remaining item count are set. A continue token that is no longer
+	// served results in a ResourceExpired storage error, and the caller has
+	// to start a new list.
+	List(ctx context.Context, key string, opts ListOptions, listObj runtime.Object) error
+
+	// GuaranteedUpdate keeps calling 'tryUpdate()' to update key 'key' (of type 'destination')
+	// retrying the update until success if there is index conflict.
+	// Note that object passed to tryUpdate may change across invocations of tryUpdate() if
+	// other writers are simultaneously updating it, so tryUpdate() needs to take into account
+	// the current contents of the object when deciding how the update object should look.
+	// If the key doesn't exist, it will return NotFound storage error if ignoreNotFound=false
+	// or zero value in 'destination' otherwise.
+	// If the object returned by tryUpdate carries a resource version that differs from the
+	// stored one, a ResourceVersionConflicts storage error is returned.
+	//
+	// Example:
+	//
+	// s := /* implementation of Interface */
+	// err := s.GuaranteedUpdate(
+	//     "/pod/default/foo", &v1.Pod{}, true, nil,
+	//     func(input runtime.Object) (runtime.Object, error) {
+	//       pod := input.(*v1.Pod).DeepCopy()
+	//       pod.Status.Phase = v1.PodRunning
+	//       return pod, nil
+	//     },
+	// )
+	GuaranteedUpdate(
+		ctx context.Context, key string, destination runtime.Object, ignoreNotFound bool,
+		preconditions *Preconditions, tryUpdate UpdateFunc) error
+}
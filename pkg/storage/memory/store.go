@@ -0,0 +1,424 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+const (
+	// DefaultHistorySize is the default number of changes kept per kind.
+	DefaultHistorySize = 1000
+	// DefaultWatchQueueLength is the default number of events buffered per watcher.
+	DefaultWatchQueueLength = 1000
+	// DefaultBookmarkInterval is the default interval between two bookmark events.
+	DefaultBookmarkInterval = time.Minute
+)
+
+// Config configures a Store. Zero values select the defaults.
+type Config struct {
+	// HistorySize is the number of most recent changes kept per kind, a watch
+	// can be resumed from any revision covered by them.
+	HistorySize int
+	// WatchQueueLength is the number of events buffered for a single watcher.
+	// A watcher that falls further behind is closed.
+	WatchQueueLength int
+	// BookmarkInterval is the interval at which watchers asking for bookmarks
+	// are sent the current revision.
+	BookmarkInterval time.Duration
+}
+
+// Store is an in-memory storage.Interface. Every write increments a single
+// store wide revision which becomes the resource version of the written
+// object, the same way etcd ModRevision is used.
+//
+// Objects are deep copied on the way in and on the way out, callers never
+// share memory with the store.
+type Store struct {
+	config Config
+
+	lock sync.RWMutex
+	// revision is the revision of the last write.
+	revision int64
+	objects  map[string]runtime.Object
+
+	// history holds the recent changes per kind to resume watches from.
+	history map[string]*eventHistory
+	// types holds the type of the objects of every kind written so far.
+	types       map[string]reflect.Type
+	broadcaster *watch.Broadcaster
+
+	bookmarkWatchers int
+	bookmarkStop     chan struct{}
+
+	// continueSecret signs the continue tokens handed out by List.
+	continueSecret []byte
+
+	// now returns the current time, overridable in tests.
+	now func() time.Time
+}
+
+var _ storage.Interface = &Store{}
+
+// New returns an empty in-memory store with the default configuration.
+func New() *Store {
+	return NewWithConfig(Config{})
+}
+
+// NewWithConfig returns an empty in-memory store.
+func NewWithConfig(config Config) *Store {
+	if config.HistorySize <= 0 {
+		config.HistorySize = DefaultHistorySize
+	}
+	if config.WatchQueueLength <= 0 {
+		config.WatchQueueLength = DefaultWatchQueueLength
+	}
+	if config.BookmarkInterval <= 0 {
+		config.BookmarkInterval = DefaultBookmarkInterval
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("failed to generate continue token secret: %v", err))
+	}
+	return &Store{
+		config:         config,
+		continueSecret: secret,
+		objects:        map[string]runtime.Object{},
+		history:        map[string]*eventHistory{},
+		types:          map[string]reflect.Type{},
+		broadcaster:    watch.NewBroadcaster(config.WatchQueueLength),
+		now:            time.Now,
+	}
+}
+
+// Revision returns the revision of the last write to the store.
+func (s *Store) Revision() int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.revision
+}
+
+// Create implements storage.Interface.Create.
+func (s *Store) Create(ctx context.Context, key string, obj, out runtime.Object) error {
+	metadata, err := v1.Accessor(obj)
+	if err != nil {
+		return storage.NewInternalErrorf("can't get meta of object %#v: %v", obj, err)
+	}
+	if len(metadata.GetResourceVersion()) != 0 {
+		return storage.NewInvalidObjError(key, "resource_version should not be set on objects to be created")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.objects[key]; ok {
+		return storage.NewKeyExistsError(key, 0)
+	}
+	stored := obj.DeepCopyObject()
+	s.initObject(stored)
+	s.commit(key, stored)
+	s.notify(watch.Added, key, stored, nil)
+	return copyInto(stored, out)
+}
+
+// Get implements storage.Interface.Get.
+func (s *Store) Get(ctx context.Context, key string, opts storage.GetOptions, out runtime.Object) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		if opts.IgnoreNotFound {
+			return setZero(out)
+		}
+		return storage.NewKeyNotFoundError(key, 0)
+	}
+	return copyInto(obj, out)
+}
+
+// Delete implements storage.Interface.Delete.
+func (s *Store) Delete(ctx context.Context, key string, out runtime.Object, preconditions *storage.Preconditions) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, ok := s.objects[key]
+	if !ok {
+		return storage.NewKeyNotFoundError(key, 0)
+	}
+	if err := preconditions.Check(key, existing); err != nil {
+		return err
+	}
+
+	delete(s.objects, key)
+	s.revision++
+	// watchers see the deletion at the revision it happened
+	deleted := existing.DeepCopyObject()
+	setResourceVersion(deleted, s.revision)
+	s.notify(watch.Deleted, key, deleted, existing)
+	return copyInto(existing, out)
+}
+
+// List implements storage.Interface.List.
+func (s *Store) List(ctx context.Context, key string, opts storage.ListOptions, listObj runtime.Object) error {
+	listMeta, err := v1.ListAccessor(listObj)
+	if err != nil {
+		return err
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	fromKey, revision := key, s.revision
+	if len(opts.Continue) != 0 {
+		fromKey, revision, err = storage.DecodeContinue(opts.Continue, key, s.continueSecret)
+		if err != nil {
+			return storage.NewInvalidObjError(key, fmt.Sprintf("invalid continue token: %v", err))
+		}
+		if revision > s.revision {
+			return storage.NewInvalidObjError(key, fmt.Sprintf("invalid continue token: revision %d is newer than the current revision %d", revision, s.revision))
+		}
+	}
+	objects, err := s.snapshot(key, fromKey, revision)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	items := make([]runtime.Object, 0, len(keys))
+	var lastKey string
+	var remaining int64
+	for _, k := range keys {
+		obj := objects[k]
+		matched, err := opts.Predicate.Matches(obj)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+		if opts.Limit > 0 && int64(len(items)) >= opts.Limit {
+			remaining++
+			continue
+		}
+		items = append(items, obj.DeepCopyObject())
+		lastKey = k
+	}
+	if err := v1.SetList(listObj, items); err != nil {
+		return err
+	}
+	listMeta.SetResourceVersion(strconv.FormatInt(revision, 10))
+	listMeta.SetContinue("")
+	listMeta.SetRemainingItemCount(nil)
+	if remaining > 0 {
+		// the next chunk starts right after the last returned key
+		next, err := storage.EncodeContinue(lastKey+"\x00", key, revision, s.continueSecret)
+		if err != nil {
+			return err
+		}
+		listMeta.SetContinue(next)
+		listMeta.SetRemainingItemCount(&remaining)
+	}
+	return nil
+}
+
+// snapshot returns the objects under the key prefix, starting at fromKey, as
+// they were at revision. Older revisions are rebuilt by undoing the changes
+// kept in the history. Must be called with the lock held.
+func (s *Store) snapshot(prefix, fromKey string, revision int64) (map[string]runtime.Object, error) {
+	objects := map[string]runtime.Object{}
+	for k, obj := range s.objects {
+		if storage.HasPrefix(k, prefix) && k >= fromKey {
+			objects[k] = obj
+		}
+	}
+	if revision == s.revision {
+		return objects, nil
+	}
+
+	h, ok := s.history[kindOf(prefix)]
+	if !ok {
+		return objects, nil
+	}
+	if revision < h.compacted {
+		return nil, storage.NewResourceExpiredError(prefix, revision,
+			"the provided continue parameter is too old to display a consistent list result, start a new list without the continue parameter")
+	}
+	for i := len(h.changes) - 1; i >= 0 && h.changes[i].revision > revision; i-- {
+		c := h.changes[i]
+		if !storage.HasPrefix(c.key, prefix) || c.key < fromKey {
+			continue
+		}
+		if c.prev == nil {
+			delete(objects, c.key)
+		} else {
+			objects[c.key] = c.prev
+		}
+	}
+	return objects, nil
+}
+
+// GuaranteedUpdate implements storage.Interface.GuaranteedUpdate.
+func (s *Store) GuaranteedUpdate(
+	ctx context.Context, key string, destination runtime.Object, ignoreNotFound bool,
+	preconditions *storage.Preconditions, tryUpdate storage.UpdateFunc) error {
+	for {
+		s.lock.RLock()
+		existing, ok := s.objects[key]
+		s.lock.RUnlock()
+
+		var input runtime.Object
+		if ok {
+			if err := preconditions.Check(key, existing); err != nil {
+				return err
+			}
+			input = existing.DeepCopyObject()
+		} else {
+			if !ignoreNotFound {
+				return storage.NewKeyNotFoundError(key, 0)
+			}
+			input = reflect.New(reflect.TypeOf(destination).Elem()).Interface().(runtime.Object)
+		}
+
+		// tryUpdate may be slow or read the store itself, so it runs without the lock
+		// and the write below is retried if the object changed in the meantime.
+		output, err := tryUpdate(input)
+		if err != nil {
+			return err
+		}
+		outMeta, err := v1.Accessor(output)
+		if err != nil {
+			return storage.NewInternalErrorf("can't get meta of object %#v: %v", output, err)
+		}
+
+		s.lock.Lock()
+		current, currentOK := s.objects[key]
+		if currentOK != ok || current != existing {
+			s.lock.Unlock()
+			continue
+		}
+
+		if !ok {
+			stored := output.DeepCopyObject()
+			setResourceVersion(stored, 0)
+			s.initObject(stored)
+			s.commit(key, stored)
+			s.notify(watch.Added, key, stored, nil)
+			s.lock.Unlock()
+			return copyInto(stored, destination)
+		}
+
+		existingMeta, _ := v1.Accessor(existing)
+		if rv := outMeta.GetResourceVersion(); len(rv) != 0 && rv != existingMeta.GetResourceVersion() {
+			s.lock.Unlock()
+			return storage.NewResourceVersionConflictsError(key, parseResourceVersion(existingMeta.GetResourceVersion()))
+		}
+
+		stored := output.DeepCopyObject()
+		storedMeta, _ := v1.Accessor(stored)
+		// uid, creation time and resource version are owned by the store
+		storedMeta.SetUID(existingMeta.GetUID())
+		storedMeta.SetCreationTime(existingMeta.GetCreationTime())
+		storedMeta.SetResourceVersion(existingMeta.GetResourceVersion())
+		storedMeta.SetGeneration(existingMeta.GetGeneration())
+		if specChanged(existing, stored) {
+			storedMeta.SetGeneration(existingMeta.GetGeneration() + 1)
+		}
+		if reflect.DeepEqual(existing, stored) {
+			// nothing changed, don't bump the revision
+			s.lock.Unlock()
+			return copyInto(existing, destination)
+		}
+
+		s.commit(key, stored)
+		s.notify(watch.Modified, key, stored, existing)
+		s.lock.Unlock()
+		return copyInto(stored, destination)
+	}
+}
+
+// initObject sets the fields the store owns on a newly created object.
+func (s *Store) initObject(obj runtime.Object) {
+	metadata, _ := v1.Accessor(obj)
+	metadata.SetUID(v1.UID(uuid.New().String()))
+	metadata.SetCreationTime(s.now())
+	metadata.SetGeneration(1)
+}
+
+// commit stores obj at key as the next revision. Must be called with the lock held.
+func (s *Store) commit(key string, obj runtime.Object) {
+	s.revision++
+	setResourceVersion(obj, s.revision)
+	s.objects[key] = obj
+}
+
+func setResourceVersion(obj runtime.Object, rv int64) {
+	metadata, _ := v1.Accessor(obj)
+	if rv == 0 {
+		metadata.SetResourceVersion("")
+		return
+	}
+	metadata.SetResourceVersion(strconv.FormatInt(rv, 10))
+}
+
+func parseResourceVersion(rv string) int64 {
+	v, _ := strconv.ParseInt(rv, 10, 64)
+	return v
+}
+
+// specChanged reports whether the desired state of obj changed, that is
+// anything but its type meta, object meta and status.
+func specChanged(oldObj, newObj runtime.Object) bool {
+	oldV := reflect.ValueOf(oldObj).Elem()
+	newV := reflect.ValueOf(newObj).Elem()
+	t := oldV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		switch t.Field(i).Name {
+		case "TypeMeta", "ObjectMeta", "Status":
+			continue
+		}
+		if !reflect.DeepEqual(oldV.Field(i).Interface(), newV.Field(i).Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyInto sets out to a deep copy of obj.
+func copyInto(obj, out runtime.Object) error {
+	if out == nil {
+		return nil
+	}
+	outV := reflect.ValueOf(out)
+	objV := reflect.ValueOf(obj.DeepCopyObject())
+	if outV.Type() != objV.Type() {
+		return fmt.Errorf("can't copy %v into %v", objV.Type(), outV.Type())
+	}
+	outV.Elem().Set(objV.Elem())
+	return nil
+}
+
+func setZero(out runtime.Object) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("expected pointer, but got %v", v.Type())
+	}
+	v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	return nil
+}
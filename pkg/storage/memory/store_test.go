@@ -0,0 +1,372 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+func newPod(namespace, name string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       v1.PodSpec{NodeName: "host-1"},
+	}
+}
+
+func TestCreateGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	key := storage.Key("pod", "default", "foo")
+
+	created := &v1.Pod{}
+	if err := s.Create(ctx, key, newPod("default", "foo", nil), created); err != nil {
+		t.Fatal(err)
+	}
+	if created.UID == "" || created.CreationTime.IsZero() || created.Generation != 1 || created.ResourceVersion != "1" {
+		t.Errorf("store owned fields not populated: %#v", created.ObjectMeta)
+	}
+
+	if err := s.Create(ctx, key, newPod("default", "foo", nil), nil); !storage.IsExist(err) {
+		t.Errorf("expected key exists error, got %v", err)
+	}
+	stale := newPod("default", "bar", nil)
+	stale.ResourceVersion = "5"
+	if err := s.Create(ctx, storage.Key("pod", "default", "bar"), stale, nil); !storage.IsInvalidObj(err) {
+		t.Errorf("expected invalid object error creating with resource version, got %v", err)
+	}
+
+	got := &v1.Pod{}
+	if err := s.Get(ctx, key, storage.GetOptions{}, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.UID != created.UID {
+		t.Errorf("expected uid %s, got %s", created.UID, got.UID)
+	}
+	got.Labels = map[string]string{"mutated": "true"}
+	if err := s.Get(ctx, key, storage.GetOptions{}, got); err != nil || got.Labels != nil {
+		t.Errorf("store shares memory with callers: %v %v", err, got.Labels)
+	}
+
+	wrongUID := storage.NewUIDPreconditions("other")
+	if err := s.Delete(ctx, key, &v1.Pod{}, wrongUID); !storage.IsInvalidObj(err) {
+		t.Errorf("expected precondition failure, got %v", err)
+	}
+	deleted := &v1.Pod{}
+	if err := s.Delete(ctx, key, deleted, storage.NewUIDPreconditions(string(created.UID))); err != nil {
+		t.Fatal(err)
+	}
+	if deleted.Name != "foo" {
+		t.Errorf("expected deleted object to be returned, got %#v", deleted)
+	}
+	if err := s.Get(ctx, key, storage.GetOptions{}, &v1.Pod{}); !storage.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	empty := newPod("default", "foo", nil)
+	if err := s.Get(ctx, key, storage.GetOptions{IgnoreNotFound: true}, empty); err != nil || empty.Name != "" {
+		t.Errorf("expected zero object, got %v %#v", err, empty)
+	}
+}
+
+func TestGuaranteedUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	key := storage.Key("pod", "default", "foo")
+	created := &v1.Pod{}
+	if err := s.Create(ctx, key, newPod("default", "foo", nil), created); err != nil {
+		t.Fatal(err)
+	}
+
+	// status only update keeps the generation
+	updated := &v1.Pod{}
+	err := s.GuaranteedUpdate(ctx, key, updated, false, nil, func(input runtime.Object) (runtime.Object, error) {
+		pod := input.(*v1.Pod)
+		pod.Status.Phase = v1.PodRunning
+		pod.UID = "ignored"
+		return pod, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ResourceVersion != "2" || updated.Generation != 1 || updated.UID != created.UID {
+		t.Errorf("unexpected meta after status update: %#v", updated.ObjectMeta)
+	}
+
+	// spec update bumps the generation
+	err = s.GuaranteedUpdate(ctx, key, updated, false, nil, func(input runtime.Object) (runtime.Object, error) {
+		pod := input.(*v1.Pod)
+		pod.Spec.NodeName = "host-2"
+		return pod, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Generation != 2 {
+		t.Errorf("expected generation 2, got %d", updated.Generation)
+	}
+
+	// no-op update keeps the revision
+	rev := s.Revision()
+	err = s.GuaranteedUpdate(ctx, key, updated, false, nil, func(input runtime.Object) (runtime.Object, error) {
+		return input, nil
+	})
+	if err != nil || s.Revision() != rev {
+		t.Errorf("no-op update changed the revision: %v %d -> %d", err, rev, s.Revision())
+	}
+
+	// stale update is rejected
+	err = s.GuaranteedUpdate(ctx, key, updated, false, nil, func(input runtime.Object) (runtime.Object, error) {
+		pod := created.DeepCopy()
+		pod.Spec.NodeName = "host-3"
+		return pod, nil
+	})
+	if !storage.IsConflict(err) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+
+	missing := storage.Key("pod", "default", "missing")
+	if err := s.GuaranteedUpdate(ctx, missing, &v1.Pod{}, false, nil, func(input runtime.Object) (runtime.Object, error) {
+		return input, nil
+	}); !storage.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	upserted := &v1.Pod{}
+	if err := s.GuaranteedUpdate(ctx, missing, upserted, true, nil, func(input runtime.Object) (runtime.Object, error) {
+		pod := input.(*v1.Pod)
+		pod.Name, pod.Namespace = "missing", "default"
+		return pod, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if upserted.UID == "" || upserted.Generation != 1 {
+		t.Errorf("expected upserted object to be initialized, got %#v", upserted.ObjectMeta)
+	}
+}
+
+func TestGuaranteedUpdateConcurrent(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	key := storage.Key("pod", "default", "foo")
+	if err := s.Create(ctx, key, newPod("default", "foo", nil), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.GuaranteedUpdate(ctx, key, &v1.Pod{}, false, nil, func(input runtime.Object) (runtime.Object, error) {
+				pod := input.(*v1.Pod)
+				restarts := int64(0)
+				if pod.Status.TaintRestarts != nil {
+					restarts = *pod.Status.TaintRestarts
+				}
+				restarts++
+				pod.Status.TaintRestarts = &restarts
+				return pod, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pod := &v1.Pod{}
+	if err := s.Get(ctx, key, storage.GetOptions{}, pod); err != nil {
+		t.Fatal(err)
+	}
+	if *pod.Status.TaintRestarts != workers {
+		t.Errorf("expected %d increments, got %d", workers, *pod.Status.TaintRestarts)
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	for i, ns := range []string{"a", "a", "b"} {
+		name := fmt.Sprintf("pod-%d", i)
+		if err := s.Create(ctx, storage.Key("pod", ns, name), newPod(ns, name, map[string]string{"i": fmt.Sprint(i)}), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := &v1.PodList{}
+	if err := s.List(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{}, list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 3 || list.ResourceVersion != "3" {
+		t.Errorf("expected 3 pods at revision 3, got %d at %s", len(list.Items), list.ResourceVersion)
+	}
+
+	if err := s.List(ctx, storage.KeyPrefix("pod", "a"), storage.ListOptions{}, list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("expected 2 pods in namespace a, got %d", len(list.Items))
+	}
+
+	opts := storage.ListOptions{Predicate: storage.SelectionPredicate{
+		Label:    labels.SelectorFromSet(labels.Set{"i": "1"}),
+		GetAttrs: storage.AttrFuncForKind("pod"),
+	}}
+	if err := s.List(ctx, storage.KeyPrefix("pod", ""), opts, list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "pod-1" {
+		t.Errorf("expected only pod-1, got %v", list.Items)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := New()
+
+	opts := storage.ListOptions{Predicate: storage.SelectionPredicate{
+		Label:    labels.SelectorFromSet(labels.Set{"app": "web"}),
+		GetAttrs: storage.AttrFuncForKind("pod"),
+	}}
+	w, err := s.Watch(ctx, storage.KeyPrefix("pod", "default"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	key := storage.Key("pod", "default", "foo")
+	setLabels := func(l map[string]string) {
+		err := s.GuaranteedUpdate(ctx, key, &v1.Pod{}, false, nil, func(input runtime.Object) (runtime.Object, error) {
+			pod := input.(*v1.Pod)
+			pod.Labels = l
+			return pod, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Create(ctx, key, newPod("default", "foo", nil), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Create(ctx, storage.Key("pod", "other", "foo"), newPod("other", "foo", map[string]string{"app": "web"}), nil); err != nil {
+		t.Fatal(err)
+	}
+	setLabels(map[string]string{"app": "web"})
+	setLabels(map[string]string{"app": "web", "v": "2"})
+	setLabels(nil)
+
+	expected := []watch.EventType{watch.Added, watch.Modified, watch.Deleted}
+	for _, eventType := range expected {
+		select {
+		case event := <-w.ResultChan():
+			if event.Type != eventType {
+				t.Errorf("expected %s, got %s", eventType, event.Type)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", eventType)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-w.ResultChan():
+		if ok {
+			t.Errorf("unexpected event after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("watch not stopped after context was cancelled")
+	}
+}
+
+func TestListPaging(t *testing.T) {
+	ctx := context.Background()
+	s := NewWithConfig(Config{HistorySize: 3})
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("pod-%d", i)
+		if err := s.Create(ctx, storage.Key("pod", "default", name), newPod("default", name, nil), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := &v1.PodList{}
+	opts := storage.ListOptions{Limit: 2}
+	if err := s.List(ctx, storage.KeyPrefix("pod", ""), opts, list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 || list.Continue == "" || list.RemainingItemCount == nil || *list.RemainingItemCount != 3 {
+		t.Fatalf("unexpected first chunk: %d items, continue %q, remaining %v", len(list.Items), list.Continue, list.RemainingItemCount)
+	}
+	firstRV := list.ResourceVersion
+
+	// changes after the first chunk are not visible in the following ones
+	if err := s.Delete(ctx, storage.Key("pod", "default", "pod-3"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Create(ctx, storage.Key("pod", "default", "pod-5"), newPod("default", "pod-5", nil), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.Name)
+	}
+	for list.Continue != "" {
+		opts.Continue = list.Continue
+		if err := s.List(ctx, storage.KeyPrefix("pod", ""), opts, list); err != nil {
+			t.Fatal(err)
+		}
+		if list.ResourceVersion != firstRV {
+			t.Errorf("expected chunk at resource version %s, got %s", firstRV, list.ResourceVersion)
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	}
+	if expected := "pod-0,pod-1,pod-2,pod-3,pod-4"; strings.Join(names, ",") != expected {
+		t.Errorf("expected %s, got %v", expected, names)
+	}
+	if list.RemainingItemCount != nil {
+		t.Errorf("expected no remaining item count on the last chunk, got %d", *list.RemainingItemCount)
+	}
+
+	// the token is bound to the listed prefix
+	opts.Limit = 1
+	opts.Continue = ""
+	if err := s.List(ctx, storage.KeyPrefix("pod", ""), opts, list); err != nil {
+		t.Fatal(err)
+	}
+	token := list.Continue
+	if err := s.List(ctx, storage.KeyPrefix("pod", "default"), storage.ListOptions{Continue: token}, list); !storage.IsInvalidObj(err) {
+		t.Errorf("expected invalid continue token, got %v", err)
+	}
+
+	// a token of a revision the store has not reached yet
+	future, err := storage.EncodeContinue("/pod/default/a\x00", storage.KeyPrefix("pod", ""), s.revision+10, s.continueSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.List(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{Continue: future}, list)
+	if !storage.IsInvalidObj(err) || !strings.Contains(err.Error(), "is newer than the current revision") {
+		t.Errorf("expected a continue token from the future to be invalid, got %v", err)
+	}
+
+	// the token expires once the history it needs is compacted
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("new-%d", i)
+		if err := s.Create(ctx, storage.Key("pod", "default", name), newPod("default", name, nil), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.List(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{Continue: token}, list); !storage.IsResourceExpired(err) {
+		t.Errorf("expected resource expired, got %v", err)
+	}
+}
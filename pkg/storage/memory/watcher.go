@@ -0,0 +1,257 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+// change is a write to the store as it is sent through the broadcaster and
+// kept in the history. It embeds the new state of the object, so it is a
+// runtime.Object itself.
+type change struct {
+	runtime.Object
+
+	eventType watch.EventType
+	key       string
+	// prev is the state of the object before the change, nil for creations.
+	prev     runtime.Object
+	revision int64
+}
+
+// bookmark is sent through the broadcaster to the watchers of kind.
+type bookmark struct {
+	runtime.Object
+
+	kind string
+}
+
+// eventHistory is the bounded list of the most recent changes of a kind.
+type eventHistory struct {
+	changes []*change
+	// compacted is the revision of the newest change dropped from the history.
+	// A watch can be resumed from any revision not older than it.
+	compacted int64
+}
+
+func (h *eventHistory) add(c *change, size int) {
+	h.changes = append(h.changes, c)
+	if len(h.changes) > size {
+		h.compacted = h.changes[0].revision
+		h.changes[0] = nil
+		h.changes = h.changes[1:]
+	}
+}
+
+// kindOf returns the kind of the objects stored under key.
+func kindOf(key string) string {
+	return strings.SplitN(strings.TrimPrefix(key, "/"), "/", 2)[0]
+}
+
+// Watch implements storage.Interface.Watch. The changes made after
+// opts.ResourceVersion are replayed from the history of the watched kind
+// before live events are sent. Callers must call Stop on the returned watch,
+// also when its result channel was closed because the watcher fell behind.
+func (s *Store) Watch(ctx context.Context, key string, opts storage.ListOptions) (watch.Interface, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	kind := kindOf(key)
+	filter := &watchFilter{
+		key:            key,
+		kind:           kind,
+		predicate:      opts.Predicate,
+		allowBookmarks: opts.AllowWatchBookmarks,
+	}
+
+	var queued []watch.Event
+	if rv := opts.ResourceVersion; len(rv) != 0 && rv != "0" {
+		revision, err := strconv.ParseInt(rv, 10, 64)
+		if err != nil || revision < 0 {
+			return nil, storage.NewInvalidObjError(key, fmt.Sprintf("invalid resource version %q", rv))
+		}
+		if revision > s.revision {
+			return nil, storage.NewResourceVersionTooLargeError(key, revision, s.revision)
+		}
+		if h, ok := s.history[kind]; ok {
+			if revision < h.compacted {
+				return nil, storage.NewResourceVersionTooOldError(key, revision, h.compacted)
+			}
+			for _, c := range h.changes {
+				if c.revision > revision {
+					queued = append(queued, watch.Event{Type: c.eventType, Object: c})
+				}
+			}
+		}
+	}
+
+	w := &storeWatcher{
+		Interface: s.broadcaster.WatchWithFilter(queued, filter.filter),
+		store:     s,
+		bookmarks: opts.AllowWatchBookmarks,
+		done:      make(chan struct{}),
+	}
+	if w.bookmarks {
+		s.addBookmarkWatcher()
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+		case <-w.done:
+		}
+	}()
+	return w, nil
+}
+
+// notify records the change of the object at key in the history and sends it
+// to the watchers. prevObj is the state before the change, nil for creations.
+// Must be called with the store lock held.
+func (s *Store) notify(eventType watch.EventType, key string, obj, prevObj runtime.Object) {
+	kind := kindOf(key)
+	c := &change{
+		Object:    obj,
+		eventType: eventType,
+		key:       key,
+		prev:      prevObj,
+		revision:  s.revision,
+	}
+	h, ok := s.history[kind]
+	if !ok {
+		h = &eventHistory{}
+		s.history[kind] = h
+		s.types[kind] = reflect.TypeOf(obj).Elem()
+	}
+	h.add(c, s.config.HistorySize)
+	s.broadcaster.Action(eventType, c)
+}
+
+// addBookmarkWatcher starts sending bookmarks when the first watcher asking
+// for them is added. Must be called with the store lock held.
+func (s *Store) addBookmarkWatcher() {
+	s.bookmarkWatchers++
+	if s.bookmarkWatchers == 1 {
+		s.bookmarkStop = make(chan struct{})
+		go s.runBookmarks(s.bookmarkStop)
+	}
+}
+
+func (s *Store) removeBookmarkWatcher() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.bookmarkWatchers--
+	if s.bookmarkWatchers == 0 {
+		close(s.bookmarkStop)
+	}
+}
+
+func (s *Store) runBookmarks(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.config.BookmarkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.sendBookmarks()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// sendBookmarks sends the current revision to the watchers of every kind
+// written so far.
+func (s *Store) sendBookmarks() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for kind, t := range s.types {
+		obj := reflect.New(t).Interface().(runtime.Object)
+		setResourceVersion(obj, s.revision)
+		s.broadcaster.Action(watch.Bookmark, &bookmark{Object: obj, kind: kind})
+	}
+}
+
+// watchFilter turns the changes sent through the broadcaster into the events
+// of a single watch.
+type watchFilter struct {
+	key            string
+	kind           string
+	predicate      storage.SelectionPredicate
+	allowBookmarks bool
+}
+
+// filter returns the event sent to the watcher for in. Every watcher gets its
+// own copy of the object, the changes are shared with the store and the other
+// watchers.
+func (f *watchFilter) filter(in watch.Event) (watch.Event, bool) {
+	var out watch.Event
+	ok := false
+	switch obj := in.Object.(type) {
+	case *change:
+		if storage.HasPrefix(obj.key, f.key) {
+			out, ok = f.convert(obj)
+		}
+	case *bookmark:
+		if f.allowBookmarks && obj.kind == f.kind {
+			out, ok = watch.Event{Type: watch.Bookmark, Object: obj.Object}, true
+		}
+	}
+	if !ok {
+		return watch.Event{}, false
+	}
+	out.Object = out.Object.DeepCopyObject()
+	return out, true
+}
+
+// convert returns the event seen by the watcher for c. An object that starts
+// or stops matching the predicate is reported as added or deleted.
+func (f *watchFilter) convert(c *change) (watch.Event, bool) {
+	if f.predicate.Empty() {
+		return watch.Event{Type: c.eventType, Object: c.Object}, true
+	}
+	curMatches := false
+	if c.eventType != watch.Deleted {
+		curMatches, _ = f.predicate.Matches(c.Object)
+	}
+	prevMatches := false
+	if c.prev != nil {
+		prevMatches, _ = f.predicate.Matches(c.prev)
+	}
+	switch {
+	case curMatches && prevMatches:
+		return watch.Event{Type: watch.Modified, Object: c.Object}, true
+	case curMatches:
+		return watch.Event{Type: watch.Added, Object: c.Object}, true
+	case prevMatches:
+		return watch.Event{Type: watch.Deleted, Object: c.Object}, true
+	}
+	return watch.Event{}, false
+}
+
+// storeWatcher releases the resources held for a watch when it is stopped.
+type storeWatcher struct {
+	watch.Interface
+
+	store     *Store
+	bookmarks bool
+	done      chan struct{}
+	stopOnce  sync.Once
+}
+
+// Stop implements watch.Interface.
+func (w *storeWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		w.Interface.Stop()
+		if w.bookmarks {
+			w.store.removeBookmarkWatcher()
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/watch"
+)
+
+func nextEvent(t *testing.T, w watch.Interface) watch.Event {
+	t.Helper()
+	select {
+	case event, ok := <-w.ResultChan():
+		if !ok {
+			t.Fatalf("result channel closed")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for an event")
+	}
+	return watch.Event{}
+}
+
+func TestWatchResume(t *testing.T) {
+	ctx := context.Background()
+	s := NewWithConfig(Config{HistorySize: 2})
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("pod-%d", i)
+		if err := s.Create(ctx, storage.Key("pod", "default", name), newPod("default", name, nil), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// revisions 3 and 4 are still in the history
+	w, err := s.Watch(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{ResourceVersion: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	for _, name := range []string{"pod-2", "pod-3"} {
+		event := nextEvent(t, w)
+		if pod := event.Object.(*v1.Pod); event.Type != watch.Added || pod.Name != name {
+			t.Errorf("expected added %s, got %s %s", name, event.Type, pod.Name)
+		}
+	}
+
+	if _, err := s.Watch(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{ResourceVersion: "1"}); !storage.IsResourceVersionTooOld(err) {
+		t.Errorf("expected resource version too old, got %v", err)
+	}
+	if _, err := s.Watch(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{ResourceVersion: "10"}); !storage.IsResourceVersionTooLarge(err) {
+		t.Errorf("expected resource version too large, got %v", err)
+	}
+	if _, err := s.Watch(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{ResourceVersion: "abc"}); !storage.IsInvalidObj(err) {
+		t.Errorf("expected invalid resource version, got %v", err)
+	}
+}
+
+func TestWatchBookmarks(t *testing.T) {
+	ctx := context.Background()
+	s := NewWithConfig(Config{BookmarkInterval: 10 * time.Millisecond})
+	if err := s.Create(ctx, storage.Key("pod", "default", "foo"), newPod("default", "foo", nil), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := s.Watch(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{ResourceVersion: "1", AllowWatchBookmarks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := nextEvent(t, w)
+	if event.Type != watch.Bookmark || event.Object.(*v1.Pod).ResourceVersion != "1" {
+		t.Errorf("expected bookmark at revision 1, got %s %#v", event.Type, event.Object)
+	}
+	w.Stop()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.bookmarkWatchers != 0 {
+		t.Errorf("expected bookmark watcher to be released, got %d", s.bookmarkWatchers)
+	}
+}
+
+func TestWatchSlowWatcher(t *testing.T) {
+	ctx := context.Background()
+	s := NewWithConfig(Config{WatchQueueLength: 1})
+	w, err := s.Watch(ctx, storage.KeyPrefix("pod", ""), storage.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	// writes must not block on a watcher that never reads
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("pod-%d", i)
+		if err := s.Create(ctx, storage.Key("pod", "default", name), newPod("default", name, nil), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for {
+		select {
+		case _, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the slow watcher to be closed")
+		}
+	}
+}
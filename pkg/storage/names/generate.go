@@ -0,0 +1,99 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package names generates the names of objects created with
+// ObjectMeta.GenerateName.
+package names
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/storage"
+	utilrand "github.com/opencarry/carry/pkg/util/rand"
+	"github.com/opencarry/carry/pkg/util/validation"
+)
+
+// NameGenerator generates names for objects. Some backends may have more information
+// available to guide selection of new names and this interface hides those details.
+type NameGenerator interface {
+	// GenerateName generates a valid name from the base name, adding a random suffix to
+	// the base. If base is valid, the returned name must also be valid. The generator is
+	// responsible for knowing the maximum valid name length.
+	GenerateName(base string) string
+}
+
+const (
+	// randomLength is the length of the random suffix of a generated name.
+	randomLength = 5
+	// MaxGenerateNameRetries is the number of names Create tries before it
+	// gives up on a key that keeps colliding.
+	MaxGenerateNameRetries = 8
+)
+
+// simpleNameGenerator generates random names.
+type simpleNameGenerator struct {
+	maxLength int
+}
+
+// SimpleNameGenerator is a generator that returns the name plus a random suffix of five alphanumerics
+// when a name is requested. The generator truncates the base so the name fits a DNS label, which makes
+// it safe for every kind.
+var SimpleNameGenerator = NewSimpleNameGenerator(validation.DNS1123LabelMaxLength)
+
+// SubdomainNameGenerator is like SimpleNameGenerator for kinds whose names are
+// DNS subdomains.
+var SubdomainNameGenerator = NewSimpleNameGenerator(validation.DNS1123SubdomainMaxLength)
+
+// NewSimpleNameGenerator returns a generator of names of at most maxLength
+// characters.
+func NewSimpleNameGenerator(maxLength int) NameGenerator {
+	return simpleNameGenerator{maxLength: maxLength}
+}
+
+func (g simpleNameGenerator) GenerateName(base string) string {
+	if max := g.maxLength - randomLength; len(base) > max {
+		base = base[:max]
+	}
+	return fmt.Sprintf("%s%s", base, utilrand.String(randomLength))
+}
+
+// KeyFunc returns the storage key of the object with the given name.
+type KeyFunc func(name string) string
+
+// Create creates obj in s like storage.Interface.Create. If obj has no name
+// but a generate_name, a name is generated from it, and on a collision with an
+// existing key a new one is tried, up to MaxGenerateNameRetries times. obj is
+// left with the name it was created with.
+func Create(ctx context.Context, s storage.Interface, keyFunc KeyFunc, generator NameGenerator, obj, out runtime.Object) error {
+	metadata, err := v1.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	if len(metadata.GetName()) != 0 || len(metadata.GetGenerateName()) == 0 {
+		return s.Create(ctx, keyFunc(metadata.GetName()), obj, out)
+	}
+
+	for i := 0; ; i++ {
+		metadata.SetName(generator.GenerateName(metadata.GetGenerateName()))
+		err = s.Create(ctx, keyFunc(metadata.GetName()), obj, out)
+		if !storage.IsExist(err) || i+1 >= MaxGenerateNameRetries {
+			return err
+		}
+	}
+}
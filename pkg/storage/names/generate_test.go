@@ -0,0 +1,95 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/storage"
+	"github.com/opencarry/carry/pkg/storage/memory"
+	"github.com/opencarry/carry/pkg/util/validation"
+)
+
+func TestSimpleNameGenerator(t *testing.T) {
+	name := SimpleNameGenerator.GenerateName("foo")
+	if !strings.HasPrefix(name, "foo") || len(name) != len("foo")+randomLength {
+		t.Errorf("unexpected name: %s", name)
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) != 0 {
+		t.Errorf("generated name %s is not a DNS label: %v", name, errs)
+	}
+
+	long := strings.Repeat("a", 300)
+	if name := SimpleNameGenerator.GenerateName(long); len(name) != validation.DNS1123LabelMaxLength {
+		t.Errorf("expected name of %d chars, got %d", validation.DNS1123LabelMaxLength, len(name))
+	}
+	if name := SubdomainNameGenerator.GenerateName(long); len(name) != validation.DNS1123SubdomainMaxLength {
+		t.Errorf("expected name of %d chars, got %d", validation.DNS1123SubdomainMaxLength, len(name))
+	}
+}
+
+// sequenceGenerator returns the given names in order.
+type sequenceGenerator struct {
+	names []string
+}
+
+func (g *sequenceGenerator) GenerateName(base string) string {
+	name := g.names[0]
+	g.names = g.names[1:]
+	return base + name
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	keyFunc := func(name string) string { return storage.Key("pod", "default", name) }
+	newPod := func() *v1.Pod {
+		return &v1.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "default", GenerateName: "web-"}}
+	}
+
+	if err := s.Create(ctx, keyFunc("web-aaaaa"), &v1.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "web-aaaaa"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a collision is retried with a new name
+	pod, out := newPod(), &v1.Pod{}
+	if err := Create(ctx, s, keyFunc, &sequenceGenerator{names: []string{"aaaaa", "bbbbb"}}, pod, out); err != nil {
+		t.Fatal(err)
+	}
+	if pod.Name != "web-bbbbb" || out.Name != "web-bbbbb" {
+		t.Errorf("expected web-bbbbb, got %s %s", pod.Name, out.Name)
+	}
+
+	// giving up after too many collisions
+	names := make([]string, MaxGenerateNameRetries)
+	for i := range names {
+		names[i] = "aaaaa"
+	}
+	if err := Create(ctx, s, keyFunc, &sequenceGenerator{names: names}, newPod(), nil); !storage.IsExist(err) {
+		t.Errorf("expected key exists error, got %v", err)
+	}
+
+	// an explicit name wins over generate_name
+	pod = newPod()
+	pod.Name = "explicit"
+	if err := Create(ctx, s, keyFunc, SimpleNameGenerator, pod, nil); err != nil || pod.Name != "explicit" {
+		t.Errorf("expected explicit name to be kept, got %v %s", err, pod.Name)
+	}
+}
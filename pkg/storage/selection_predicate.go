@@ -0,0 +1,116 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+	"github.com/opencarry/carry/pkg/fields"
+	"github.com/opencarry/carry/pkg/labels"
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+// AttrFunc returns label and field sets and the uninitialized flag for List or Watch to match.
+// In any failure to parse given object, it returns error.
+type AttrFunc func(obj runtime.Object) (labels.Set, fields.Set, error)
+
+// AttrFuncForKind returns an AttrFunc that exposes the labels of an object and
+// the fields selectable for kind, see v1.SelectableFields.
+func AttrFuncForKind(kind string) AttrFunc {
+	return func(obj runtime.Object) (labels.Set, fields.Set, error) {
+		metadata, err := v1.Accessor(obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		fieldSet, err := v1.SelectableFields(kind, obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		return labels.Set(metadata.GetLabels()), fieldSet, nil
+	}
+}
+
+// DefaultNamespaceScopedAttr returns the labels and the metadata fields of a
+// namespaced object.
+func DefaultNamespaceScopedAttr(obj runtime.Object) (labels.Set, fields.Set, error) {
+	metadata, err := v1.Accessor(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return labels.Set(metadata.GetLabels()), v1.ObjectMetaFieldsSet(metadata, true), nil
+}
+
+// DefaultClusterScopedAttr returns the labels and the metadata fields of a
+// cluster scoped object.
+func DefaultClusterScopedAttr(obj runtime.Object) (labels.Set, fields.Set, error) {
+	metadata, err := v1.Accessor(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return labels.Set(metadata.GetLabels()), v1.ObjectMetaFieldsSet(metadata, false), nil
+}
+
+// SelectionPredicate is used to represent the way to select objects from api storage.
+type SelectionPredicate struct {
+	Label    labels.Selector
+	Field    fields.Selector
+	GetAttrs AttrFunc
+}
+
+// Everything accepts all objects.
+var Everything = SelectionPredicate{
+	Label: labels.Everything(),
+	Field: fields.Everything(),
+}
+
+// Matches returns true if the given object's labels and fields (as
+// returned by s.GetAttrs) match s.Label and s.Field. An error is
+// returned if s.GetAttrs fails.
+func (s *SelectionPredicate) Matches(obj runtime.Object) (bool, error) {
+	if s.Empty() {
+		return true, nil
+	}
+	getAttrs := s.GetAttrs
+	if getAttrs == nil {
+		getAttrs = DefaultNamespaceScopedAttr
+	}
+	labelSet, fieldSet, err := getAttrs(obj)
+	if err != nil {
+		return false, err
+	}
+	matched := s.Label == nil || s.Label.Matches(labelSet)
+	if matched && s.Field != nil {
+		matched = s.Field.Matches(fieldSet)
+	}
+	return matched, nil
+}
+
+// MatchesSingle will return (name, true) if and only if s.Field matches on the object's
+// name.
+func (s *SelectionPredicate) MatchesSingle() (string, bool) {
+	if s.Field == nil {
+		return "", false
+	}
+	if name, ok := s.Field.RequiresExactMatch("metadata.name"); ok {
+		return name, true
+	}
+	return "", false
+}
+
+// Empty returns true if the predicate performs no filtering.
+func (s *SelectionPredicate) Empty() bool {
+	return (s.Label == nil || s.Label.Empty()) && (s.Field == nil || s.Field.Empty())
+}
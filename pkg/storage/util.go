@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"path"
+	"strings"
+)
+
+// Key returns the storage key of the object name of kind in namespace. For
+// cluster scoped kinds namespace is empty.
+func Key(kind, namespace, name string) string {
+	return path.Join(KeyPrefix(kind, namespace), name)
+}
+
+// KeyPrefix returns the key prefix under which all objects of kind in
+// namespace are stored. An empty namespace selects all namespaces (or the
+// single cluster scope of cluster scoped kinds).
+func KeyPrefix(kind, namespace string) string {
+	if len(namespace) == 0 {
+		return "/" + kind + "/"
+	}
+	return "/" + kind + "/" + namespace + "/"
+}
+
+// HasPrefix returns true if key is stored under prefix. A key is its own prefix,
+// which lets Watch and List be used on a single object.
+func HasPrefix(key, prefix string) bool {
+	if key == prefix {
+		return true
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return strings.HasPrefix(key, prefix)
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// PatchType is the media type of a patch sent to the API server.
+type PatchType string
+
+// Only the types below are supported by the PATCH verb.
+const (
+	// JSONPatchType is a list of operations as described in RFC 6902.
+	JSONPatchType PatchType = "application/json-patch+json"
+	// MergePatchType is a JSON merge patch as described in RFC 7386.
+	MergePatchType PatchType = "application/merge-patch+json"
+	// StrategicMergePatchType is a merge patch that merges lists by the merge
+	// keys declared on the carry.i/v1 types.
+	StrategicMergePatchType PatchType = "application/strategic-merge-patch+json"
+	// ApplyPatchType is a partial object, in YAML or JSON, applied on behalf
+	// of a field manager (server-side apply).
+	ApplyPatchType PatchType = "application/apply-patch+yaml"
+)
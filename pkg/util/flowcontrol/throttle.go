@@ -0,0 +1,207 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flowcontrol limits the rate at which clients send requests.
+package flowcontrol
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of requests with a token bucket.
+type RateLimiter interface {
+	// TryAccept returns true if a token is taken immediately. Otherwise,
+	// it returns false.
+	TryAccept() bool
+	// Accept returns once a token becomes available.
+	Accept()
+	// Stop stops the rate limiter, it has no effect on the token bucket
+	Stop()
+	// QPS returns QPS of this rate limiter
+	QPS() float32
+	// Wait returns nil if a token is taken before the Context is done.
+	Wait(ctx context.Context) error
+}
+
+// ReservingRateLimiter is a RateLimiter that can also hand out tokens
+// without blocking, for callers that schedule the wait themselves.
+type ReservingRateLimiter interface {
+	RateLimiter
+	// Reserve takes a token, possibly borrowed from the future, and returns
+	// how long the caller has to wait before using it.
+	Reserve() time.Duration
+}
+
+// errWouldExceedDeadline is returned by Wait when the context would be done
+// before a token is available.
+var errWouldExceedDeadline = errors.New("rate limiter wait would exceed context deadline")
+
+type tokenBucketRateLimiter struct {
+	lock sync.Mutex
+	qps  float32
+	// burst is the size of the bucket.
+	burst int
+	// tokens is the number of tokens in the bucket at last, it is negative
+	// when tokens are reserved by waiting callers.
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucketRateLimiter creates a rate limiter which implements a token bucket approach.
+// The rate limiter allows bursts of up to 'burst' to exceed the QPS, while still maintaining a
+// smoothed qps rate of 'qps'.
+// The bucket is initially filled with 'burst' tokens, and refills at a rate of 'qps'.
+// The maximum number of tokens in the bucket is capped at 'burst'.
+func NewTokenBucketRateLimiter(qps float32, burst int) RateLimiter {
+	return newTokenBucketRateLimiter(qps, burst, time.Now)
+}
+
+// NewTokenBucketReservingRateLimiter is like NewTokenBucketRateLimiter but
+// the returned rate limiter also supports Reserve.
+func NewTokenBucketReservingRateLimiter(qps float32, burst int) ReservingRateLimiter {
+	return newTokenBucketRateLimiter(qps, burst, time.Now)
+}
+
+func newTokenBucketRateLimiter(qps float32, burst int, now func() time.Time) *tokenBucketRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucketRateLimiter{
+		qps:    qps,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// advance adds the tokens earned since the last call, up to burst.
+func (t *tokenBucketRateLimiter) advance(now time.Time) {
+	if elapsed := now.Sub(t.last); elapsed > 0 {
+		t.tokens += elapsed.Seconds() * float64(t.qps)
+		if t.tokens > float64(t.burst) {
+			t.tokens = float64(t.burst)
+		}
+	}
+	t.last = now
+}
+
+// reserve takes a token, possibly borrowed from the future, and returns how
+// long the caller has to wait before using it. It returns false if the wait
+// would be longer than maxWait.
+func (t *tokenBucketRateLimiter) reserve(maxWait time.Duration) (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.advance(now)
+	tokens := t.tokens - 1
+	var wait time.Duration
+	if tokens < 0 {
+		if t.qps <= 0 {
+			return 0, false
+		}
+		wait = time.Duration(-tokens / float64(t.qps) * float64(time.Second))
+	}
+	if wait > maxWait {
+		return 0, false
+	}
+	t.tokens = tokens
+	return wait, true
+}
+
+func (t *tokenBucketRateLimiter) TryAccept() bool {
+	_, ok := t.reserve(0)
+	return ok
+}
+
+// Accept will block until a token becomes available
+func (t *tokenBucketRateLimiter) Accept() {
+	wait, ok := t.reserve(time.Duration(1<<63 - 1))
+	if ok && wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (t *tokenBucketRateLimiter) Reserve() time.Duration {
+	wait, _ := t.reserve(time.Duration(1<<63 - 1))
+	return wait
+}
+
+func (t *tokenBucketRateLimiter) Stop() {
+}
+
+func (t *tokenBucketRateLimiter) QPS() float32 {
+	return t.qps
+}
+
+func (t *tokenBucketRateLimiter) Wait(ctx context.Context) error {
+	maxWait := time.Duration(1<<63 - 1)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = deadline.Sub(t.now())
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	wait, ok := t.reserve(maxWait)
+	if !ok {
+		return errWouldExceedDeadline
+	}
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// 放回预留的令牌
+		t.lock.Lock()
+		t.tokens++
+		t.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+type fakeAlwaysRateLimiter struct{}
+
+// NewFakeAlwaysRateLimiter returns a rate limiter that never limits.
+func NewFakeAlwaysRateLimiter() RateLimiter {
+	return &fakeAlwaysRateLimiter{}
+}
+
+func (t *fakeAlwaysRateLimiter) TryAccept() bool {
+	return true
+}
+
+func (t *fakeAlwaysRateLimiter) Stop() {}
+
+func (t *fakeAlwaysRateLimiter) Accept() {}
+
+func (t *fakeAlwaysRateLimiter) QPS() float32 {
+	return 1
+}
+
+func (t *fakeAlwaysRateLimiter) Wait(ctx context.Context) error {
+	return nil
+}
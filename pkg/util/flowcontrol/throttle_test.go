@@ -0,0 +1,117 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestTokenBucketRateLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	r := newTokenBucketRateLimiter(1, 3, clock.Now)
+
+	// burst 个令牌可以立即拿到
+	for i := 0; i < 3; i++ {
+		if !r.TryAccept() {
+			t.Fatalf("expected token %d of the burst to be accepted", i)
+		}
+	}
+	if r.TryAccept() {
+		t.Fatal("expected the 4th token to be rejected")
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if !r.TryAccept() {
+		t.Error("expected a token to be accepted after a second")
+	}
+	if r.TryAccept() {
+		t.Error("expected only one token to be earned in a second")
+	}
+
+	// 令牌数不超过burst
+	clock.now = clock.now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		if !r.TryAccept() {
+			t.Fatalf("expected token %d of the burst to be accepted", i)
+		}
+	}
+	if r.TryAccept() {
+		t.Error("expected the tokens to be capped at the burst")
+	}
+}
+
+func TestWait(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	r := newTokenBucketRateLimiter(0.01, 1, clock.Now)
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("expected the first token without waiting, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.Wait(ctx); err == nil {
+		t.Error("expected an error when the wait exceeds the deadline")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := r.Wait(ctx); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	r := newTokenBucketRateLimiter(10, 2, clock.Now)
+	for i := 0; i < 2; i++ {
+		if wait := r.Reserve(); wait != 0 {
+			t.Fatalf("expected token %d of the burst without waiting, got %v", i, wait)
+		}
+	}
+	// 预留的令牌依次排队
+	if wait := r.Reserve(); wait != 100*time.Millisecond {
+		t.Errorf("expected to wait 100ms, got %v", wait)
+	}
+	if wait := r.Reserve(); wait != 200*time.Millisecond {
+		t.Errorf("expected to wait 200ms, got %v", wait)
+	}
+	if r.TryAccept() {
+		t.Error("expected no token while reservations are pending")
+	}
+}
+
+func TestFakeAlwaysRateLimiter(t *testing.T) {
+	r := NewFakeAlwaysRateLimiter()
+	for i := 0; i < 100; i++ {
+		if !r.TryAccept() {
+			t.Fatal("expected the fake rate limiter to always accept")
+		}
+	}
+	if err := r.Wait(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,393 @@
+// Package jsonpatch applies JSON patches (RFC 6902) and JSON merge patches
+// (RFC 7386) to JSON documents.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrTestFailed is returned by Apply when the value of a test operation does
+// not match the document.
+var ErrTestFailed = errors.New("test operation failed")
+
+// Operation is a single operation of a JSON patch.
+type Operation struct {
+	// Op is one of add, remove, replace, move, copy and test.
+	Op string `json:"op"`
+	// Path is the JSON pointer (RFC 6901) of the target location.
+	Path string `json:"path"`
+	// From is the source location of move and copy.
+	From string `json:"from,omitempty"`
+	// Value is the value of add, replace and test. A nil Value is missing, a
+	// JSON null is not.
+	Value *json.RawMessage `json:"value,omitempty"`
+}
+
+// UnmarshalJSON keeps a null value, which encoding/json would decode as a
+// missing one.
+func (op *Operation) UnmarshalJSON(data []byte) error {
+	type operation Operation
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*operation)(op)); err != nil {
+		return err
+	}
+	if value, ok := raw["value"]; ok {
+		if value == nil {
+			value = json.RawMessage("null")
+		}
+		op.Value = &value
+	}
+	return nil
+}
+
+// Patch is an ordered list of operations.
+type Patch []Operation
+
+// DecodePatch decodes a JSON patch document.
+func DecodePatch(buf []byte) (Patch, error) {
+	var p Patch
+	if err := json.Unmarshal(buf, &p); err != nil {
+		return nil, err
+	}
+	for i, op := range p {
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, fmt.Errorf("operation %d: %s requires a value", i, op.Op)
+			}
+		case "move", "copy":
+			if _, err := parsePointer(op.From); err != nil {
+				return nil, fmt.Errorf("operation %d: %v", i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+		if _, err := parsePointer(op.Path); err != nil {
+			return nil, fmt.Errorf("operation %d: %v", i, err)
+		}
+	}
+	return p, nil
+}
+
+// Apply applies the operations of p in order to doc and returns the patched
+// document. Either all operations are applied or none.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+	node, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range p {
+		node, err = op.apply(node)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(node)
+}
+
+func (op Operation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add":
+		value, err := decode(*op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "replace":
+		value, err := decode(*op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "move":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, fmt.Errorf("cannot move %s into one of its children", op.From)
+		}
+		doc, value, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = deepCopy(value); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "test":
+		expected, err := decode(*op.Value)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, expected) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unknown op %q", op.Op)
+}
+
+// MergePatch applies the JSON merge patch to doc as described in RFC 7386:
+// objects are merged recursively, null removes a member and any other value
+// replaces the one in doc.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	docNode, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	patchNode, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(docNode, patchNode))
+}
+
+func mergePatch(doc, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	docObj, ok := doc.(map[string]interface{})
+	if !ok {
+		docObj = map[string]interface{}{}
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(docObj, k)
+			continue
+		}
+		docObj[k] = mergePatch(docObj[k], v)
+	}
+	return docObj
+}
+
+// decode decodes a JSON document keeping numbers as json.Number, so that
+// integers survive the round trip unchanged.
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+	return v, nil
+}
+
+// parsePointer splits a JSON pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if len(pointer) == 0 {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid path %q: must be empty or start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+// index parses the array index token. With end set "-" and len(array) are
+// accepted too, they point past the last element.
+func index(token string, length int, end bool) (int, error) {
+	if end && token == "-" {
+		return length, nil
+	}
+	if len(token) == 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > length || (i == length && !end) {
+		return 0, fmt.Errorf("array index %d out of bounds", i)
+	}
+	return i, nil
+}
+
+// navigate calls f with the value at path and stores the value returned by f
+// in its place. It returns the new root.
+func navigate(node interface{}, path []string, f func(node interface{}) (interface{}, error)) (interface{}, error) {
+	if len(path) == 0 {
+		return f(node)
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", path[0])
+		}
+		child, err := navigate(child, path[1:], f)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = child
+		return n, nil
+	case []interface{}:
+		i, err := index(path[0], len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		child, err := navigate(n[i], path[1:], f)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	}
+	return nil, fmt.Errorf("cannot traverse into %T at %q", node, path[0])
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	var value interface{}
+	_, err := navigate(node, path, func(node interface{}) (interface{}, error) {
+		value = node
+		return node, nil
+	})
+	return value, err
+}
+
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	key := path[len(path)-1]
+	return navigate(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[key] = value
+			return p, nil
+		case []interface{}:
+			i, err := index(key, len(p), true)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		}
+		return nil, fmt.Errorf("cannot add %q to %T", key, parent)
+	})
+}
+
+// remove removes the value at path and returns the new root and the removed
+// value.
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	key := path[len(path)-1]
+	var removed interface{}
+	doc, err := navigate(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			value, ok := p[key]
+			if !ok {
+				return nil, fmt.Errorf("member %q not found", key)
+			}
+			removed = value
+			delete(p, key)
+			return p, nil
+		case []interface{}:
+			i, err := index(key, len(p), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = p[i]
+			return append(p[:i:i], p[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot remove %q from %T", key, parent)
+	})
+	return doc, removed, err
+}
+
+func deepCopy(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+// equal compares two decoded JSON values, numbers are equal if their values
+// are, regardless of how they are written.
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if x == y {
+			return true
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,223 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func jsonEqual(t *testing.T, expected string, got []byte) {
+	t.Helper()
+	var e, g interface{}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(e, g) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+// the examples of RFC 6902 appendix A
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "add object member",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			expected: `{"baz": "qux", "foo": "bar"}`,
+		},
+		{
+			name:     "add array element",
+			doc:      `{"foo": ["bar", "baz"]}`,
+			patch:    `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			expected: `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			name:     "append array element",
+			doc:      `{"foo": ["bar"]}`,
+			patch:    `[{"op": "add", "path": "/foo/-", "value": "qux"}]`,
+			expected: `{"foo": ["bar", "qux"]}`,
+		},
+		{
+			name:     "remove object member",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "remove", "path": "/baz"}]`,
+			expected: `{"foo": "bar"}`,
+		},
+		{
+			name:     "remove array element",
+			doc:      `{"foo": ["bar", "qux", "baz"]}`,
+			patch:    `[{"op": "remove", "path": "/foo/1"}]`,
+			expected: `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			name:     "replace value",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			expected: `{"baz": "boo", "foo": "bar"}`,
+		},
+		{
+			name:     "move value",
+			doc:      `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch:    `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			expected: `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{
+			name:     "move array element",
+			doc:      `{"foo": ["all", "grass", "cows", "eat"]}`,
+			patch:    `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			expected: `{"foo": ["all", "cows", "eat", "grass"]}`,
+		},
+		{
+			name:     "copy value",
+			doc:      `{"foo": {"bar": 1}}`,
+			patch:    `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "replace", "path": "/baz/bar", "value": 2}]`,
+			expected: `{"foo": {"bar": 1}, "baz": {"bar": 2}}`,
+		},
+		{
+			name:     "test value",
+			doc:      `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			patch:    `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			expected: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		},
+		{
+			name:     "add nested member object",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			expected: `{"foo": "bar", "child": {"grandchild": {}}}`,
+		},
+		{
+			name:     "escaped path",
+			doc:      `{"/": 9, "~1": 10}`,
+			patch:    `[{"op": "test", "path": "/~01", "value": 10}, {"op": "remove", "path": "/~1"}]`,
+			expected: `{"~1": 10}`,
+		},
+		{
+			name:     "add array value",
+			doc:      `{"foo": ["bar"]}`,
+			patch:    `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			expected: `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+		{
+			name:     "add null",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": null}]`,
+			expected: `{"foo": "bar", "baz": null}`,
+		},
+		{
+			name:     "replace root",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "", "value": {"baz": "qux"}}]`,
+			expected: `{"baz": "qux"}`,
+		},
+		{
+			name:     "large integers are kept",
+			doc:      `{"foo": 9007199254740993}`,
+			patch:    `[{"op": "add", "path": "/bar", "value": 1}]`,
+			expected: `{"foo": 9007199254740993, "bar": 1}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := DecodePatch([]byte(tc.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Apply([]byte(tc.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			jsonEqual(t, tc.expected, got)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		doc   string
+		patch string
+	}{
+		{"add to missing parent", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`},
+		{"remove missing member", `{"foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`},
+		{"replace missing member", `{"foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": 1}]`},
+		{"index out of bounds", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": 1}]`},
+		{"leading zero index", `{"foo": ["bar", "baz"]}`, `[{"op": "remove", "path": "/foo/01"}]`},
+		{"move into own child", `{"foo": {"bar": 1}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := DecodePatch([]byte(tc.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := p.Apply([]byte(tc.doc)); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+
+	p, err := DecodePatch([]byte(`[{"op": "test", "path": "/baz", "value": "bar"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Apply([]byte(`{"baz": "qux"}`)); !errors.Is(err, ErrTestFailed) {
+		t.Errorf("expected the test to fail, got %v", err)
+	}
+}
+
+func TestDecodePatchErrors(t *testing.T) {
+	for _, patch := range []string{
+		`{"op": "add"}`,
+		`[{"op": "add", "path": "/foo"}]`,
+		`[{"op": "bogus", "path": "/foo"}]`,
+		`[{"op": "remove", "path": "foo"}]`,
+		`[{"op": "move", "from": "foo", "path": "/bar"}]`,
+	} {
+		if _, err := DecodePatch([]byte(patch)); err == nil {
+			t.Errorf("expected an error for %s", patch)
+		}
+	}
+}
+
+// the examples of RFC 7386 appendix A
+func TestMergePatch(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tc := range testCases {
+		got, err := MergePatch([]byte(tc.doc), []byte(tc.patch))
+		if err != nil {
+			t.Errorf("%s + %s: %v", tc.doc, tc.patch, err)
+			continue
+		}
+		jsonEqual(t, tc.expected, got)
+	}
+}
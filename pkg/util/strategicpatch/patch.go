@@ -0,0 +1,336 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package strategicpatch applies strategic merge patches to the JSON form of
+// API objects.
+//
+// A strategic merge patch is a JSON merge patch (RFC 7386) that knows about
+// the lists of the patched type: a list field tagged with
+// patchStrategy:"merge" and patchMergeKey:"<key>" is merged element by
+// element, elements being matched by the value of their merge key, instead
+// of being replaced as a whole. For example a patch of a pod
+//
+//	{"spec": {"containers": [{"name": "web", "image": "web:2"}]}}
+//
+// updates the image of the container named web and leaves the other
+// containers alone.
+//
+// The following directives are understood:
+//
+//	{"$patch": "replace"}  in a map or as a list element, replaces the whole
+//	                       map or list instead of merging it
+//	{"$patch": "delete"}   as a list element, removes the element with the
+//	                       same merge key; in a map, removes the map
+//	{"$patch": "merge"}    the default, merges the map
+package strategicpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	directiveMarker  = "$patch"
+	replaceDirective = "replace"
+	deleteDirective  = "delete"
+	mergeDirective   = "merge"
+
+	mergeStrategy = "merge"
+)
+
+// StrategicMergePatch applies the strategic merge patch to the JSON document
+// original and returns the patched document. dataStruct is a value of the
+// type the document encodes, like &v1.Pod{}; its struct tags tell which
+// lists are merged and by what key.
+func StrategicMergePatch(original, patch []byte, dataStruct interface{}) ([]byte, error) {
+	originalMap, err := decodeMap(original)
+	if err != nil {
+		return nil, fmt.Errorf("invalid original document: %v", err)
+	}
+	patchMap, err := decodeMap(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %v", err)
+	}
+	result, err := StrategicMergeMapPatch(originalMap, patchMap, dataStruct)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// StrategicMergeMapPatch is like StrategicMergePatch for already decoded
+// documents. original is modified in place.
+func StrategicMergeMapPatch(original, patch map[string]interface{}, dataStruct interface{}) (map[string]interface{}, error) {
+	t, err := structType(dataStruct)
+	if err != nil {
+		return nil, err
+	}
+	return mergeMap(original, patch, t)
+}
+
+func structType(dataStruct interface{}) (reflect.Type, error) {
+	if dataStruct == nil {
+		return nil, fmt.Errorf("dataStruct must not be nil")
+	}
+	t := reflect.TypeOf(dataStruct)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dataStruct must be a struct or a pointer to a struct, got %v", t)
+	}
+	return t, nil
+}
+
+func decodeMap(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	m := map[string]interface{}{}
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+	return m, nil
+}
+
+// mergeMap merges patch into original. t is the type original encodes, nil
+// when unknown, then maps are merged recursively and lists replaced.
+func mergeMap(original, patch map[string]interface{}, t reflect.Type) (map[string]interface{}, error) {
+	if original == nil {
+		original = map[string]interface{}{}
+	}
+	if v, ok := patch[directiveMarker]; ok {
+		switch v {
+		case replaceDirective:
+			// the patch, without the directive, becomes the new value
+			return stripDirective(patch), nil
+		case deleteDirective:
+			return map[string]interface{}{}, nil
+		case mergeDirective:
+		default:
+			return nil, fmt.Errorf("unknown patch directive %v in map", v)
+		}
+	}
+
+	for k, patchValue := range patch {
+		if k == directiveMarker {
+			continue
+		}
+		if patchValue == nil {
+			delete(original, k)
+			continue
+		}
+		if m, ok := patchValue.(map[string]interface{}); ok && m[directiveMarker] == deleteDirective {
+			delete(original, k)
+			continue
+		}
+		fieldType, strategy, mergeKey := LookupPatchMetadata(t, k)
+		merged, err := mergeValue(original[k], patchValue, fieldType, strategy, mergeKey)
+		if err != nil {
+			return nil, err
+		}
+		original[k] = merged
+	}
+	return original, nil
+}
+
+func mergeValue(original, patch interface{}, t reflect.Type, strategy, mergeKey string) (interface{}, error) {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		o, _ := original.(map[string]interface{})
+		return mergeMap(o, p, elemType(t))
+	case []interface{}:
+		if strategy != mergeStrategy {
+			return p, nil
+		}
+		o, _ := original.([]interface{})
+		return mergeList(o, p, sliceElemType(t), mergeKey)
+	}
+	return patch, nil
+}
+
+// mergeList merges the elements of patch into original. Elements of lists
+// of objects are matched by mergeKey, new elements are appended. Lists of
+// primitives are merged as sets.
+func mergeList(original, patch []interface{}, t reflect.Type, mergeKey string) ([]interface{}, error) {
+	for _, v := range patch {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if directive, ok := m[directiveMarker]; ok && directive == replaceDirective {
+			var replaced []interface{}
+			for _, v := range patch {
+				if m, ok := v.(map[string]interface{}); ok {
+					if _, ok := m[directiveMarker]; ok {
+						continue
+					}
+				}
+				replaced = append(replaced, v)
+			}
+			return replaced, nil
+		}
+	}
+
+	if len(mergeKey) == 0 {
+		for _, v := range patch {
+			if !containsValue(original, v) {
+				original = append(original, v)
+			}
+		}
+		return original, nil
+	}
+
+	for _, v := range patch {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object in a list merged by %q, got %v", mergeKey, v)
+		}
+		key, ok := m[mergeKey]
+		if !ok {
+			return nil, fmt.Errorf("map: %v does not contain declared merge key: %s", m, mergeKey)
+		}
+		i := findByMergeKey(original, mergeKey, key)
+
+		if directive, ok := m[directiveMarker]; ok && directive == deleteDirective {
+			if i >= 0 {
+				original = append(original[:i:i], original[i+1:]...)
+			}
+			continue
+		}
+		if i < 0 {
+			merged, err := mergeMap(nil, m, t)
+			if err != nil {
+				return nil, err
+			}
+			original = append(original, merged)
+			continue
+		}
+		o, _ := original[i].(map[string]interface{})
+		merged, err := mergeMap(o, m, t)
+		if err != nil {
+			return nil, err
+		}
+		original[i] = merged
+	}
+	return original, nil
+}
+
+func findByMergeKey(list []interface{}, mergeKey string, value interface{}) int {
+	for i, v := range list {
+		if m, ok := v.(map[string]interface{}); ok && equalValue(m[mergeKey], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, v := range list {
+		if equalValue(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValue compares merge keys, numbers are compared by value so that a
+// port written as 8080 matches one encoded as 8080.0.
+func equalValue(a, b interface{}) bool {
+	if x, ok := a.(json.Number); ok {
+		if y, ok := b.(json.Number); ok {
+			fx, errX := x.Float64()
+			fy, errY := y.Float64()
+			if errX == nil && errY == nil {
+				return fx == fy
+			}
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func stripDirective(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k != directiveMarker {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// LookupPatchMetadata returns the type of the field of t encoded as key,
+// with its patch strategy and merge key. Map types return their value type.
+// It returns a nil type when t is unknown or has no such field.
+func LookupPatchMetadata(t reflect.Type, key string) (reflect.Type, string, string) {
+	t = elemType(t)
+	if t == nil {
+		return nil, "", ""
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Elem(), "", ""
+	case reflect.Struct:
+	default:
+		return nil, "", ""
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if len(f.PkgPath) != 0 && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && len(name) == 0 {
+			// inlined struct, its fields are fields of t
+			if ft, strategy, mergeKey := LookupPatchMetadata(f.Type, key); ft != nil {
+				return ft, strategy, mergeKey
+			}
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		if name == key {
+			return f.Type, f.Tag.Get("patchStrategy"), f.Tag.Get("patchMergeKey")
+		}
+	}
+	return nil, "", ""
+}
+
+// elemType dereferences pointer types.
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func sliceElemType(t reflect.Type) reflect.Type {
+	t = elemType(t)
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return nil
+	}
+	return t.Elem()
+}
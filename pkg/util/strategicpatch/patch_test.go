@@ -0,0 +1,241 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategicpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	v1 "github.com/opencarry/carry/pkg/apis/carry.i/v1"
+)
+
+const originalPod = `{
+	"metadata": {"name": "web", "labels": {"app": "web", "tier": "front"}},
+	"spec": {
+		"containers": [
+			{
+				"name": "web",
+				"image": "web:1",
+				"command": ["web"],
+				"env": [{"name": "A", "value": "1"}, {"name": "B", "value": "2"}],
+				"ports": [{"name": "http", "container_port": 80}, {"name": "admin", "container_port": 9090}]
+			},
+			{"name": "sidecar", "image": "sidecar:1", "command": ["sidecar"]}
+		],
+		"volumes": [{"name": "config", "configMap": {"name": "web-config"}}],
+		"node_selector": {"zone": "a"}
+	},
+	"status": {
+		"phase": "running",
+		"conditions": [{"type": "pod_scheduled", "state": "true"}, {"type": "ready", "state": "false"}]
+	}
+}`
+
+func jsonEqual(t *testing.T, expected string, got []byte) {
+	t.Helper()
+	var e, g interface{}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(e, g) {
+		t.Errorf("expected %s\ngot %s", expected, got)
+	}
+}
+
+func TestStrategicMergePatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patch    string
+		expected string
+	}{
+		{
+			name:  "merge container by name",
+			patch: `{"spec": {"containers": [{"name": "web", "image": "web:2"}]}}`,
+			expected: `{
+				"metadata": {"name": "web", "labels": {"app": "web", "tier": "front"}},
+				"spec": {
+					"containers": [
+						{
+							"name": "web",
+							"image": "web:2",
+							"command": ["web"],
+							"env": [{"name": "A", "value": "1"}, {"name": "B", "value": "2"}],
+							"ports": [{"name": "http", "container_port": 80}, {"name": "admin", "container_port": 9090}]
+						},
+						{"name": "sidecar", "image": "sidecar:1", "command": ["sidecar"]}
+					],
+					"volumes": [{"name": "config", "configMap": {"name": "web-config"}}],
+					"node_selector": {"zone": "a"}
+				},
+				"status": {
+					"phase": "running",
+					"conditions": [{"type": "pod_scheduled", "state": "true"}, {"type": "ready", "state": "false"}]
+				}
+			}`,
+		},
+		{
+			name: "merge env by name and ports by container_port",
+			patch: `{"spec": {"containers": [{
+				"name": "web",
+				"env": [{"name": "B", "value": "3"}, {"name": "C", "value": "4"}],
+				"ports": [{"container_port": 9090, "name": "metrics"}]
+			}]}}`,
+			expected: `{
+				"metadata": {"name": "web", "labels": {"app": "web", "tier": "front"}},
+				"spec": {
+					"containers": [
+						{
+							"name": "web",
+							"image": "web:1",
+							"command": ["web"],
+							"env": [{"name": "A", "value": "1"}, {"name": "B", "value": "3"}, {"name": "C", "value": "4"}],
+							"ports": [{"name": "http", "container_port": 80}, {"name": "metrics", "container_port": 9090}]
+						},
+						{"name": "sidecar", "image": "sidecar:1", "command": ["sidecar"]}
+					],
+					"volumes": [{"name": "config", "configMap": {"name": "web-config"}}],
+					"node_selector": {"zone": "a"}
+				},
+				"status": {
+					"phase": "running",
+					"conditions": [{"type": "pod_scheduled", "state": "true"}, {"type": "ready", "state": "false"}]
+				}
+			}`,
+		},
+		{
+			name: "merge conditions by type, add volume, delete container",
+			patch: `{
+				"spec": {
+					"containers": [{"name": "sidecar", "$patch": "delete"}],
+					"volumes": [{"name": "data", "configMap": {"name": "web-data"}}]
+				},
+				"status": {"conditions": [{"type": "ready", "state": "true"}]}
+			}`,
+			expected: `{
+				"metadata": {"name": "web", "labels": {"app": "web", "tier": "front"}},
+				"spec": {
+					"containers": [
+						{
+							"name": "web",
+							"image": "web:1",
+							"command": ["web"],
+							"env": [{"name": "A", "value": "1"}, {"name": "B", "value": "2"}],
+							"ports": [{"name": "http", "container_port": 80}, {"name": "admin", "container_port": 9090}]
+						}
+					],
+					"volumes": [{"name": "config", "configMap": {"name": "web-config"}}, {"name": "data", "configMap": {"name": "web-data"}}],
+					"node_selector": {"zone": "a"}
+				},
+				"status": {
+					"phase": "running",
+					"conditions": [{"type": "pod_scheduled", "state": "true"}, {"type": "ready", "state": "true"}]
+				}
+			}`,
+		},
+		{
+			name: "replace directives and null",
+			patch: `{
+				"metadata": {"labels": {"tier": null}},
+				"spec": {
+					"containers": [{"name": "only", "image": "only:1", "command": ["only"]}, {"$patch": "replace"}],
+					"volumes": null,
+					"node_selector": {"$patch": "replace", "zone": "b"},
+					"suspended": true
+				},
+				"status": {"conditions": [{"$patch": "replace"}]}
+			}`,
+			expected: `{
+				"metadata": {"name": "web", "labels": {"app": "web"}},
+				"spec": {
+					"containers": [{"name": "only", "image": "only:1", "command": ["only"]}],
+					"node_selector": {"zone": "b"},
+					"suspended": true
+				},
+				"status": {"phase": "running", "conditions": null}
+			}`,
+		},
+		{
+			name:  "delete map",
+			patch: `{"spec": {"node_selector": {"$patch": "delete"}}, "status": {"taint_restarts": 1}}`,
+			expected: `{
+				"metadata": {"name": "web", "labels": {"app": "web", "tier": "front"}},
+				"spec": {
+					"containers": [
+						{
+							"name": "web",
+							"image": "web:1",
+							"command": ["web"],
+							"env": [{"name": "A", "value": "1"}, {"name": "B", "value": "2"}],
+							"ports": [{"name": "http", "container_port": 80}, {"name": "admin", "container_port": 9090}]
+						},
+						{"name": "sidecar", "image": "sidecar:1", "command": ["sidecar"]}
+					],
+					"volumes": [{"name": "config", "configMap": {"name": "web-config"}}]
+				},
+				"status": {
+					"phase": "running",
+					"taint_restarts": 1,
+					"conditions": [{"type": "pod_scheduled", "state": "true"}, {"type": "ready", "state": "false"}]
+				}
+			}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := StrategicMergePatch([]byte(originalPod), []byte(tc.patch), &v1.Pod{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			jsonEqual(t, tc.expected, got)
+		})
+	}
+}
+
+func TestStrategicMergePatchUnknownLists(t *testing.T) {
+	// lists without a merge strategy are replaced like in a JSON merge patch
+	got, err := StrategicMergePatch([]byte(originalPod), []byte(`{"spec": {"containers": [{"name": "web", "command": ["web", "-v"]}]}}`), &v1.Pod{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &v1.Pod{}
+	if err := json.Unmarshal(got, pod); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pod.Spec.Containers[0].Command, []string{"web", "-v"}) {
+		t.Errorf("expected the command to be replaced, got %v", pod.Spec.Containers[0].Command)
+	}
+}
+
+func TestStrategicMergePatchErrors(t *testing.T) {
+	for _, patch := range []string{
+		`{"spec": {"containers": [{"image": "web:2"}]}}`,
+		`{"spec": {"containers": ["web"]}}`,
+		`{"spec": {"node_selector": {"$patch": "bogus"}}}`,
+		`[]`,
+	} {
+		if _, err := StrategicMergePatch([]byte(originalPod), []byte(patch), &v1.Pod{}); err == nil {
+			t.Errorf("expected an error for %s", patch)
+		}
+	}
+	if _, err := StrategicMergePatch([]byte(originalPod), []byte(`{}`), "pod"); err == nil {
+		t.Errorf("expected an error for a dataStruct that is not a struct")
+	}
+}
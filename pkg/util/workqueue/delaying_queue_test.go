@@ -0,0 +1,168 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workqueue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/opencarry/carry/pkg/util/wait"
+)
+
+// waitForAdded waits until the queue holds depth items.
+func waitForAdded(q DelayingInterface, depth int) error {
+	deadline := time.Now().Add(wait.ForeverTestTimeout)
+	for q.Len() != depth {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %d items, have %d", depth, q.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+func TestSimpleQueue(t *testing.T) {
+	q := NewDelayingQueue()
+	defer q.ShutDown()
+
+	first := "foo"
+
+	q.AddAfter(first, 50*time.Millisecond)
+	if q.Len() != 0 {
+		t.Errorf("should not have added")
+	}
+
+	if err := waitForAdded(q, 1); err != nil {
+		t.Errorf("should have added")
+	}
+	item, _ := q.Get()
+	q.Done(item)
+
+	// step past the next heartbeat
+	time.Sleep(100 * time.Millisecond)
+	if q.Len() != 0 {
+		t.Errorf("should not have added")
+	}
+}
+
+func TestDeduping(t *testing.T) {
+	q := NewDelayingQueue()
+	defer q.ShutDown()
+
+	first := "foo"
+
+	q.AddAfter(first, time.Hour)
+	q.AddAfter(first, 50*time.Millisecond)
+	q.AddAfter(first, 2*time.Hour)
+
+	// 只保留最早的到期时间
+	if err := waitForAdded(q, 1); err != nil {
+		t.Fatalf("should have added")
+	}
+	item, _ := q.Get()
+	q.Done(item)
+
+	time.Sleep(100 * time.Millisecond)
+	if q.Len() != 0 {
+		t.Errorf("should not have added the later deadlines again")
+	}
+}
+
+func TestAddTwoFireEarly(t *testing.T) {
+	q := NewDelayingQueue()
+	defer q.ShutDown()
+
+	first := "foo"
+	second := "bar"
+	third := "baz"
+
+	q.AddAfter(first, time.Hour)
+	q.AddAfter(second, 50*time.Millisecond)
+	if err := waitForAdded(q, 1); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	item, _ := q.Get()
+	if item != second {
+		t.Errorf("expected %v, got %v", second, item)
+	}
+
+	q.AddAfter(third, 0)
+	if item, _ := q.Get(); item != third {
+		t.Errorf("expected %v, got %v", third, item)
+	}
+	if q.Len() != 0 {
+		t.Errorf("expected the hour long entry to still be waiting")
+	}
+}
+
+func TestCopyShifting(t *testing.T) {
+	q := NewDelayingQueue()
+	defer q.ShutDown()
+
+	first := "foo"
+	second := "bar"
+	third := "baz"
+
+	q.AddAfter(first, 150*time.Millisecond)
+	q.AddAfter(second, 100*time.Millisecond)
+	q.AddAfter(third, 50*time.Millisecond)
+
+	if err := waitForAdded(q, 3); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	for _, expected := range []string{third, second, first} {
+		if actual, _ := q.Get(); actual != expected {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestDelayingShutDownWithDrain(t *testing.T) {
+	q := NewDelayingQueue()
+	q.Add("foo")
+	q.AddAfter("bar", time.Hour)
+	item, _ := q.Get()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.ShutDownWithDrain()
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected ShutDownWithDrain to wait for the item being processed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	q.Done(item)
+	<-done
+
+	q.AddAfter("baz", 0)
+	if _, shutdown := q.Get(); !shutdown {
+		t.Error("expected the queue to be shut down")
+	}
+}
+
+func BenchmarkDelayingQueue_AddAfter(b *testing.B) {
+	q := NewDelayingQueue()
+	defer q.ShutDown()
+
+	for n := 0; n < b.N; n++ {
+		q.AddAfter(fmt.Sprintf("%d", n), time.Duration(n%100)*time.Millisecond)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workqueue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitingQueue(t *testing.T) {
+	limiter := NewItemExponentialFailureRateLimiter(10*time.Millisecond, time.Second)
+	queue := NewRateLimitingQueue(limiter)
+	defer queue.ShutDown()
+
+	queue.AddRateLimited("one")
+	queue.AddRateLimited("one")
+	queue.AddRateLimited("two")
+	if e, a := 2, queue.NumRequeues("one"); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+
+	if err := waitForAdded(queue, 2); err != nil {
+		t.Fatal(err)
+	}
+	item, _ := queue.Get()
+	queue.Forget(item)
+	queue.Done(item)
+	if e, a := 0, queue.NumRequeues(item); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
+type testMetric struct {
+	lock          sync.Mutex
+	inc           int64
+	dec           int64
+	set           float64
+	observedValue float64
+	observedCount int
+	notifyCh      chan<- struct{}
+}
+
+func (m *testMetric) Inc() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.inc++
+}
+
+func (m *testMetric) Dec() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.dec++
+}
+
+func (m *testMetric) Set(f float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.set = f
+}
+
+func (m *testMetric) Observe(f float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.observedValue = f
+	m.observedCount++
+}
+
+func (m *testMetric) gaugeValue() float64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.set != 0 {
+		return m.set
+	}
+	return float64(m.inc - m.dec)
+}
+
+type testMetricsProvider struct {
+	depth      testMetric
+	adds       testMetric
+	latency    testMetric
+	duration   testMetric
+	unfinished testMetric
+	longest    testMetric
+	retries    testMetric
+}
+
+func (m *testMetricsProvider) NewDepthMetric(name string) GaugeMetric {
+	return &m.depth
+}
+
+func (m *testMetricsProvider) NewAddsMetric(name string) CounterMetric {
+	return &m.adds
+}
+
+func (m *testMetricsProvider) NewLatencyMetric(name string) HistogramMetric {
+	return &m.latency
+}
+
+func (m *testMetricsProvider) NewWorkDurationMetric(name string) HistogramMetric {
+	return &m.duration
+}
+
+func (m *testMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) SettableGaugeMetric {
+	return &m.unfinished
+}
+
+func (m *testMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) SettableGaugeMetric {
+	return &m.longest
+}
+
+func (m *testMetricsProvider) NewRetriesMetric(name string) CounterMetric {
+	return &m.retries
+}
+
+type fakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Step(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestMetrics(t *testing.T) {
+	mp := &testMetricsProvider{}
+	factory := &queueMetricsFactory{metricsProvider: mp}
+	clock := &fakeClock{now: time.Now()}
+	// 用一个很长的周期，由测试自己调用updateUnfinishedWork
+	q := newQueue(factory.newQueueMetrics("test", clock.Now), time.Hour)
+	defer q.ShutDown()
+
+	q.Add("foo")
+	if e, a := 1.0, mp.adds.gaugeValue(); e != a {
+		t.Errorf("expected %v adds, got %v", e, a)
+	}
+	if e, a := 1.0, mp.depth.gaugeValue(); e != a {
+		t.Errorf("expected depth %v, got %v", e, a)
+	}
+
+	clock.Step(50 * time.Microsecond)
+
+	// Start processing
+	i, _ := q.Get()
+	if i != "foo" {
+		t.Errorf("Expected %v, got %v", "foo", i)
+	}
+	if e, a := 5e-05, mp.latency.observedValue; e != a {
+		t.Errorf("expected latency %v, got %v", e, a)
+	}
+	if e, a := 0.0, mp.depth.gaugeValue(); e != a {
+		t.Errorf("expected depth %v, got %v", e, a)
+	}
+
+	// Add it back while processing; multiple adds of the same item are
+	// de-duped.
+	q.Add(i)
+	q.Add(i)
+	q.Add(i)
+	if e, a := 2.0, mp.adds.gaugeValue(); e != a {
+		t.Errorf("expected %v adds, got %v", e, a)
+	}
+	// One thing remains in the queue
+	if e, a := 1.0, mp.depth.gaugeValue(); e != a {
+		t.Errorf("expected depth %v, got %v", e, a)
+	}
+
+	clock.Step(25 * time.Microsecond)
+	q.cond.L.Lock()
+	q.metrics.updateUnfinishedWork()
+	q.cond.L.Unlock()
+	if e, a := 2.5e-05, mp.unfinished.gaugeValue(); e != a {
+		t.Errorf("expected unfinished work %v, got %v", e, a)
+	}
+	if e, a := 2.5e-05, mp.longest.gaugeValue(); e != a {
+		t.Errorf("expected longest running processor %v, got %v", e, a)
+	}
+
+	// Finish it up
+	q.Done(i)
+	if e, a := 2.5e-05, mp.duration.observedValue; e != a {
+		t.Errorf("expected work duration %v, got %v", e, a)
+	}
+
+	// One thing remains in the queue
+	if e, a := 1.0, mp.depth.gaugeValue(); e != a {
+		t.Errorf("expected depth %v, got %v", e, a)
+	}
+	// It should be back on the queue
+	i, _ = q.Get()
+	if i != "foo" {
+		t.Errorf("Expected %v, got %v", "foo", i)
+	}
+	if e, a := 2.5e-05, mp.latency.observedValue; e != a {
+		t.Errorf("expected latency %v, got %v", e, a)
+	}
+	if e, a := 2, mp.latency.observedCount; e != a {
+		t.Errorf("expected %v latency observations, got %v", e, a)
+	}
+	if e, a := 0.0, mp.depth.gaugeValue(); e != a {
+		t.Errorf("expected depth %v, got %v", e, a)
+	}
+
+	// 延迟重新入队计为重试
+	dq := newDelayingQueue(clock.Now, q, factory.newRetryMetrics("test"))
+	defer dq.ShutDown()
+	dq.AddAfter("bar", 0)
+	dq.AddAfter("baz", time.Hour)
+	if e, a := 2.0, mp.retries.gaugeValue(); e != a {
+		t.Errorf("expected %v retries, got %v", e, a)
+	}
+}
+
+func TestUnnamedQueueHasNoMetrics(t *testing.T) {
+	factory := &queueMetricsFactory{metricsProvider: &testMetricsProvider{}}
+	if _, ok := factory.newQueueMetrics("", time.Now).(noMetrics); !ok {
+		t.Error("expected no metrics for an unnamed queue")
+	}
+	// nil的retry metrics可以安全调用
+	factory.newRetryMetrics("").retry()
+}
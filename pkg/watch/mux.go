@@ -0,0 +1,202 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"sync"
+
+	"github.com/opencarry/carry/pkg/runtime"
+)
+
+// Broadcaster distributes event notifications among any number of watchers.
+//
+// Every watcher has its own queue of at most queueLength events, drained by
+// a goroutine of its own, so Action never blocks on a slow watcher. A watcher
+// whose queue is full is stopped and its result channel closed; the consumer
+// is expected to start a new watch from the last resource version it saw.
+type Broadcaster struct {
+	lock sync.Mutex
+
+	watchers    map[int64]*broadcasterWatcher
+	nextWatcher int64
+	queueLength int
+	stopped     bool
+}
+
+// NewBroadcaster creates a new Broadcaster. queueLength is the maximum number of
+// events buffered for each watcher.
+func NewBroadcaster(queueLength int) *Broadcaster {
+	return &Broadcaster{
+		watchers:    map[int64]*broadcasterWatcher{},
+		queueLength: queueLength,
+	}
+}
+
+// Watch adds a new watcher to the list and returns an Interface for it.
+func (m *Broadcaster) Watch() Interface {
+	return m.WatchWithFilter(nil, nil)
+}
+
+// WatchWithPrefix adds a new watcher to the list and returns an Interface for it. It sends
+// queuedEvents down the new watch before beginning to send ordinary events from Broadcaster.
+func (m *Broadcaster) WatchWithPrefix(queuedEvents []Event) Interface {
+	return m.WatchWithFilter(queuedEvents, nil)
+}
+
+// WatchWithFilter is like WatchWithPrefix but passes every event, including
+// queuedEvents, through filter first. filter is called with the broadcaster
+// lock held and must not block.
+func (m *Broadcaster) WatchWithFilter(queuedEvents []Event, filter FilterFunc) Interface {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	w := &broadcasterWatcher{
+		m:       m,
+		id:      m.nextWatcher,
+		filter:  filter,
+		result:  make(chan Event),
+		notify:  make(chan struct{}, 1),
+		stopped: make(chan struct{}),
+	}
+	m.nextWatcher++
+	go w.run()
+
+	if m.stopped {
+		w.stop()
+		return w
+	}
+	m.watchers[w.id] = w
+	for _, e := range queuedEvents {
+		if !w.push(e) {
+			m.closeWatcher(w.id)
+			break
+		}
+	}
+	return w
+}
+
+// Action distributes the given event among all watchers. It never blocks.
+func (m *Broadcaster) Action(action EventType, obj runtime.Object) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	event := Event{Type: action, Object: obj}
+	for id, w := range m.watchers {
+		if !w.push(event) {
+			m.closeWatcher(id)
+		}
+	}
+}
+
+// Shutdown disconnects all watchers, events not yet delivered are dropped.
+// Watchers added after Shutdown are closed immediately.
+func (m *Broadcaster) Shutdown() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stopped = true
+	for id := range m.watchers {
+		m.closeWatcher(id)
+	}
+}
+
+// closeWatcher removes the watcher with id. Must be called with the lock held.
+func (m *Broadcaster) closeWatcher(id int64) {
+	if w, ok := m.watchers[id]; ok {
+		delete(m.watchers, id)
+		w.stop()
+	}
+}
+
+// broadcasterWatcher handles a single watcher of a broadcaster.
+type broadcasterWatcher struct {
+	m      *Broadcaster
+	id     int64
+	filter FilterFunc
+	result chan Event
+
+	lock  sync.Mutex
+	queue []Event
+	// notify is signalled when an event is queued
+	notify   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// push queues e for delivery. It returns false if the queue is full.
+func (w *broadcasterWatcher) push(e Event) bool {
+	if w.filter != nil {
+		var keep bool
+		if e, keep = w.filter(e); !keep {
+			return true
+		}
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.queue) >= w.m.queueLength {
+		return false
+	}
+	w.queue = append(w.queue, e)
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// run delivers the queued events to the result channel until the watcher is stopped.
+func (w *broadcasterWatcher) run() {
+	defer close(w.result)
+	for {
+		w.lock.Lock()
+		if len(w.queue) == 0 {
+			w.lock.Unlock()
+			select {
+			case <-w.notify:
+				continue
+			case <-w.stopped:
+				return
+			}
+		}
+		e := w.queue[0]
+		w.queue[0] = Event{}
+		w.queue = w.queue[1:]
+		w.lock.Unlock()
+
+		select {
+		case w.result <- e:
+		case <-w.stopped:
+			return
+		}
+	}
+}
+
+// ResultChan returns a channel to use for waiting on events.
+func (w *broadcasterWatcher) ResultChan() <-chan Event {
+	return w.result
+}
+
+// Stop stops watching and removes w from the list of watchers.
+func (w *broadcasterWatcher) Stop() {
+	w.m.lock.Lock()
+	defer w.m.lock.Unlock()
+	w.m.closeWatcher(w.id)
+	w.stop()
+}
+
+func (w *broadcasterWatcher) stop() {
+	w.stopOnce.Do(func() { close(w.stopped) })
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opencarry/carry/pkg/runtime"
+	"github.com/opencarry/carry/pkg/runtime/schema"
+)
+
+const testTimeout = 5 * time.Second
+
+type myType struct {
+	ID    string
+	Value string
+}
+
+func (obj *myType) GetObjectKind() schema.ObjectKind { return schema.EmptyObjectKind }
+func (obj *myType) DeepCopyObject() runtime.Object {
+	if obj == nil {
+		return nil
+	}
+	clone := *obj
+	return &clone
+}
+
+func receive(t *testing.T, w Interface) (Event, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-w.ResultChan():
+		return event, ok
+	case <-time.After(testTimeout):
+		t.Fatalf("timed out waiting for an event")
+	}
+	return Event{}, false
+}
+
+func TestBroadcaster(t *testing.T) {
+	table := []Event{
+		{Added, &myType{"foo", "hello world 1"}},
+		{Added, &myType{"bar", "hello world 2"}},
+		{Modified, &myType{"foo", "goodbye world 3"}},
+		{Deleted, &myType{"bar", "hello world 4"}},
+	}
+
+	m := NewBroadcaster(len(table) + 1)
+	watchers := []Interface{m.Watch(), m.Watch(), m.WatchWithPrefix(table[:1])}
+	for _, item := range table {
+		m.Action(item.Type, item.Object)
+	}
+
+	for i, w := range watchers {
+		expected := table
+		if i == 2 {
+			expected = append(table[:1:1], table...)
+		}
+		for j, item := range expected {
+			event, ok := receive(t, w)
+			if !ok {
+				t.Fatalf("watcher %d: closed early", i)
+			}
+			if event.Type != item.Type || event.Object != item.Object {
+				t.Errorf("watcher %d: expected event %d to be %v, got %v", i, j, item, event)
+			}
+		}
+	}
+
+	m.Shutdown()
+	for i, w := range watchers {
+		if _, ok := receive(t, w); ok {
+			t.Errorf("watcher %d: expected closed result channel after shutdown", i)
+		}
+	}
+}
+
+func TestBroadcasterSlowWatcher(t *testing.T) {
+	m := NewBroadcaster(2)
+	slow := m.Watch()
+	fast := m.Watch()
+
+	// the slow watcher never reads, Action must not block on it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			m.Action(Added, &myType{ID: "foo"})
+			if _, ok := <-fast.ResultChan(); !ok {
+				t.Errorf("fast watcher closed")
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(testTimeout):
+		t.Fatalf("Action blocked on a slow watcher")
+	}
+
+	// the slow watcher was closed once its queue overflowed
+	for {
+		_, ok := receive(t, slow)
+		if !ok {
+			break
+		}
+	}
+	slow.Stop()
+	fast.Stop()
+}
+
+func TestBroadcasterWatchWithFilter(t *testing.T) {
+	m := NewBroadcaster(10)
+	w := m.WatchWithFilter(nil, func(in Event) (Event, bool) {
+		obj := in.Object.(*myType)
+		if obj.ID != "foo" {
+			return in, false
+		}
+		return Event{Type: Modified, Object: obj}, true
+	})
+	m.Action(Added, &myType{ID: "bar"})
+	m.Action(Added, &myType{ID: "foo"})
+
+	event, _ := receive(t, w)
+	if event.Type != Modified || event.Object.(*myType).ID != "foo" {
+		t.Errorf("unexpected event %v", event)
+	}
+	w.Stop()
+	if _, ok := receive(t, w); ok {
+		t.Errorf("expected closed result channel after stop")
+	}
+}